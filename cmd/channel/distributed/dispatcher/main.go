@@ -4,9 +4,11 @@ import (
 	"flag"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -20,6 +22,7 @@ import (
 	dispatcherhealth "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/health"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	kncontroller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	eventingmetrics "knative.dev/pkg/metrics"
@@ -33,10 +36,11 @@ const (
 
 // Variables
 var (
-	logger     *zap.Logger
-	dispatcher dispatch.Dispatcher
-	serverURL  = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
-	kubeconfig = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	logger         *zap.Logger
+	dispatcher     dispatch.Dispatcher
+	dispatcherPool *dispatch.DispatcherPool // Only Set When Dispatching A Pool Of KafkaChannels (DISPATCHER_POOL_SELECTOR)
+	serverURL      = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	kubeconfig     = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 )
 
 // The Main Function (Go Command)
@@ -62,7 +66,7 @@ func main() {
 	}
 
 	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap
-	saramaConfig, _, err := sarama.LoadSettings(ctx)
+	saramaConfig, ekConfig, err := sarama.LoadSettings(ctx)
 	if err != nil {
 		logger.Fatal("Failed To Load Sarama Settings", zap.Error(err))
 	}
@@ -90,17 +94,32 @@ func main() {
 
 	// Create The Dispatcher With Specified Configuration
 	dispatcherConfig := dispatch.DispatcherConfig{
-		Logger:        logger,
-		ClientId:      Component,
-		Brokers:       strings.Split(environment.KafkaBrokers, ","),
-		Topic:         environment.KafkaTopic,
-		Username:      environment.KafkaUsername,
-		Password:      environment.KafkaPassword,
-		ChannelKey:    environment.ChannelKey,
-		StatsReporter: statsReporter,
-		SaramaConfig:  saramaConfig,
-	}
-	dispatcher = dispatch.NewDispatcher(dispatcherConfig)
+		Logger:           logger,
+		ClientId:         Component,
+		Brokers:          strings.Split(environment.KafkaBrokers, ","),
+		Topic:            environment.KafkaTopic,
+		Username:         environment.KafkaUsername,
+		Password:         environment.KafkaPassword,
+		ChannelKey:       environment.ChannelKey,
+		StatsReporter:    statsReporter,
+		DeliveryReporter: metrics.NewDeliveryReporter(logger),
+		SaramaConfig:     saramaConfig,
+	}
+
+	// Delete The ConsumerGroups Of Removed Subscribers After The Grace Period (Negative Disables, Zero Uses The Default)
+	gracePeriod := commonconsumer.DefaultGroupCleanupGracePeriod
+	if ekConfig != nil && ekConfig.Dispatcher.ConsumerGroupCleanupGracePeriodMillis != 0 {
+		gracePeriod = time.Duration(ekConfig.Dispatcher.ConsumerGroupCleanupGracePeriodMillis) * time.Millisecond
+	}
+	dispatcherConfig.GroupCleaner = dispatch.NewGroupCleaner(dispatcherConfig, gracePeriod)
+
+	// Create A Dispatcher Pool For The Selected KafkaChannels, Otherwise A Dispatcher For The Single KafkaChannel
+	if environment.DispatcherPoolSelector != "" {
+		logger.Info("Dispatching A Pool Of KafkaChannels", zap.String("Selector", environment.DispatcherPoolSelector))
+		dispatcherPool = dispatch.NewDispatcherPool(dispatcherConfig)
+	} else {
+		dispatcher = dispatch.NewDispatcher(dispatcherConfig)
+	}
 
 	// Watch The Settings ConfigMap For Changes
 	err = commonconfig.InitializeConfigWatcher(ctx, logger.Sugar(), configMapObserver)
@@ -118,23 +137,22 @@ func main() {
 	config.Burst = numControllers * rest.DefaultBurst
 	kafkaClientSet := versioned.NewForConfigOrDie(config)
 	kubeClient := kubernetes.NewForConfigOrDie(config)
-	kafkaInformerFactory := externalversions.NewSharedInformerFactory(kafkaClientSet, kncontroller.DefaultResyncPeriod)
 
-	// Create KafkaChannel Informer
+	// Create KafkaChannel Informer (Limited To The Dispatcher Pool's KafkaChannels If Dispatching A Pool)
+	kafkaInformerFactory := externalversions.NewSharedInformerFactoryWithOptions(kafkaClientSet, kncontroller.DefaultResyncPeriod,
+		externalversions.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = environment.DispatcherPoolSelector
+		}))
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
 
 	// Construct Array Of Controllers, In Our Case Just The One
-	controllers := [...]*kncontroller.Impl{
-		controller.NewController(
-			logger,
-			environment.ChannelKey,
-			dispatcher,
-			kafkaChannelInformer,
-			kubeClient,
-			kafkaClientSet,
-			ctx.Done(),
-		),
+	var channelController *kncontroller.Impl
+	if dispatcherPool != nil {
+		channelController = controller.NewPoolController(logger, dispatcherPool, kafkaChannelInformer, kubeClient, kafkaClientSet, ctx.Done())
+	} else {
+		channelController = controller.NewController(logger, environment.ChannelKey, dispatcher, kafkaChannelInformer, kubeClient, kafkaClientSet, ctx.Done())
 	}
+	controllers := [...]*kncontroller.Impl{channelController}
 
 	// Start The Informers
 	logger.Info("Starting informers.")
@@ -155,8 +173,12 @@ func main() {
 	// Reset The Liveness and Readiness Flags In Preparation For Shutdown
 	healthServer.Shutdown()
 
-	// Shutdown The Dispatcher (Close ConsumerGroups)
-	dispatcher.Shutdown()
+	// Shutdown The Dispatcher(s) (Close ConsumerGroups)
+	if dispatcherPool != nil {
+		dispatcherPool.Shutdown()
+	} else {
+		dispatcher.Shutdown()
+	}
 
 	// Stop The Liveness And Readiness Servers
 	healthServer.Stop(logger)
@@ -174,6 +196,12 @@ func configMapObserver(configMap *v1.ConfigMap) {
 		return
 	}
 
+	if dispatcherPool != nil {
+		// The dispatcher pool recreates the dispatchers of all its KafkaChannels as needed
+		dispatcherPool.ConfigChanged(configMap)
+		return
+	}
+
 	if dispatcher == nil {
 		// This typically happens during startup
 		logger.Info("Dispatcher is nil during call to configMapObserver; ignoring changes")
@@ -153,8 +153,8 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 		return err
 	}
 
-	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic)
-	err = kafkaProducer.ProduceKafkaMessage(ctx, channelReference, message, transformers...)
+	// Produce The CloudEvent Binding Message (Send To The Channel's Kafka Topic, Keyed By The Channel's Partition Key Attribute)
+	err = kafkaProducer.ProduceKafkaMessage(ctx, channel.TopicName(channelReference), channel.PartitionKeyAttribute(channelReference), message, transformers...)
 	if err != nil {
 		logger.Error("Failed To Produce Kafka Message", zap.Error(err))
 		return err
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/webhook/settings"
+	"knative.dev/eventing/pkg/logconfig"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
+	"knative.dev/pkg/webhook"
+	"knative.dev/pkg/webhook/certificates"
+)
+
+// Create The Admission Controller Validating The Eventing-Kafka Settings ConfigMap
+func NewSettingsValidationController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+	return settings.NewAdmissionController(ctx,
+		// Name Of The ValidatingWebhookConfiguration
+		"config.webhook.eventing-kafka.knative.dev",
+		// Path On Which To Serve The Webhook
+		"/config-validation",
+	)
+}
+
+// Eventing-Kafka Webhook Main
+func main() {
+
+	// Set Up A Signal Context With The Webhook Options
+	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
+		ServiceName: logconfig.WebhookName(),
+		Port:        webhook.PortFromEnv(8443),
+		SecretName:  "eventing-kafka-channel-webhook-certs",
+	})
+
+	// Create The SharedMain Instance With The Certificate & Settings Validation Controllers
+	sharedmain.WebhookMainWithContext(ctx, "eventing-kafka-channel-webhook",
+		certificates.NewController,
+		NewSettingsValidationController,
+	)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The dlq command lists the events on a KafkaChannel dead letter topic and replays selected
+// events back into the topics of their KafkaChannels.
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+)
+
+var (
+	brokers      = flag.String("brokers", "", "Comma separated list of Kafka bootstrap servers (required).")
+	topic        = flag.String("topic", "", "The dead letter topic (required).")
+	subscriber   = flag.String("subscriber", "", "Only include the events dead lettered for the subscriber with this UID.")
+	replay       = flag.String("replay", "", "Replay the events with these comma separated <partition>:<offset> identifiers, or \"all\", instead of listing.")
+	username     = flag.String("username", "", "SASL PLAIN username.")
+	password     = flag.String("password", "", "SASL PLAIN password.")
+	enableTLS    = flag.Bool("tls", false, "Connect to the Kafka bootstrap servers with TLS.")
+	kafkaVersion = flag.String("kafka-version", "2.0.0", "The Kafka protocol version.")
+)
+
+func main() {
+	flag.Parse()
+	if *brokers == "" || *topic == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	config, err := newConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := sarama.NewClient(strings.Split(*brokers, ","), config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", *brokers, err)
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	// Select the events to replay (if any)
+	var selection dlq.Selection
+	replayAll := *replay == "all"
+	if *replay != "" && !replayAll {
+		if selection, err = dlq.ParseSelection(*replay); err != nil {
+			return err
+		}
+	}
+
+	// Read the dead letter topic, listing or collecting the events to replay
+	var entries []dlq.Entry
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if *replay == "" {
+		fmt.Fprintln(writer, "PARTITION\tOFFSET\tSUBSCRIBER\tATTEMPTS\tCHANNEL TOPIC\tORIGINAL\tERROR")
+	}
+	err = dlq.Read(client, consumer, *topic, func(entry dlq.Entry) error {
+		if *subscriber != "" && entry.Subscriber != *subscriber {
+			return nil
+		}
+		if *replay == "" {
+			fmt.Fprintf(writer, "%d\t%d\t%s\t%d\t%s\t%s/%d/%d\t%s\n", entry.Partition, entry.Offset, entry.Subscriber,
+				entry.Attempts, entry.ChannelTopic, entry.OriginalTopic, entry.OriginalPartition, entry.OriginalOffset, entry.Error)
+		} else if replayAll || selection.Contains(entry) {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if *replay == "" {
+		return writer.Flush()
+	}
+
+	// Replay the selected events
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create producer: %w", err)
+	}
+	defer producer.Close()
+	if err := dlq.Replay(producer, entries); err != nil {
+		return err
+	}
+	fmt.Printf("Replayed %d event(s)\n", len(entries))
+	return nil
+}
+
+func newConfig() (*sarama.Config, error) {
+	version, err := sarama.ParseKafkaVersion(*kafkaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka version %q: %w", *kafkaVersion, err)
+	}
+	config := sarama.NewConfig()
+	config.Version = version
+	config.ClientID = "kafkachannel-dlq"
+	config.Consumer.Return.Errors = true
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	if *username != "" {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = *username
+		config.Net.SASL.Password = *password
+	}
+	if *enableTLS {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return config, nil
+}
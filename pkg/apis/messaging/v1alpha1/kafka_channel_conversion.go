@@ -87,8 +87,11 @@ func (source *KafkaChannel) ConvertTo(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = v1beta1.KafkaChannelSpec{
-			NumPartitions:     source.Spec.NumPartitions,
-			ReplicationFactor: source.Spec.ReplicationFactor,
+			NumPartitions:       source.Spec.NumPartitions,
+			ReplicationFactor:   source.Spec.ReplicationFactor,
+			Topic:               (*v1beta1.KafkaChannelTopic)(source.Spec.Topic.DeepCopy()),
+			TopicDeletionPolicy: v1beta1.TopicDeletionPolicy(source.Spec.TopicDeletionPolicy),
+			Dispatcher:          (*v1beta1.KafkaChannelDispatcher)(source.Spec.Dispatcher.DeepCopy()),
 			ChannelableSpec: eventingduckv1.ChannelableSpec{
 				SubscribableSpec: subscribableSpec,
 				// no delivery in v1alpha1
@@ -171,9 +174,12 @@ func (sink *KafkaChannel) ConvertFrom(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = KafkaChannelSpec{
-			NumPartitions:     source.Spec.NumPartitions,
-			ReplicationFactor: source.Spec.ReplicationFactor,
-			Subscribable:      &subscribableSpec,
+			NumPartitions:       source.Spec.NumPartitions,
+			ReplicationFactor:   source.Spec.ReplicationFactor,
+			Topic:               (*KafkaChannelTopic)(source.Spec.Topic.DeepCopy()),
+			TopicDeletionPolicy: string(source.Spec.TopicDeletionPolicy),
+			Dispatcher:          (*KafkaChannelDispatcher)(source.Spec.Dispatcher.DeepCopy()),
+			Subscribable:        &subscribableSpec,
 		}
 		sink.Status = KafkaChannelStatus{
 			Status: source.Status.Status,
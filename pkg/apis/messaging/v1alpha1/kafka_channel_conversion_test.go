@@ -89,8 +89,11 @@ func TestKafkaChannelConversionRoundTripV1alpha1(t *testing.T) {
 				Generation: 17,
 			},
 			Spec: KafkaChannelSpec{
-				NumPartitions:     1,
-				ReplicationFactor: 2,
+				NumPartitions:       1,
+				ReplicationFactor:   2,
+				Topic:               &KafkaChannelTopic{Name: "existing-topic", Adopt: true},
+				TopicDeletionPolicy: "Retain",
+				Dispatcher:          &KafkaChannelDispatcher{Replicas: pointer.Int32Ptr(3), NodeSelector: map[string]string{"pool": "kafka"}},
 				Subscribable: &eventingduckv1alpha1.Subscribable{
 					Subscribers: []eventingduckv1alpha1.SubscriberSpec{
 						{
@@ -212,8 +215,11 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 				Generation: 17,
 			},
 			Spec: v1beta1.KafkaChannelSpec{
-				NumPartitions:     117,
-				ReplicationFactor: 118,
+				NumPartitions:       117,
+				ReplicationFactor:   118,
+				Topic:               &v1beta1.KafkaChannelTopic{Name: "existing-topic", Adopt: true},
+				TopicDeletionPolicy: v1beta1.TopicDeletionPolicyRetain,
+				Dispatcher:          &v1beta1.KafkaChannelDispatcher{Replicas: pointer.Int32Ptr(3), NodeSelector: map[string]string{"pool": "kafka"}},
 				ChannelableSpec: v1.ChannelableSpec{
 					SubscribableSpec: v1.SubscribableSpec{
 						Subscribers: []eventingduckv1.SubscriberSpec{
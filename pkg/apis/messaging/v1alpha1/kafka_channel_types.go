@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -59,10 +60,51 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// Topic is an existing Kafka topic adopted by the channel instead of the topic created for it.
+	// +optional
+	Topic *KafkaChannelTopic `json:"topic,omitempty"`
+
+	// TopicDeletionPolicy determines whether the topic of the channel is deleted with the channel.
+	// +optional
+	TopicDeletionPolicy string `json:"topicDeletionPolicy,omitempty"`
+
+	// Dispatcher overrides the configuration of the channel's dispatcher.
+	// +optional
+	Dispatcher *KafkaChannelDispatcher `json:"dispatcher,omitempty"`
+
 	// KafkaChannel conforms to Duck type Subscribable.
 	Subscribable *eventingduck.Subscribable `json:"subscribable,omitempty"`
 }
 
+// KafkaChannelTopic identifies an existing Kafka topic backing a KafkaChannel.
+type KafkaChannelTopic struct {
+	// Name is the name of the existing Kafka topic.
+	Name string `json:"name"`
+
+	// Adopt acknowledges that the channel uses the existing topic without owning it.
+	Adopt bool `json:"adopt"`
+}
+
+// KafkaChannelDispatcher overrides the cluster wide configuration of the dispatcher of a single
+// KafkaChannel.
+type KafkaChannelDispatcher struct {
+	// Replicas is the number of dispatcher replicas.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the compute resources of the dispatcher container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the dispatcher pods to the nodes with the specified labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the dispatcher pods to be scheduled onto nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// inherits duck/v1 Status, which currently provides:
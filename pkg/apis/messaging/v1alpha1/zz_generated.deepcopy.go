@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -21,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 )
@@ -53,6 +55,46 @@ func (in *KafkaChannel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelDispatcher) DeepCopyInto(out *KafkaChannelDispatcher) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelDispatcher.
+func (in *KafkaChannelDispatcher) DeepCopy() *KafkaChannelDispatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelDispatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelList) DeepCopyInto(out *KafkaChannelList) {
 	*out = *in
@@ -89,6 +131,16 @@ func (in *KafkaChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelSpec) DeepCopyInto(out *KafkaChannelSpec) {
 	*out = *in
+	if in.Topic != nil {
+		in, out := &in.Topic, &out.Topic
+		*out = new(KafkaChannelTopic)
+		**out = **in
+	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(KafkaChannelDispatcher)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Subscribable != nil {
 		in, out := &in.Subscribable, &out.Subscribable
 		*out = new(duckv1alpha1.Subscribable)
@@ -125,3 +177,19 @@ func (in *KafkaChannelStatus) DeepCopy() *KafkaChannelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelTopic) DeepCopyInto(out *KafkaChannelTopic) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelTopic.
+func (in *KafkaChannelTopic) DeepCopy() *KafkaChannelTopic {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelTopic)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -86,6 +86,11 @@ func (cs *KafkaChannelStatus) InitializeConditions() {
 	kc.Manage(cs).InitializeConditions()
 }
 
+// SetDeadLetterSinkURI sets the URI the dead letter sink of the channel's delivery spec resolves to.
+func (cs *KafkaChannelStatus) SetDeadLetterSinkURI(uri *apis.URL) {
+	cs.DeadLetterSinkURI = uri
+}
+
 // SetAddress sets the address (as part of Addressable contract) and marks the correct condition.
 func (cs *KafkaChannelStatus) SetAddress(url *apis.URL) {
 	if cs.Address == nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -67,14 +68,82 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// Topic is an existing Kafka topic adopted by the channel instead of the topic created for it.
+	// An adopted topic is verified rather than created and is not deleted with the channel.
+	// +optional
+	Topic *KafkaChannelTopic `json:"topic,omitempty"`
+
+	// TopicDeletionPolicy determines whether the topic of the channel is deleted with the channel,
+	// the policy configured for the cluster (Delete by default) applies when it is empty.
+	// +optional
+	TopicDeletionPolicy TopicDeletionPolicy `json:"topicDeletionPolicy,omitempty"`
+
+	// Dispatcher overrides the configuration of the channel's dispatcher, it is only supported by
+	// the distributed channel which runs a dispatcher per channel, and not by the channels sharing
+	// the dispatcher of a pool (labelled with DispatcherPoolLabel).
+	// +optional
+	Dispatcher *KafkaChannelDispatcher `json:"dispatcher,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
 
+// KafkaChannelTopic identifies an existing Kafka topic backing a KafkaChannel.
+type KafkaChannelTopic struct {
+	// Name is the name of the existing Kafka topic.
+	Name string `json:"name"`
+
+	// Adopt acknowledges that the channel uses the existing topic without owning it, it must be true.
+	Adopt bool `json:"adopt"`
+}
+
+// KafkaChannelDispatcher overrides the cluster wide configuration of the dispatcher of a single
+// KafkaChannel, the fields which are not specified keep the cluster wide value.
+type KafkaChannelDispatcher struct {
+	// Replicas is the number of dispatcher replicas.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the compute resources of the dispatcher container, the specified limits and
+	// requests replace those of the same resource in the cluster wide configuration.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the dispatcher pods to the nodes with the specified labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the dispatcher pods to be scheduled onto nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// TopicDeletionPolicy determines what happens to the topic of a KafkaChannel when the channel is
+// deleted.
+type TopicDeletionPolicy string
+
+const (
+	// TopicDeletionPolicyDelete deletes the topic, and the events it holds, with the channel.
+	TopicDeletionPolicyDelete TopicDeletionPolicy = "Delete"
+
+	// TopicDeletionPolicyRetain keeps the topic when the channel is deleted, a later channel with
+	// the same name uses the retained topic again.
+	TopicDeletionPolicyRetain TopicDeletionPolicy = "Retain"
+)
+
+// DispatcherPoolLabel selects the dispatcher pool shared by a (low traffic) KafkaChannel of the
+// distributed channel instead of a dedicated dispatcher.
+const DispatcherPoolLabel = "eventing-kafka.knative.dev/dispatcher-pool"
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableStatus `json:",inline"`
+
+	// DeadLetterSinkURI is the URI the dead letter sink of the channel's delivery spec resolves to,
+	// used by the subscribers that do not specify a delivery spec of their own.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -92,6 +161,44 @@ func (c *KafkaChannel) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("KafkaChannel")
 }
 
+// AdoptedTopicName returns the name of the existing Kafka topic adopted by the channel, or an
+// empty string if the channel uses the topic created for it.
+func (c *KafkaChannel) AdoptedTopicName() string {
+	if c.Spec.Topic == nil || !c.Spec.Topic.Adopt {
+		return ""
+	}
+	return c.Spec.Topic.Name
+}
+
+// TopicDeletionPolicyOrDefault returns the deletion policy of the channel's topic, falling back to
+// the specified default policy, and to Delete when both are empty.
+func (c *KafkaChannel) TopicDeletionPolicyOrDefault(defaultPolicy TopicDeletionPolicy) TopicDeletionPolicy {
+	if c.Spec.TopicDeletionPolicy != "" {
+		return c.Spec.TopicDeletionPolicy
+	}
+	if defaultPolicy != "" {
+		return defaultPolicy
+	}
+	return TopicDeletionPolicyDelete
+}
+
+// SubscriberDelivery returns the delivery spec of the specified subscriber, falling back to the
+// delivery spec of the channel when the subscriber specifies none. The dead letter sink of the
+// channel is replaced with the URI it was resolved to in the status, and is omitted until resolved.
+func (c *KafkaChannel) SubscriberDelivery(sub eventingduck.SubscriberSpec) *eventingduck.DeliverySpec {
+	if sub.Delivery != nil || c.Spec.Delivery == nil {
+		return sub.Delivery
+	}
+	delivery := c.Spec.Delivery.DeepCopy()
+	if delivery.DeadLetterSink != nil {
+		delivery.DeadLetterSink = nil
+		if c.Status.DeadLetterSinkURI != nil {
+			delivery.DeadLetterSink = &duckv1.Destination{URI: c.Status.DeadLetterSinkURI.DeepCopy()}
+		}
+	}
+	return delivery
+}
+
 // GetStatus retrieves the duck status for this resource. Implements the KRShaped interface.
 func (k *KafkaChannel) GetStatus() *duckv1.Status {
 	return &k.Status.Status
@@ -21,7 +21,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestKafkaChannel_GetGroupVersionKind(t *testing.T) {
@@ -47,3 +49,70 @@ func TestKafkaChannelGetStatus(t *testing.T) {
 		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
 	}
 }
+
+func TestKafkaChannelSubscriberDelivery(t *testing.T) {
+	channelDLS := apis.HTTP("channel-dls.example.com")
+	subscriberDLS := apis.HTTP("subscriber-dls.example.com")
+	exponential := eventingduck.BackoffPolicyExponential
+	subscriberDelivery := &eventingduck.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{URI: subscriberDLS},
+		Retry:          ptr.Int32(1),
+	}
+	channelDelivery := &eventingduck.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{
+			Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "channel-dls"},
+		},
+		Retry:         ptr.Int32(5),
+		BackoffDelay:  ptr.String("PT1S"),
+		BackoffPolicy: &exponential,
+	}
+
+	testCases := map[string]struct {
+		channelDelivery   *eventingduck.DeliverySpec
+		deadLetterSinkURI *apis.URL
+		sub               eventingduck.SubscriberSpec
+		want              *eventingduck.DeliverySpec
+	}{
+		"no delivery": {
+			sub:  eventingduck.SubscriberSpec{},
+			want: nil,
+		},
+		"subscriber delivery": {
+			channelDelivery:   channelDelivery,
+			deadLetterSinkURI: channelDLS,
+			sub:               eventingduck.SubscriberSpec{Delivery: subscriberDelivery},
+			want:              subscriberDelivery,
+		},
+		"channel delivery": {
+			channelDelivery:   channelDelivery,
+			deadLetterSinkURI: channelDLS,
+			sub:               eventingduck.SubscriberSpec{},
+			want: &eventingduck.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: channelDLS},
+				Retry:          ptr.Int32(5),
+				BackoffDelay:   ptr.String("PT1S"),
+				BackoffPolicy:  &exponential,
+			},
+		},
+		"channel delivery with unresolved dead letter sink": {
+			channelDelivery: channelDelivery,
+			sub:             eventingduck.SubscriberSpec{},
+			want: &eventingduck.DeliverySpec{
+				Retry:         ptr.Int32(5),
+				BackoffDelay:  ptr.String("PT1S"),
+				BackoffPolicy: &exponential,
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			c := &KafkaChannel{}
+			c.Spec.Delivery = tc.channelDelivery
+			c.Status.DeadLetterSinkURI = tc.deadLetterSinkURI
+			if diff := cmp.Diff(tc.want, c.SubscriberDelivery(tc.sub)); diff != "" {
+				t.Errorf("unexpected delivery (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
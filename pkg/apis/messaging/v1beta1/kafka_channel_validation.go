@@ -19,14 +19,39 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
 )
 
+const (
+	// maxTopicNameLength is the maximum length of a Kafka topic name.
+	maxTopicNameLength = 249
+)
+
+// topicNameRegexp matches the characters allowed in a Kafka topic name.
+var topicNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
 func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 	errs := c.Spec.Validate(ctx).ViaField("spec")
 
+	// The channels of a dispatcher pool share its dispatcher, which they can not override
+	if pool := c.Labels[DispatcherPoolLabel]; pool != "" && c.Spec.Dispatcher != nil {
+		fe := apis.ErrDisallowedFields("dispatcher")
+		fe.Details = fmt.Sprintf("the channel shares the dispatcher of the %q pool", pool)
+		errs = errs.Also(fe.ViaField("spec"))
+	}
+
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*KafkaChannel); ok {
+			errs = errs.Also(c.CheckImmutableFields(ctx, original))
+		}
+	}
+
 	// Validate annotations
 	if c.Annotations != nil {
 		if scope, ok := c.Annotations[eventing.ScopeAnnotationKey]; ok {
@@ -54,6 +79,22 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(fe)
 	}
 
+	if cs.Topic != nil {
+		errs = errs.Also(cs.Topic.Validate(ctx).ViaField("topic"))
+	}
+
+	switch cs.TopicDeletionPolicy {
+	case "", TopicDeletionPolicyDelete, TopicDeletionPolicyRetain:
+	default:
+		fe := apis.ErrInvalidValue(cs.TopicDeletionPolicy, "topicDeletionPolicy")
+		fe.Details = "expected either 'Delete' or 'Retain'"
+		errs = errs.Also(fe)
+	}
+
+	if cs.Dispatcher != nil {
+		errs = errs.Also(cs.Dispatcher.Validate(ctx).ViaField("dispatcher"))
+	}
+
 	for i, subscriber := range cs.SubscribableSpec.Subscribers {
 		if subscriber.ReplyURI == nil && subscriber.SubscriberURI == nil {
 			fe := apis.ErrMissingField("replyURI", "subscriberURI")
@@ -63,3 +104,148 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 	return errs
 }
+
+func (ct *KafkaChannelTopic) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if ct.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if len(ct.Name) > maxTopicNameLength || !topicNameRegexp.MatchString(ct.Name) || ct.Name == "." || ct.Name == ".." {
+		fe := apis.ErrInvalidValue(ct.Name, "name")
+		fe.Details = fmt.Sprintf("expected at most %d of the characters [a-zA-Z0-9._-]", maxTopicNameLength)
+		errs = errs.Also(fe)
+	}
+
+	if !ct.Adopt {
+		fe := apis.ErrInvalidValue(ct.Adopt, "adopt")
+		fe.Details = "expected true, the channel only supports adopting an existing topic"
+		errs = errs.Also(fe)
+	}
+	return errs
+}
+
+func (cd *KafkaChannelDispatcher) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if cd.Replicas != nil && *cd.Replicas <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*cd.Replicas, "replicas"))
+	}
+
+	if cd.Resources != nil {
+		errs = errs.Also(validateResources(cd.Resources).ViaField("resources"))
+	}
+
+	for key, value := range cd.NodeSelector {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, "nodeSelector", msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			fe := apis.ErrInvalidValue(value, apis.CurrentField)
+			fe.Details = msg
+			errs = errs.Also(fe.ViaFieldKey("nodeSelector", key))
+		}
+	}
+
+	for i, toleration := range cd.Tolerations {
+		errs = errs.Also(validateToleration(toleration).ViaFieldIndex("tolerations", i))
+	}
+	return errs
+}
+
+// validateResources checks that the quantities of the resource requirements are not negative, and
+// that no request exceeds the limit of the same resource.
+func validateResources(resources *corev1.ResourceRequirements) *apis.FieldError {
+	var errs *apis.FieldError
+
+	for name, quantity := range resources.Limits {
+		if quantity.Sign() < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(quantity.String(), apis.CurrentField).ViaFieldKey("limits", string(name)))
+		}
+	}
+
+	for name, quantity := range resources.Requests {
+		if quantity.Sign() < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(quantity.String(), apis.CurrentField).ViaFieldKey("requests", string(name)))
+		} else if limit, ok := resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			fe := apis.ErrInvalidValue(quantity.String(), apis.CurrentField)
+			fe.Details = fmt.Sprintf("expected at most the limit of %s", limit.String())
+			errs = errs.Also(fe.ViaFieldKey("requests", string(name)))
+		}
+	}
+	return errs
+}
+
+// validateToleration checks the toleration the way the API server checks the tolerations of a pod.
+func validateToleration(toleration corev1.Toleration) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if toleration.Key != "" {
+		for _, msg := range validation.IsQualifiedName(toleration.Key) {
+			fe := apis.ErrInvalidValue(toleration.Key, "key")
+			fe.Details = msg
+			errs = errs.Also(fe)
+		}
+	}
+
+	switch toleration.Operator {
+	case corev1.TolerationOpEqual, "":
+		if toleration.Key == "" {
+			fe := apis.ErrInvalidValue(toleration.Operator, "operator")
+			fe.Details = "expected 'Exists' when the key is empty"
+			errs = errs.Also(fe)
+		}
+		for _, msg := range validation.IsValidLabelValue(toleration.Value) {
+			fe := apis.ErrInvalidValue(toleration.Value, "value")
+			fe.Details = msg
+			errs = errs.Also(fe)
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			fe := apis.ErrInvalidValue(toleration.Value, "value")
+			fe.Details = "expected an empty value when the operator is 'Exists'"
+			errs = errs.Also(fe)
+		}
+	default:
+		fe := apis.ErrInvalidValue(toleration.Operator, "operator")
+		fe.Details = "expected either 'Equal' or 'Exists'"
+		errs = errs.Also(fe)
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		fe := apis.ErrInvalidValue(toleration.Effect, "effect")
+		fe.Details = "expected one of 'NoSchedule', 'PreferNoSchedule' or 'NoExecute'"
+		errs = errs.Also(fe)
+	}
+
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		fe := apis.ErrInvalidValue(*toleration.TolerationSeconds, "tolerationSeconds")
+		fe.Details = "expected only with the 'NoExecute' effect"
+		errs = errs.Also(fe)
+	}
+	return errs
+}
+
+// CheckImmutableFields checks that the topic of the channel is not changed after its creation,
+// since the events of the channel are not moved between topics.
+func (c *KafkaChannel) CheckImmutableFields(ctx context.Context, original *KafkaChannel) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	if diff, err := kmp.ShortDiff(original.Spec.Topic, c.Spec.Topic); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff KafkaChannel",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec.topic"},
+			Details: diff,
+		}
+	}
+	return nil
+}
@@ -18,15 +18,20 @@ package v1beta1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/webhook/resourcesemantics"
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestKafkaChannelValidation(t *testing.T) {
@@ -131,6 +136,200 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return errs
 			}(),
 		},
+		"adopted topic": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Topic:             &KafkaChannelTopic{Name: "existing.topic-1", Adopt: true},
+				},
+			},
+			want: nil,
+		},
+		"topic without name": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Topic:             &KafkaChannelTopic{Adopt: true},
+				},
+			},
+			want: apis.ErrMissingField("spec.topic.name"),
+		},
+		"topic with invalid name": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Topic:             &KafkaChannelTopic{Name: "not/valid", Adopt: true},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("not/valid", "spec.topic.name")
+				fe.Details = "expected at most 249 of the characters [a-zA-Z0-9._-]"
+				return fe
+			}(),
+		},
+		"topic not adopted": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Topic:             &KafkaChannelTopic{Name: "existing-topic"},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(false, "spec.topic.adopt")
+				fe.Details = "expected true, the channel only supports adopting an existing topic"
+				return fe
+			}(),
+		},
+		"retained topic": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:       1,
+					ReplicationFactor:   1,
+					TopicDeletionPolicy: TopicDeletionPolicyRetain,
+				},
+			},
+			want: nil,
+		},
+		"invalid topic deletion policy": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:       1,
+					ReplicationFactor:   1,
+					TopicDeletionPolicy: "Orphan",
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("Orphan", "spec.topicDeletionPolicy")
+				fe.Details = "expected either 'Delete' or 'Retain'"
+				return fe
+			}(),
+		},
+		"dispatcher overrides": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher: &KafkaChannelDispatcher{
+						Replicas: ptr.Int32(3),
+						Resources: &corev1.ResourceRequirements{
+							Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+						},
+						NodeSelector: map[string]string{"node.kubernetes.io/pool": "kafka"},
+						Tolerations: []corev1.Toleration{
+							{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "kafka", Effect: corev1.TaintEffectNoSchedule},
+							{Operator: corev1.TolerationOpExists},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid dispatcher replicas": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher:        &KafkaChannelDispatcher{Replicas: ptr.Int32(0)},
+				},
+			},
+			want: apis.ErrInvalidValue(int32(0), "spec.dispatcher.replicas"),
+		},
+		"dispatcher request exceeds limit": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher: &KafkaChannelDispatcher{
+						Resources: &corev1.ResourceRequirements{
+							Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("1Gi", "spec.dispatcher.resources.requests[memory]")
+				fe.Details = "expected at most the limit of 128Mi"
+				return fe
+			}(),
+		},
+		"invalid dispatcher node selector": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher: &KafkaChannelDispatcher{
+						NodeSelector: map[string]string{"pool": "not valid"},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("not valid", "spec.dispatcher.nodeSelector[pool]")
+				fe.Details = strings.Join(validation.IsValidLabelValue("not valid"), "")
+				return fe
+			}(),
+		},
+		"invalid dispatcher toleration": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher: &KafkaChannelDispatcher{
+						Tolerations: []corev1.Toleration{{
+							Key:               "dedicated",
+							Operator:          corev1.TolerationOpExists,
+							Value:             "kafka",
+							Effect:            corev1.TaintEffectNoSchedule,
+							TolerationSeconds: ptr.Int64(60),
+						}},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrInvalidValue("kafka", "spec.dispatcher.tolerations[0].value")
+				fe.Details = "expected an empty value when the operator is 'Exists'"
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue(int64(60), "spec.dispatcher.tolerations[0].tolerationSeconds")
+				fe.Details = "expected only with the 'NoExecute' effect"
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		"pooled channel dispatcher overrides": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{DispatcherPoolLabel: "low-traffic"},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Dispatcher:        &KafkaChannelDispatcher{Replicas: ptr.Int32(3)},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrDisallowedFields("spec.dispatcher")
+				fe.Details = `the channel shares the dispatcher of the "low-traffic" pool`
+				return fe
+			}(),
+		},
+		"pooled channel": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{DispatcherPoolLabel: "low-traffic"},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
 		"invalid scope annotation": {
 			cr: &KafkaChannel{
 				ObjectMeta: metav1.ObjectMeta{
@@ -160,3 +359,58 @@ func TestKafkaChannelValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaChannelImmutableTopic(t *testing.T) {
+	channel := func(topic *KafkaChannelTopic) *KafkaChannel {
+		return &KafkaChannel{
+			Spec: KafkaChannelSpec{
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+				Topic:             topic,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		original *KafkaChannel
+		updated  *KafkaChannel
+		wantErr  bool
+	}{
+		"unchanged topic": {
+			original: channel(&KafkaChannelTopic{Name: "existing-topic", Adopt: true}),
+			updated:  channel(&KafkaChannelTopic{Name: "existing-topic", Adopt: true}),
+		},
+		"unchanged without topic": {
+			original: channel(nil),
+			updated:  channel(nil),
+		},
+		"changed topic": {
+			original: channel(&KafkaChannelTopic{Name: "existing-topic", Adopt: true}),
+			updated:  channel(&KafkaChannelTopic{Name: "other-topic", Adopt: true}),
+			wantErr:  true,
+		},
+		"added topic": {
+			original: channel(nil),
+			updated:  channel(&KafkaChannelTopic{Name: "existing-topic", Adopt: true}),
+			wantErr:  true,
+		},
+		"removed topic": {
+			original: channel(&KafkaChannelTopic{Name: "existing-topic", Adopt: true}),
+			updated:  channel(nil),
+			wantErr:  true,
+		},
+	}
+
+	for n, test := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := apis.WithinUpdate(context.Background(), test.original)
+			got := test.updated.Validate(ctx)
+			if (got != nil) != test.wantErr {
+				t.Errorf("Validate() = %v, wanted error %v", got, test.wantErr)
+			}
+			if got != nil && !strings.Contains(got.Error(), "Immutable fields changed (-old +new): spec.topic") {
+				t.Errorf("Validate() = %v, wanted an immutable spec.topic error", got)
+			}
+		})
+	}
+}
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -21,7 +22,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apis "knative.dev/pkg/apis"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -52,6 +55,46 @@ func (in *KafkaChannel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelDispatcher) DeepCopyInto(out *KafkaChannelDispatcher) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelDispatcher.
+func (in *KafkaChannelDispatcher) DeepCopy() *KafkaChannelDispatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelDispatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelList) DeepCopyInto(out *KafkaChannelList) {
 	*out = *in
@@ -88,6 +131,16 @@ func (in *KafkaChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelSpec) DeepCopyInto(out *KafkaChannelSpec) {
 	*out = *in
+	if in.Topic != nil {
+		in, out := &in.Topic, &out.Topic
+		*out = new(KafkaChannelTopic)
+		**out = **in
+	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(KafkaChannelDispatcher)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ChannelableSpec.DeepCopyInto(&out.ChannelableSpec)
 	return
 }
@@ -106,6 +159,11 @@ func (in *KafkaChannelSpec) DeepCopy() *KafkaChannelSpec {
 func (in *KafkaChannelStatus) DeepCopyInto(out *KafkaChannelStatus) {
 	*out = *in
 	in.ChannelableStatus.DeepCopyInto(&out.ChannelableStatus)
+	if in.DeadLetterSinkURI != nil {
+		in, out := &in.DeadLetterSinkURI, &out.DeadLetterSinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -118,3 +176,19 @@ func (in *KafkaChannelStatus) DeepCopy() *KafkaChannelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelTopic) DeepCopyInto(out *KafkaChannelTopic) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelTopic.
+func (in *KafkaChannelTopic) DeepCopy() *KafkaChannelTopic {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelTopic)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -20,38 +20,68 @@ import (
 	"errors"
 	"fmt"
 	nethttp "net/http"
+	"reflect"
 	"strings"
 	"sync"
-	"sync/atomic"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/partition"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
 
 type KafkaDispatcher struct {
-	hostToChannelMap atomic.Value
-	// hostToChannelMapLock is used to update hostToChannelMap
-	hostToChannelMapLock sync.Mutex
+	hostToChannelMap map[string]eventingchannels.ChannelReference
+	// channelHosts is the reverse of hostToChannelMap, to replace or remove the host of a channel.
+	channelHosts map[eventingchannels.ChannelReference]string
+	// partitionKeyAttributes names the attribute keying the records of each channel, the
+	// partitionkey extension by default.
+	partitionKeyAttributes map[eventingchannels.ChannelReference]string
+	// adoptedTopics names the existing topics of the channels which adopted one.
+	adoptedTopics map[eventingchannels.ChannelReference]string
+	// hostToChannelMapLock guards hostToChannelMap, channelHosts, partitionKeyAttributes and
+	// adoptedTopics
+	hostToChannelMapLock sync.RWMutex
 
 	receiver   *eventingchannels.MessageReceiver
 	dispatcher *eventingchannels.MessageDispatcherImpl
 
-	kafkaAsyncProducer   sarama.AsyncProducer
+	kafkaAsyncProducer sarama.AsyncProducer
+	// produceQueueTimeout is the time an event waits for room in the queue of kafkaAsyncProducer
+	// before it is rejected.
+	produceQueueTimeout time.Duration
+	// acknowledgerDone is closed when acknowledgeProduced stops acknowledging the produced messages.
+	acknowledgerDone chan struct{}
+	// kafkaSyncProducer writes undeliverable events to dead letter topics,
+	// it is created by the first subscription with a dead letter topic.
+	kafkaSyncProducer    sarama.SyncProducer
+	brokers              []string
+	config               *sarama.Config
 	channelSubscriptions map[eventingchannels.ChannelReference][]types.UID
 	subsConsumerGroups   map[types.UID]sarama.ConsumerGroup
 	subscriptions        map[types.UID]Subscription
+	// subsStartedFrom describes the start position offsets committed for new consumer groups.
+	subsStartedFrom map[types.UID]string
 	// consumerUpdateLock must be used to update kafkaConsumers
 	consumerUpdateLock   sync.Mutex
 	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
+	// groupCleaner deletes the consumer groups of removed subscriptions, nil keeps them.
+	groupCleaner *consumer.GroupCleaner
+	// ownsSubscription selects the subscriptions consumed by this replica, nil consumes all.
+	ownsSubscription func(uid types.UID) bool
 
 	topicFunc TopicFunc
 	logger    *zap.SugaredLogger
@@ -60,6 +90,15 @@ type KafkaDispatcher struct {
 type Subscription struct {
 	UID types.UID
 	fanout.Subscription
+	// DeadLetterTopic is the Kafka topic to which undeliverable events are written, if any.
+	DeadLetterTopic string
+	// Filter selects the events delivered to the subscriber, nil delivers all events.
+	Filter *filter.Filter
+	// StartPosition is the position from which a new consumer group starts consuming.
+	StartPosition consumer.StartPosition
+	// Delivery is the effective delivery spec the subscription's retries and dead letter sink are
+	// configured from, it detects delivery changes which the RetryConfig functions can not reveal.
+	Delivery *eventingduckv1.DeliverySpec
 }
 
 func (sub Subscription) String() string {
@@ -78,6 +117,18 @@ func (sub Subscription) String() string {
 		s.WriteString("DeadLetter: " + sub.DeadLetter.String())
 		s.WriteRune('\n')
 	}
+	if sub.DeadLetterTopic != "" {
+		s.WriteString("DeadLetterTopic: " + sub.DeadLetterTopic)
+		s.WriteRune('\n')
+	}
+	if sub.Filter != nil {
+		s.WriteString("Filter: " + sub.Filter.String())
+		s.WriteRune('\n')
+	}
+	if sub.StartPosition != "" {
+		s.WriteString("StartPosition: " + string(sub.StartPosition))
+		s.WriteRune('\n')
+	}
 	return s.String()
 }
 
@@ -86,6 +137,8 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 	conf.Version = sarama.V2_0_0_0
 	conf.ClientID = args.ClientID
 	conf.Consumer.Return.Errors = true // Returns the errors in ConsumerGroup#Errors() https://godoc.org/github.com/Shopify/sarama#ConsumerGroup
+	// Acknowledges the produced events in AsyncProducer#Successes(), which the ingress waits for
+	conf.Producer.Return.Successes = true
 
 	producer, err := sarama.NewAsyncProducer(args.Brokers, conf)
 	if err != nil {
@@ -95,30 +148,22 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 	dispatcher := &KafkaDispatcher{
 		dispatcher:           eventingchannels.NewMessageDispatcher(args.Logger.Desugar()),
 		kafkaConsumerFactory: consumer.NewConsumerGroupFactory(args.Brokers, conf),
+		groupCleaner:         consumer.NewGroupCleaner(args.Logger, args.Brokers, conf, args.ConsumerGroupCleanupGracePeriod, reportCleanupFailure),
+		ownsSubscription:     args.OwnsSubscription,
 		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
 		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
 		kafkaAsyncProducer:   producer,
+		produceQueueTimeout:  defaultProduceQueueTimeout,
+		acknowledgerDone:     make(chan struct{}),
+		brokers:              args.Brokers,
+		config:               conf,
 		logger:               args.Logger,
 		topicFunc:            args.TopicFunc,
 	}
 	receiverFunc, err := eventingchannels.NewMessageReceiver(
-		func(ctx context.Context, channel eventingchannels.ChannelReference, message binding.Message, transformers []binding.Transformer, _ nethttp.Header) error {
-			kafkaProducerMessage := sarama.ProducerMessage{
-				Topic: dispatcher.topicFunc(utils.KafkaChannelSeparator, channel.Namespace, channel.Name),
-			}
-
-			dispatcher.logger.Debugw("Received a new message from MessageReceiver, dispatching to Kafka", zap.Any("channel", channel))
-			err := protocolkafka.WriteProducerMessage(ctx, message, &kafkaProducerMessage, transformers...)
-			if err != nil {
-				return err
-			}
-
-			kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, serializeTrace(trace.FromContext(ctx).SpanContext())...)
-
-			dispatcher.kafkaAsyncProducer.Input() <- &kafkaProducerMessage
-			return nil
-		},
+		dispatcher.receive,
 		args.Logger.Desugar(),
 		eventingchannels.ResolveMessageChannelFromHostHeader(dispatcher.getChannelReferenceFromHost))
 	if err != nil {
@@ -130,6 +175,133 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 	return dispatcher, nil
 }
 
+// defaultProduceQueueTimeout is the default time an event waits for room in the producer queue.
+const defaultProduceQueueTimeout = 5 * time.Second
+
+// errProducerQueueFull rejects the events which can not be queued in time, so that the clients
+// retry them later instead of the ingress buffering them without bounds.
+var errProducerQueueFull = errors.New("the kafka producer queue is full")
+
+// errProducerClosed fails the queued events which are no longer acknowledged because the
+// dispatcher is stopping, they may still have been written.
+var errProducerClosed = errors.New("the kafka producer is closed")
+
+// ingressPort is the port on which the ingress receives the events sent to the channels.
+const ingressPort = 8080
+
+// ServeHTTP receives the events sent to the channels with the MessageReceiver, which answers every
+// failure with 500 Internal Server Error, answering the events rejected because the producer queue
+// is full with 503 Service Unavailable instead, as the clients only have to retry them later.
+func (d *KafkaDispatcher) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	writer := &ingressResponseWriter{ResponseWriter: response}
+	d.receiver.ServeHTTP(writer, request.WithContext(context.WithValue(request.Context(), ingressResponseWriterKey{}, writer)))
+}
+
+// ingressResponseWriterKey is the request context key of the ingressResponseWriter.
+type ingressResponseWriterKey struct{}
+
+// ingressResponseWriter answers with 503 Service Unavailable instead of 500 Internal Server Error
+// once the event is known to be rejected because the producer queue is full.
+type ingressResponseWriter struct {
+	nethttp.ResponseWriter
+	queueFull bool
+}
+
+func (w *ingressResponseWriter) WriteHeader(statusCode int) {
+	if w.queueFull && statusCode == nethttp.StatusInternalServerError {
+		statusCode = nethttp.StatusServiceUnavailable
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// receive is the MessageReceiver function writing the received events to the channels' topics.
+func (d *KafkaDispatcher) receive(ctx context.Context, channel eventingchannels.ChannelReference, message binding.Message, transformers []binding.Transformer, _ nethttp.Header) error {
+	err := d.produce(ctx, channel, message, transformers)
+	if err == errProducerQueueFull {
+		if writer, ok := ctx.Value(ingressResponseWriterKey{}).(*ingressResponseWriter); ok {
+			writer.queueFull = true
+		}
+	}
+	return err
+}
+
+// produceRequest is the metadata of a produced message, which correlates the acknowledgement of
+// the message with the request waiting for it.
+type produceRequest struct {
+	start time.Time
+	done  chan error
+}
+
+// produce writes the message to the channel's topic, waiting for the acknowledgement of Kafka so
+// that the ingress only accepts the events which have been written. Once queued, the message is
+// written even if the request is cancelled, so the acknowledgement is awaited regardless of the
+// request context: failing the request instead would have the client retry, and duplicate, an
+// event which has been written. The producer acknowledges every queued message, at the latest
+// when its retries are exhausted.
+func (d *KafkaDispatcher) produce(ctx context.Context, channel eventingchannels.ChannelReference, message binding.Message, transformers []binding.Transformer) error {
+	kafkaProducerMessage := sarama.ProducerMessage{
+		Topic: d.topicName(channel),
+	}
+
+	d.logger.Debugw("Received a new message from MessageReceiver, dispatching to Kafka", zap.Any("channel", channel))
+	err := partition.WriteProducerMessage(ctx, message, &kafkaProducerMessage, d.getPartitionKeyAttribute(channel), transformers...)
+	if err != nil {
+		return err
+	}
+
+	kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, serializeTrace(trace.FromContext(ctx).SpanContext())...)
+
+	request := &produceRequest{start: time.Now(), done: make(chan error, 1)}
+	kafkaProducerMessage.Metadata = request
+
+	timer := time.NewTimer(d.produceQueueTimeout)
+	defer timer.Stop()
+	select {
+	case d.kafkaAsyncProducer.Input() <- &kafkaProducerMessage:
+	case <-timer.C:
+		reportProduced(kafkaProducerMessage.Topic, time.Since(request.start), errProducerQueueFull)
+		return errProducerQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-request.done:
+		return err
+	case <-d.acknowledgerDone:
+		return errProducerClosed
+	}
+}
+
+// acknowledgeProduced completes the requests waiting for the messages produced by
+// kafkaAsyncProducer, until the context is done.
+func (d *KafkaDispatcher) acknowledgeProduced(ctx context.Context) {
+	defer close(d.acknowledgerDone)
+	for {
+		select {
+		case e := <-d.kafkaAsyncProducer.Errors():
+			d.acknowledge(e.Msg, e.Err)
+		case s := <-d.kafkaAsyncProducer.Successes():
+			d.acknowledge(s, nil)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// acknowledge completes the request waiting for the produced message.
+func (d *KafkaDispatcher) acknowledge(message *sarama.ProducerMessage, err error) {
+	if err != nil {
+		d.logger.Warnw("Failed to produce an event", zap.String("topic", message.Topic), zap.Error(err))
+	}
+	request, ok := message.Metadata.(*produceRequest)
+	if !ok {
+		return
+	}
+	reportProduced(message.Topic, time.Since(request.start), err)
+	request.done <- err
+}
+
 type TopicFunc func(separator, namespace, name string) string
 
 type KafkaDispatcherArgs struct {
@@ -138,12 +310,20 @@ type KafkaDispatcherArgs struct {
 	Brokers            []string
 	TopicFunc          TopicFunc
 	Logger             *zap.SugaredLogger
+	// ConsumerGroupCleanupGracePeriod is the time after which the consumer group of a removed
+	// subscription is deleted, a negative duration keeps the consumer groups.
+	ConsumerGroupCleanupGracePeriod time.Duration
+	// OwnsSubscription selects the subscriptions consumed by this replica when the dispatcher is
+	// sharded across several replicas, nil consumes all subscriptions.
+	OwnsSubscription func(uid types.UID) bool
 }
 
 type consumerMessageHandler struct {
 	logger     *zap.SugaredLogger
 	sub        Subscription
 	dispatcher *eventingchannels.MessageDispatcherImpl
+	// deadLetterProducer is only set when the subscription has a dead letter topic.
+	deadLetterProducer sarama.SyncProducer
 }
 
 func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
@@ -160,6 +340,18 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 		return false, errors.New("received a message with unknown encoding")
 	}
 
+	// Events which do not match the subscription's filter are committed without being delivered,
+	// while events which can't be evaluated are dispatched to fail as usual.
+	if match, err := c.sub.Filter.Match(ctx, message); err != nil {
+		c.logger.Warnw("Failed to evaluate the subscription's filter", zap.String("topic", consumerMessage.Topic), zap.Error(err))
+	} else if !match {
+		c.logger.Debugw("Skipping a message excluded by the subscription's filter", zap.String("topic", consumerMessage.Topic))
+		if err := reportFiltered(ctx, consumerMessage.Topic, c.sub.UID); err != nil {
+			c.logger.Warnw("Failed to report the filtered message", zap.Error(err))
+		}
+		return true, nil
+	}
+
 	c.logger.Debug("Going to dispatch the message",
 		zap.String("topic", consumerMessage.Topic),
 		zap.String("subscription", c.sub.String()),
@@ -168,6 +360,18 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 	ctx, span := startTraceFromMessage(c.logger, ctx, message, consumerMessage.Topic)
 	defer span.End()
 
+	// Count the attempts to the subscriber for the dead letter topic headers.
+	var attempts int32
+	retryConfig := c.sub.RetryConfig
+	if c.deadLetterProducer != nil {
+		countingRetryConfig := kncloudevents.NoRetries()
+		if retryConfig != nil {
+			countingRetryConfig = *retryConfig
+		}
+		countingRetryConfig.CheckRetry = dlq.CountAttempts(countingRetryConfig.CheckRetry, c.sub.Subscriber, &attempts)
+		retryConfig = &countingRetryConfig
+	}
+
 	err := c.dispatcher.DispatchMessageWithRetries(
 		ctx,
 		message,
@@ -175,13 +379,37 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 		c.sub.Subscriber,
 		c.sub.Reply,
 		c.sub.DeadLetter,
-		c.sub.RetryConfig,
+		retryConfig,
 	)
 
-	// NOTE: only return `true` here if DispatchMessage actually delivered the message.
+	if err != nil && c.deadLetterProducer != nil {
+		if attempts == 0 {
+			attempts = 1
+		}
+		err = c.writeDeadLetter(consumerMessage, int(attempts), err)
+	}
+
+	// NOTE: only return `true` here if DispatchMessage actually delivered the message
+	// (or it was written to the dead letter topic).
 	return err == nil, err
 }
 
+// writeDeadLetter writes the undeliverable message to the subscription's dead letter topic,
+// returning an error (including the delivery error) if that fails.
+func (c consumerMessageHandler) writeDeadLetter(consumerMessage *sarama.ConsumerMessage, attempts int, deliveryErr error) error {
+	deadLetterMessage := dlq.NewDeadLetterMessage(consumerMessage, c.sub.DeadLetterTopic, consumerMessage.Topic, c.sub.UID, attempts, deliveryErr)
+	if _, _, err := c.deadLetterProducer.SendMessage(deadLetterMessage); err != nil {
+		return fmt.Errorf("%v (failed to write to dead letter topic %s: %v)", deliveryErr, c.sub.DeadLetterTopic, err)
+	}
+	c.logger.Warnw("Wrote undeliverable message to the dead letter topic",
+		zap.String("topic", consumerMessage.Topic),
+		zap.String("deadLetterTopic", c.sub.DeadLetterTopic),
+		zap.Int("attempts", attempts),
+		zap.Error(deliveryErr),
+	)
+	return nil
+}
+
 var _ consumer.KafkaConsumerHandler = (*consumerMessageHandler)(nil)
 
 type Config struct {
@@ -194,9 +422,17 @@ type ChannelConfig struct {
 	Name          string
 	HostName      string
 	Subscriptions []Subscription
+	// PartitionKeyAttribute names the CloudEvent attribute keying the records written to the
+	// channel's topic, the partitionkey extension when empty.
+	PartitionKeyAttribute string
+	// Topic is the existing topic adopted by the channel, the topic named by the dispatcher's
+	// TopicFunc when empty.
+	Topic string
 }
 
-// UpdateKafkaConsumers will be called by new CRD based kafka channel dispatcher controller.
+// UpdateKafkaConsumers applies the subscriptions of all channels at once, unsubscribing the
+// subscriptions of the channels which are not in the config.  The dispatcher controller uses
+// ReconcileChannel and CleanupChannel instead, which only apply the delta of a single channel.
 func (d *KafkaDispatcher) UpdateKafkaConsumers(config *Config) (map[types.UID]error, error) {
 	if config == nil {
 		return nil, fmt.Errorf("nil config")
@@ -205,59 +441,154 @@ func (d *KafkaDispatcher) UpdateKafkaConsumers(config *Config) (map[types.UID]er
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
 
-	var newSubs []types.UID
 	failedToSubscribe := make(map[types.UID]error)
+	channels := make(map[eventingchannels.ChannelReference]bool, len(config.ChannelConfigs))
 	for _, cc := range config.ChannelConfigs {
 		channelRef := eventingchannels.ChannelReference{
 			Name:      cc.Name,
 			Namespace: cc.Namespace,
 		}
-		for _, subSpec := range cc.Subscriptions {
-			newSubs = append(newSubs, subSpec.UID)
-
-			// Check if sub already exists
-			exists := false
-			for _, s := range d.channelSubscriptions[channelRef] {
-				if s == subSpec.UID {
-					exists = true
-				}
-			}
+		channels[channelRef] = true
+		if err := d.reconcileSubscriptions(channelRef, cc.Subscriptions, failedToSubscribe); err != nil {
+			return nil, err
+		}
+	}
 
-			if !exists {
-				// only subscribe when not exists in channel-subscriptions map
-				// do not need to resubscribe every time channel fanout config is updated
-				if err := d.subscribe(channelRef, subSpec); err != nil {
-					failedToSubscribe[subSpec.UID] = err
-				}
+	// Unsubscribe and close consumer for any deleted channels
+	for channelRef := range d.channelSubscriptions {
+		if !channels[channelRef] {
+			if err := d.reconcileSubscriptions(channelRef, nil, failedToSubscribe); err != nil {
+				return nil, err
 			}
 		}
 	}
 
-	d.logger.Debug("Number of new subs", zap.Any("subs", len(newSubs)))
 	d.logger.Debug("Number of subs failed to subscribe", zap.Any("subs", len(failedToSubscribe)))
+	return failedToSubscribe, nil
+}
+
+// ReconcileChannel applies the configuration of a single channel, routing its host name to it,
+// subscribing its new subscriptions and unsubscribing its removed ones, without looking at any
+// other channel.  It returns the subscriptions of the channel which failed to subscribe.
+func (d *KafkaDispatcher) ReconcileChannel(config *ChannelConfig) (map[types.UID]error, error) {
+	if config == nil {
+		return nil, errors.New("nil channel config")
+	}
+	channelRef := eventingchannels.ChannelReference{
+		Name:      config.Name,
+		Namespace: config.Namespace,
+	}
+
+	if err := d.setChannelHost(channelRef, config.HostName); err != nil {
+		return nil, err
+	}
+	d.setPartitionKeyAttribute(channelRef, config.PartitionKeyAttribute)
+	d.setAdoptedTopic(channelRef, config.Topic)
+
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	failedToSubscribe := make(map[types.UID]error)
+	if err := d.reconcileSubscriptions(channelRef, config.Subscriptions, failedToSubscribe); err != nil {
+		return nil, err
+	}
+	return failedToSubscribe, nil
+}
+
+// CleanupChannel removes the host name of a deleted (or no longer ready) channel and unsubscribes
+// all of its subscriptions.  Cleaning up an unknown channel does nothing.
+func (d *KafkaDispatcher) CleanupChannel(name, namespace string) error {
+	channelRef := eventingchannels.ChannelReference{
+		Name:      name,
+		Namespace: namespace,
+	}
+
+	d.removeChannelHost(channelRef)
+	d.setPartitionKeyAttribute(channelRef, "")
+	d.setAdoptedTopic(channelRef, "")
+
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	return d.reconcileSubscriptions(channelRef, nil, nil)
+}
+
+// reconcileSubscriptions subscribes the subscriptions of the channel owned by this replica which
+// are not yet subscribed, resubscribes those whose configuration changed, recording failures in
+// failedToSubscribe, and unsubscribes the channel's other subscriptions.  reconcileSubscriptions
+// must be called under updateLock.
+func (d *KafkaDispatcher) reconcileSubscriptions(channelRef eventingchannels.ChannelReference, subs []Subscription, failedToSubscribe map[types.UID]error) error {
+	existing := make(map[types.UID]bool, len(d.channelSubscriptions[channelRef]))
+	for _, uid := range d.channelSubscriptions[channelRef] {
+		existing[uid] = true
+	}
 
-	// Unsubscribe and close consumer for any deleted subscriptions
-	for channelRef, subs := range d.channelSubscriptions {
-		for _, oldSub := range subs {
-			removedSub := true
-			for _, s := range newSubs {
-				if s == oldSub {
-					removedSub = false
-				}
+	inChannel := make(map[types.UID]bool, len(subs))
+	desired := make(map[types.UID]bool, len(subs))
+	for _, sub := range subs {
+		inChannel[sub.UID] = true
+		if d.ownsSubscription != nil && !d.ownsSubscription(sub.UID) {
+			continue
+		}
+		desired[sub.UID] = true
+		// only subscribe when not exists in channel-subscriptions map
+		// do not need to resubscribe every time channel fanout config is updated
+		if !existing[sub.UID] {
+			if err := d.subscribe(channelRef, sub); err != nil {
+				failedToSubscribe[sub.UID] = err
 			}
+		} else if subscriptionChanged(d.subscriptions[sub.UID], sub) {
+			if err := d.resubscribe(channelRef, sub); err != nil {
+				failedToSubscribe[sub.UID] = err
+			}
+		}
+	}
 
-			if removedSub {
-				if err := d.unsubscribe(channelRef, d.subscriptions[oldSub]); err != nil {
-					return nil, err
-				}
+	for uid := range existing {
+		if !desired[uid] {
+			// Subscriptions handed over to another replica keep their consumer group
+			if err := d.unsubscribe(channelRef, d.subscriptions[uid], !inChannel[uid]); err != nil {
+				return err
 			}
 		}
-		d.channelSubscriptions[channelRef] = newSubs
 	}
-	return failedToSubscribe, nil
+
+	if len(d.channelSubscriptions[channelRef]) == 0 {
+		delete(d.channelSubscriptions, channelRef)
+	}
+	return nil
 }
 
-// UpdateHostToChannelMap will be called by new CRD based kafka channel dispatcher controller.
+// subscriptionChanged returns whether the desired configuration of a subscribed subscription
+// differs from the configuration its consumer group was started with.
+func subscriptionChanged(subscribed, desired Subscription) bool {
+	return !reflect.DeepEqual(subscribed.Subscriber, desired.Subscriber) ||
+		!reflect.DeepEqual(subscribed.Reply, desired.Reply) ||
+		!reflect.DeepEqual(subscribed.DeadLetter, desired.DeadLetter) ||
+		subscribed.DeadLetterTopic != desired.DeadLetterTopic ||
+		!reflect.DeepEqual(subscribed.Filter, desired.Filter) ||
+		subscribed.StartPosition != desired.StartPosition ||
+		!equality.Semantic.DeepEqual(subscribed.Delivery, desired.Delivery)
+}
+
+// resubscribe restarts the consumer group of a subscribed subscription with its new configuration,
+// the consumer group keeps its committed offsets.  resubscribe must be called under updateLock.
+func (d *KafkaDispatcher) resubscribe(channelRef eventingchannels.ChannelReference, sub Subscription) error {
+	startedFrom, ok := d.subsStartedFrom[sub.UID]
+	if err := d.unsubscribe(channelRef, d.subscriptions[sub.UID], false); err != nil {
+		return err
+	}
+	if err := d.subscribe(channelRef, sub); err != nil {
+		return err
+	}
+	// The start position of the existing consumer group is not committed again
+	if _, restarted := d.subsStartedFrom[sub.UID]; ok && !restarted {
+		d.subsStartedFrom[sub.UID] = startedFrom
+	}
+	return nil
+}
+
+// UpdateHostToChannelMap replaces the host names of all channels at once.  The dispatcher
+// controller uses ReconcileChannel and CleanupChannel instead.
 func (d *KafkaDispatcher) UpdateHostToChannelMap(config *Config) error {
 	if config == nil {
 		return errors.New("nil config")
@@ -271,7 +602,22 @@ func (d *KafkaDispatcher) UpdateHostToChannelMap(config *Config) error {
 		return err
 	}
 
-	d.setHostToChannelMap(hcMap)
+	d.hostToChannelMap = hcMap
+	d.channelHosts = make(map[eventingchannels.ChannelReference]string, len(hcMap))
+	for host, channelRef := range hcMap {
+		d.channelHosts[channelRef] = host
+	}
+	d.partitionKeyAttributes = make(map[eventingchannels.ChannelReference]string)
+	d.adoptedTopics = make(map[eventingchannels.ChannelReference]string)
+	for _, cConfig := range config.ChannelConfigs {
+		channelRef := eventingchannels.ChannelReference{Name: cConfig.Name, Namespace: cConfig.Namespace}
+		if cConfig.PartitionKeyAttribute != "" {
+			d.partitionKeyAttributes[channelRef] = cConfig.PartitionKeyAttribute
+		}
+		if cConfig.Topic != "" {
+			d.adoptedTopics[channelRef] = cConfig.Topic
+		}
+	}
 	return nil
 }
 
@@ -279,19 +625,101 @@ func createHostToChannelMap(config *Config) (map[string]eventingchannels.Channel
 	hcMap := make(map[string]eventingchannels.ChannelReference, len(config.ChannelConfigs))
 	for _, cConfig := range config.ChannelConfigs {
 		if cr, ok := hcMap[cConfig.HostName]; ok {
-			return nil, fmt.Errorf(
-				"duplicate hostName found. Each channel must have a unique host header. HostName:%s, channel:%s.%s, channel:%s.%s",
-				cConfig.HostName,
-				cConfig.Namespace,
-				cConfig.Name,
-				cr.Namespace,
-				cr.Name)
+			return nil, duplicateHostNameError(cConfig.HostName, cConfig.Namespace, cConfig.Name, cr)
 		}
 		hcMap[cConfig.HostName] = eventingchannels.ChannelReference{Name: cConfig.Name, Namespace: cConfig.Namespace}
 	}
 	return hcMap, nil
 }
 
+// setChannelHost routes the host name to the channel, replacing the channel's previous host name.
+func (d *KafkaDispatcher) setChannelHost(channelRef eventingchannels.ChannelReference, host string) error {
+	d.hostToChannelMapLock.Lock()
+	defer d.hostToChannelMapLock.Unlock()
+
+	if cr, ok := d.hostToChannelMap[host]; ok && cr != channelRef {
+		return duplicateHostNameError(host, channelRef.Namespace, channelRef.Name, cr)
+	}
+	if oldHost, ok := d.channelHosts[channelRef]; ok && oldHost != host {
+		delete(d.hostToChannelMap, oldHost)
+	}
+	d.hostToChannelMap[host] = channelRef
+	d.channelHosts[channelRef] = host
+	return nil
+}
+
+// removeChannelHost stops routing the host name of the channel to it.
+func (d *KafkaDispatcher) removeChannelHost(channelRef eventingchannels.ChannelReference) {
+	d.hostToChannelMapLock.Lock()
+	defer d.hostToChannelMapLock.Unlock()
+
+	if host, ok := d.channelHosts[channelRef]; ok {
+		delete(d.hostToChannelMap, host)
+		delete(d.channelHosts, channelRef)
+	}
+}
+
+// setPartitionKeyAttribute sets the attribute keying the records of the channel, an empty
+// attribute restores the default.
+func (d *KafkaDispatcher) setPartitionKeyAttribute(channelRef eventingchannels.ChannelReference, attribute string) {
+	d.hostToChannelMapLock.Lock()
+	defer d.hostToChannelMapLock.Unlock()
+
+	if attribute == "" {
+		delete(d.partitionKeyAttributes, channelRef)
+		return
+	}
+	if d.partitionKeyAttributes == nil {
+		d.partitionKeyAttributes = make(map[eventingchannels.ChannelReference]string)
+	}
+	d.partitionKeyAttributes[channelRef] = attribute
+}
+
+// getPartitionKeyAttribute returns the attribute keying the records of the channel.
+func (d *KafkaDispatcher) getPartitionKeyAttribute(channelRef eventingchannels.ChannelReference) string {
+	d.hostToChannelMapLock.RLock()
+	defer d.hostToChannelMapLock.RUnlock()
+	return d.partitionKeyAttributes[channelRef]
+}
+
+// setAdoptedTopic sets the existing topic adopted by the channel, an empty topic restores the
+// topic named by topicFunc.
+func (d *KafkaDispatcher) setAdoptedTopic(channelRef eventingchannels.ChannelReference, topic string) {
+	d.hostToChannelMapLock.Lock()
+	defer d.hostToChannelMapLock.Unlock()
+
+	if topic == "" {
+		delete(d.adoptedTopics, channelRef)
+		return
+	}
+	if d.adoptedTopics == nil {
+		d.adoptedTopics = make(map[eventingchannels.ChannelReference]string)
+	}
+	d.adoptedTopics[channelRef] = topic
+}
+
+// topicName returns the topic of the channel, which is either the existing topic it adopted or
+// the topic named by topicFunc.
+func (d *KafkaDispatcher) topicName(channelRef eventingchannels.ChannelReference) string {
+	d.hostToChannelMapLock.RLock()
+	topic, ok := d.adoptedTopics[channelRef]
+	d.hostToChannelMapLock.RUnlock()
+	if ok {
+		return topic
+	}
+	return d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
+}
+
+func duplicateHostNameError(host, namespace, name string, cr eventingchannels.ChannelReference) error {
+	return fmt.Errorf(
+		"duplicate hostName found. Each channel must have a unique host header. HostName:%s, channel:%s.%s, channel:%s.%s",
+		host,
+		namespace,
+		name,
+		cr.Namespace,
+		cr.Name)
+}
+
 // Start starts the kafka dispatcher's message processing.
 func (d *KafkaDispatcher) Start(ctx context.Context) error {
 	if d.receiver == nil {
@@ -302,20 +730,9 @@ func (d *KafkaDispatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("kafkaAsyncProducer is not set")
 	}
 
-	go func() {
-		for {
-			select {
-			case e := <-d.kafkaAsyncProducer.Errors():
-				d.logger.Warn("Got", zap.Error(e))
-			case s := <-d.kafkaAsyncProducer.Successes():
-				d.logger.Info("Sent", zap.Any("success", s))
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
+	go d.acknowledgeProduced(ctx)
 
-	return d.receiver.Start(ctx)
+	return kncloudevents.NewHTTPMessageReceiver(ingressPort).StartListen(ctx, d)
 }
 
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
@@ -323,10 +740,31 @@ func (d *KafkaDispatcher) Start(ctx context.Context) error {
 func (d *KafkaDispatcher) subscribe(channelRef eventingchannels.ChannelReference, sub Subscription) error {
 	d.logger.Info("Subscribing", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
 
-	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
-	groupID := fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(sub.UID))
+	topicName := d.topicName(channelRef)
+	groupID := consumerGroupID(channelRef, sub.UID)
+
+	// The subscription may have been removed and added back before its consumer group was deleted.
+	d.groupCleaner.Cancel(groupID)
+
+	var deadLetterProducer sarama.SyncProducer
+	if sub.DeadLetterTopic != "" {
+		producer, err := d.getSyncProducer()
+		if err != nil {
+			d.logger.Infow("Could not create the dead letter topic producer", zap.Error(err))
+			return err
+		}
+		deadLetterProducer = producer
+	}
+
+	handler := &consumerMessageHandler{d.logger, sub, d.dispatcher, deadLetterProducer}
 
-	handler := &consumerMessageHandler{d.logger, sub, d.dispatcher}
+	// A new consumer group starts from the subscription's start position (if any),
+	// existing groups continue from their committed offsets.
+	startedFrom, err := d.initializeOffsets(groupID, topicName, sub.StartPosition)
+	if err != nil {
+		d.logger.Infow("Could not initialize the consumer group offsets", zap.Error(err))
+		return err
+	}
 
 	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, []string{topicName}, d.logger, handler)
 
@@ -347,15 +785,70 @@ func (d *KafkaDispatcher) subscribe(channelRef eventingchannels.ChannelReference
 	d.channelSubscriptions[channelRef] = append(d.channelSubscriptions[channelRef], sub.UID)
 	d.subscriptions[sub.UID] = sub
 	d.subsConsumerGroups[sub.UID] = consumerGroup
+	if startedFrom != "" {
+		d.subsStartedFrom[sub.UID] = startedFrom
+	}
 
 	return nil
 }
 
-// unsubscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
+// initializeOffsets commits the offsets of the start position for a new consumer group, returning
+// a description of the offsets or "" if the group already existed or there is no start position.
+func (d *KafkaDispatcher) initializeOffsets(groupID string, topic string, position consumer.StartPosition) (string, error) {
+	if position == "" {
+		return "", nil
+	}
+	client, err := newClient(d.brokers, d.config)
+	if err != nil {
+		return "", fmt.Errorf("unable to create kafka client against Kafka bootstrap servers %v : %v", d.brokers, err)
+	}
+	defer client.Close()
+	return consumer.InitializeOffsets(client, groupID, topic, position)
+}
+
+// newClient creates the Client used to initialize offsets, it is a variable to facilitate testing.
+var newClient = sarama.NewClient
+
+// SubscriptionStartPositions returns a description of the start position offsets committed for the
+// consumer group of each subscription created with a start position.
+func (d *KafkaDispatcher) SubscriptionStartPositions() map[types.UID]string {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	startPositions := make(map[types.UID]string, len(d.subsStartedFrom))
+	for uid, startedFrom := range d.subsStartedFrom {
+		startPositions[uid] = startedFrom
+	}
+	return startPositions
+}
+
+// getSyncProducer returns the dispatcher's SyncProducer, creating it on first use.
+// getSyncProducer must be called under updateLock.
+func (d *KafkaDispatcher) getSyncProducer() (sarama.SyncProducer, error) {
+	if d.kafkaSyncProducer == nil {
+		conf := sarama.NewConfig()
+		if d.config != nil {
+			*conf = *d.config
+		}
+		conf.Producer.Return.Successes = true // Required by the SyncProducer
+		producer, err := newSyncProducer(d.brokers, conf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create kafka sync producer against Kafka bootstrap servers %v : %v", d.brokers, err)
+		}
+		d.kafkaSyncProducer = producer
+	}
+	return d.kafkaSyncProducer, nil
+}
+
+// newSyncProducer creates the SyncProducer, it is a variable to facilitate testing.
+var newSyncProducer = sarama.NewSyncProducer
+
+// unsubscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine,
+// deleteGroup schedules the deletion of the closed consumer group.
 // unsubscribe must be called under updateLock.
-func (d *KafkaDispatcher) unsubscribe(channel eventingchannels.ChannelReference, sub Subscription) error {
+func (d *KafkaDispatcher) unsubscribe(channel eventingchannels.ChannelReference, sub Subscription, deleteGroup bool) error {
 	d.logger.Infow("Unsubscribing from channel", zap.Any("channel", channel), zap.String("subscription", sub.String()))
 	delete(d.subscriptions, sub.UID)
+	delete(d.subsStartedFrom, sub.UID)
 	if subsSlice, ok := d.channelSubscriptions[channel]; ok {
 		var newSlice []types.UID
 		for _, oldSub := range subsSlice {
@@ -365,24 +858,48 @@ func (d *KafkaDispatcher) unsubscribe(channel eventingchannels.ChannelReference,
 		}
 		d.channelSubscriptions[channel] = newSlice
 	}
-	if consumer, ok := d.subsConsumerGroups[sub.UID]; ok {
+	if consumerGroup, ok := d.subsConsumerGroups[sub.UID]; ok {
 		delete(d.subsConsumerGroups, sub.UID)
-		return consumer.Close()
+		if err := consumerGroup.Close(); err != nil {
+			return err
+		}
+		if deleteGroup {
+			d.groupCleaner.Schedule(consumerGroupID(channel, sub.UID))
+		}
 	}
 	return nil
 }
 
+// consumerGroupID returns the ID of the consumer group of the specified subscription.
+func consumerGroupID(channelRef eventingchannels.ChannelReference, uid types.UID) string {
+	return fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(uid))
+}
+
+// getHostToChannelMap returns a copy of the host to channel map.
 func (d *KafkaDispatcher) getHostToChannelMap() map[string]eventingchannels.ChannelReference {
-	return d.hostToChannelMap.Load().(map[string]eventingchannels.ChannelReference)
+	d.hostToChannelMapLock.RLock()
+	defer d.hostToChannelMapLock.RUnlock()
+	hcMap := make(map[string]eventingchannels.ChannelReference, len(d.hostToChannelMap))
+	for host, channelRef := range d.hostToChannelMap {
+		hcMap[host] = channelRef
+	}
+	return hcMap
 }
 
 func (d *KafkaDispatcher) setHostToChannelMap(hcMap map[string]eventingchannels.ChannelReference) {
-	d.hostToChannelMap.Store(hcMap)
+	d.hostToChannelMapLock.Lock()
+	defer d.hostToChannelMapLock.Unlock()
+	d.hostToChannelMap = hcMap
+	d.channelHosts = make(map[eventingchannels.ChannelReference]string, len(hcMap))
+	for host, channelRef := range hcMap {
+		d.channelHosts[channelRef] = host
+	}
 }
 
 func (d *KafkaDispatcher) getChannelReferenceFromHost(host string) (eventingchannels.ChannelReference, error) {
-	chMap := d.getHostToChannelMap()
-	cr, ok := chMap[host]
+	d.hostToChannelMapLock.RLock()
+	defer d.hostToChannelMapLock.RUnlock()
+	cr, ok := d.hostToChannelMap[host]
 	if !ok {
 		return cr, eventingchannels.UnknownHostError(host)
 	}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	eventingchannels "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+)
+
+// Run with go test ./pkg/channel/consolidated/dispatcher/ -run=XXX -bench=Channel -benchmem
+
+const benchmarkSubscriptionsPerChannel = 5
+
+var benchmarkChannelCounts = []int{100, 1000, 5000}
+
+// BenchmarkReconcileChannel measures the reconciliation of a single unchanged channel, as happens
+// on every resync, which only looks at the reconciled channel.
+func BenchmarkReconcileChannel(b *testing.B) {
+	for _, channels := range benchmarkChannelCounts {
+		b.Run(fmt.Sprintf("channels=%d", channels), func(b *testing.B) {
+			d, config := newBenchmarkDispatcher(b, channels)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := d.ReconcileChannel(&config.ChannelConfigs[i%channels]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkReconcileChannelSubscriptionChange measures adding and removing a subscription of a
+// single channel.
+func BenchmarkReconcileChannelSubscriptionChange(b *testing.B) {
+	for _, channels := range benchmarkChannelCounts {
+		b.Run(fmt.Sprintf("channels=%d", channels), func(b *testing.B) {
+			d, config := newBenchmarkDispatcher(b, channels)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				channelConfig := config.ChannelConfigs[i%channels]
+				changed := channelConfig
+				changed.Subscriptions = append(channelConfig.Subscriptions[:len(channelConfig.Subscriptions):len(channelConfig.Subscriptions)],
+					Subscription{UID: types.UID(fmt.Sprintf("added-%d", i))})
+				if _, err := d.ReconcileChannel(&changed); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := d.ReconcileChannel(&channelConfig); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkUpdateAllChannels measures the reconciliation of a single channel by applying the
+// configuration of all channels, as the dispatcher controller used to.
+func BenchmarkUpdateAllChannels(b *testing.B) {
+	for _, channels := range benchmarkChannelCounts {
+		b.Run(fmt.Sprintf("channels=%d", channels), func(b *testing.B) {
+			d, config := newBenchmarkDispatcher(b, channels)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := d.UpdateHostToChannelMap(config); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := d.UpdateKafkaConsumers(config); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// newBenchmarkDispatcher creates a dispatcher with the specified number of subscribed channels,
+// returning the configuration of the channels.
+func newBenchmarkDispatcher(b *testing.B, channels int) (*KafkaDispatcher, *Config) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		topicFunc:            utils.TopicName,
+		logger:               zap.NewNop().Sugar(),
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+
+	config := &Config{ChannelConfigs: make([]ChannelConfig, 0, channels)}
+	for c := 0; c < channels; c++ {
+		channelConfig := ChannelConfig{
+			Namespace: "default",
+			Name:      fmt.Sprintf("channel-%d", c),
+			HostName:  fmt.Sprintf("channel-%d-kn-channel.default.svc.cluster.local", c),
+		}
+		for s := 0; s < benchmarkSubscriptionsPerChannel; s++ {
+			channelConfig.Subscriptions = append(channelConfig.Subscriptions, Subscription{UID: types.UID(fmt.Sprintf("sub-%d-%d", c, s))})
+		}
+		if _, err := d.ReconcileChannel(&channelConfig); err != nil {
+			b.Fatal(err)
+		}
+		config.ChannelConfigs = append(config.ChannelConfigs, channelConfig)
+	}
+	return d, config
+}
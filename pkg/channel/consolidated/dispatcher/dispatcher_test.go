@@ -20,21 +20,32 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/ptr"
 	_ "knative.dev/pkg/system/testing"
 )
 
@@ -43,12 +54,17 @@ import (
 type mockKafkaConsumerFactory struct {
 	// createErr will return an error when creating a consumer
 	createErr bool
+	// handlers records the handlers of the started consumer groups, if set
+	handlers *[]consumer.KafkaConsumerHandler
 }
 
 func (c mockKafkaConsumerFactory) StartConsumerGroup(groupID string, topics []string, logger *zap.SugaredLogger, handler consumer.KafkaConsumerHandler) (sarama.ConsumerGroup, error) {
 	if c.createErr {
 		return nil, errors.New("error creating consumer")
 	}
+	if c.handlers != nil {
+		*c.handlers = append(*c.handlers, handler)
+	}
 
 	return mockConsumerGroup{}, nil
 }
@@ -71,6 +87,29 @@ func (m mockConsumerGroup) Close() error {
 
 var _ sarama.ConsumerGroup = (*mockConsumerGroup)(nil)
 
+type mockSyncProducer struct {
+	messages []*sarama.ProducerMessage
+	err      error
+}
+
+func (m *mockSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	if m.err != nil {
+		return -1, -1, m.err
+	}
+	m.messages = append(m.messages, message)
+	return 0, int64(len(m.messages)), nil
+}
+
+func (m *mockSyncProducer) SendMessages(_ []*sarama.ProducerMessage) error {
+	return nil
+}
+
+func (m *mockSyncProducer) Close() error {
+	return nil
+}
+
+var _ sarama.SyncProducer = (*mockSyncProducer)(nil)
+
 // ----- Tests
 
 // test util for various config checks
@@ -376,6 +415,249 @@ func TestDispatcher_UpdateConfig(t *testing.T) {
 	}
 }
 
+func TestDispatcher_ReconcileChannel(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	channel1 := eventingchannels.ChannelReference{Name: "test-channel-1", Namespace: "default"}
+	channel2 := eventingchannels.ChannelReference{Name: "test-channel-2", Namespace: "default"}
+
+	reconcile := func(channelRef eventingchannels.ChannelReference, host string, uids ...types.UID) {
+		t.Helper()
+		config := &ChannelConfig{Namespace: channelRef.Namespace, Name: channelRef.Name, HostName: host}
+		for _, uid := range uids {
+			config.Subscriptions = append(config.Subscriptions, Subscription{UID: uid})
+		}
+		failed, err := d.ReconcileChannel(config)
+		if err != nil {
+			t.Fatalf("ReconcileChannel() = %v", err)
+		}
+		if len(failed) != 0 {
+			t.Fatalf("ReconcileChannel() failed subscriptions = %v", failed)
+		}
+	}
+	check := func(wantHosts map[string]eventingchannels.ChannelReference, wantSubs map[eventingchannels.ChannelReference][]types.UID) {
+		t.Helper()
+		if diff := cmp.Diff(wantHosts, d.getHostToChannelMap()); diff != "" {
+			t.Errorf("unexpected hostToChannelMap (-want, +got) = %v", diff)
+		}
+		sortUIDs := cmpopts.SortSlices(func(x, y types.UID) bool { return x < y })
+		if diff := cmp.Diff(wantSubs, d.channelSubscriptions, sortUIDs, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected channelSubscriptions (-want, +got) = %v", diff)
+		}
+	}
+
+	// Each channel only affects its own host and subscriptions
+	reconcile(channel1, "a.b.c.d", "sub-1", "sub-2")
+	reconcile(channel2, "e.f.g.h", "sub-3")
+	check(map[string]eventingchannels.ChannelReference{"a.b.c.d": channel1, "e.f.g.h": channel2},
+		map[eventingchannels.ChannelReference][]types.UID{channel1: {"sub-1", "sub-2"}, channel2: {"sub-3"}})
+
+	// Changing a channel's host and subscriptions applies the delta
+	reconcile(channel1, "i.j.k.l", "sub-2", "sub-4")
+	check(map[string]eventingchannels.ChannelReference{"i.j.k.l": channel1, "e.f.g.h": channel2},
+		map[eventingchannels.ChannelReference][]types.UID{channel1: {"sub-2", "sub-4"}, channel2: {"sub-3"}})
+	if _, ok := d.subscriptions["sub-1"]; ok {
+		t.Error("sub-1 was not unsubscribed")
+	}
+
+	// A host name can not be claimed by another channel
+	_, err := d.ReconcileChannel(&ChannelConfig{Namespace: channel2.Namespace, Name: channel2.Name, HostName: "i.j.k.l"})
+	want := "duplicate hostName found. Each channel must have a unique host header. HostName:i.j.k.l, channel:default.test-channel-2, channel:default.test-channel-1"
+	if err == nil || err.Error() != want {
+		t.Errorf("ReconcileChannel() = %v, want %s", err, want)
+	}
+
+	// Cleaning up a channel removes its host and subscriptions, and is idempotent
+	for i := 0; i < 2; i++ {
+		if err := d.CleanupChannel(channel1.Name, channel1.Namespace); err != nil {
+			t.Fatalf("CleanupChannel() = %v", err)
+		}
+	}
+	check(map[string]eventingchannels.ChannelReference{"e.f.g.h": channel2},
+		map[eventingchannels.ChannelReference][]types.UID{channel2: {"sub-3"}})
+	if len(d.subscriptions) != 1 || len(d.subsConsumerGroups) != 1 {
+		t.Errorf("unexpected subscriptions %v and consumer groups %v", d.subscriptions, d.subsConsumerGroups)
+	}
+
+	if _, err := d.ReconcileChannel(nil); err == nil {
+		t.Error("ReconcileChannel(nil) = nil, want error")
+	}
+}
+
+func TestDispatcher_UpdateKafkaConsumersPerChannel(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+	config := &Config{
+		ChannelConfigs: []ChannelConfig{
+			{Namespace: "default", Name: "test-channel-1", Subscriptions: []Subscription{{UID: "sub-1"}}},
+			{Namespace: "default", Name: "test-channel-2", Subscriptions: []Subscription{{UID: "sub-2"}}},
+		},
+	}
+	if _, err := d.UpdateKafkaConsumers(config); err != nil {
+		t.Fatalf("UpdateKafkaConsumers() = %v", err)
+	}
+
+	// The subscriptions are tracked per channel, and channels missing from the config are unsubscribed
+	want := map[eventingchannels.ChannelReference][]types.UID{
+		{Name: "test-channel-1", Namespace: "default"}: {"sub-1"},
+		{Name: "test-channel-2", Namespace: "default"}: {"sub-2"},
+	}
+	if diff := cmp.Diff(want, d.channelSubscriptions); diff != "" {
+		t.Errorf("unexpected channelSubscriptions (-want, +got) = %v", diff)
+	}
+	config.ChannelConfigs = config.ChannelConfigs[1:]
+	if _, err := d.UpdateKafkaConsumers(config); err != nil {
+		t.Fatalf("UpdateKafkaConsumers() = %v", err)
+	}
+	delete(want, eventingchannels.ChannelReference{Name: "test-channel-1", Namespace: "default"})
+	if diff := cmp.Diff(want, d.channelSubscriptions); diff != "" {
+		t.Errorf("unexpected channelSubscriptions (-want, +got) = %v", diff)
+	}
+	if _, ok := d.subsConsumerGroups["sub-1"]; ok {
+		t.Error("sub-1 was not unsubscribed")
+	}
+}
+
+func TestDispatcher_ReconcileChannelOwnedSubscriptions(t *testing.T) {
+	const groupID = "kafka.default.test-channel.sub-2"
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, groupID, broker),
+		"DeleteGroupsRequest": sarama.NewMockDeleteGroupsRequest(t).
+			SetDeletedGroups([]string{groupID}),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	logger := zaptest.NewLogger(t).Sugar()
+	var owned atomic.Value
+	owned.Store(sets.NewString("sub-1", "sub-2"))
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		groupCleaner:         consumer.NewGroupCleaner(logger, []string{broker.Addr()}, config, 0, reportCleanupFailure),
+		ownsSubscription: func(uid types.UID) bool {
+			return owned.Load().(sets.String).Has(string(uid))
+		},
+		topicFunc: utils.TopicName,
+		logger:    logger,
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	channelRef := eventingchannels.ChannelReference{Name: "test-channel", Namespace: "default"}
+	channelConfig := &ChannelConfig{
+		Namespace:     channelRef.Namespace,
+		Name:          channelRef.Name,
+		HostName:      "a.b.c.d",
+		Subscriptions: []Subscription{{UID: "sub-1"}, {UID: "sub-2"}, {UID: "sub-3"}},
+	}
+	check := func(want ...types.UID) {
+		t.Helper()
+		if _, err := d.ReconcileChannel(channelConfig); err != nil {
+			t.Fatalf("ReconcileChannel() = %v", err)
+		}
+		sortUIDs := cmpopts.SortSlices(func(x, y types.UID) bool { return x < y })
+		if diff := cmp.Diff(want, d.channelSubscriptions[channelRef], sortUIDs, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected subscriptions (-want, +got) = %v", diff)
+		}
+		// The channel receives events regardless of the subscriptions owned by the replica
+		if diff := cmp.Diff(map[string]eventingchannels.ChannelReference{"a.b.c.d": channelRef}, d.getHostToChannelMap()); diff != "" {
+			t.Errorf("unexpected hostToChannelMap (-want, +got) = %v", diff)
+		}
+	}
+
+	// Only the owned subscriptions are consumed
+	check("sub-1", "sub-2")
+
+	// A subscription handed over to another replica is unsubscribed, keeping its consumer group
+	owned.Store(sets.NewString("sub-1", "sub-3"))
+	check("sub-1", "sub-3")
+	time.Sleep(100 * time.Millisecond)
+	if deleteGroupsRequested(broker, groupID) {
+		t.Errorf("Consumer group %s of a handed over subscription was deleted", groupID)
+	}
+}
+
+func TestDispatcher_ReconcileChannelChangedSubscription(t *testing.T) {
+	var handlers []consumer.KafkaConsumerHandler
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{handlers: &handlers},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	channelConfig := &ChannelConfig{
+		Namespace:     "default",
+		Name:          "test-channel",
+		HostName:      "a.b.c.d",
+		Subscriptions: []Subscription{{UID: "sub-1"}},
+	}
+	reconcile := func() {
+		t.Helper()
+		if failed, err := d.ReconcileChannel(channelConfig); err != nil || len(failed) != 0 {
+			t.Fatalf("ReconcileChannel() = %v, %v", failed, err)
+		}
+	}
+	reconcile()
+
+	// An unchanged subscription keeps its consumer group
+	reconcile()
+	if len(handlers) != 1 {
+		t.Fatalf("Expected 1 started consumer group, got %d", len(handlers))
+	}
+
+	// Changing the filter of the subscription restarts its consumer group with the new filter
+	subFilter := &filter.Filter{Exact: map[string]string{"type": "com.example.order.created"}}
+	channelConfig.Subscriptions = []Subscription{{UID: "sub-1", Filter: subFilter}}
+	reconcile()
+	if len(handlers) != 2 {
+		t.Fatalf("Expected 2 started consumer groups, got %d", len(handlers))
+	}
+	if diff := cmp.Diff(subFilter, handlers[1].(*consumerMessageHandler).sub.Filter); diff != "" {
+		t.Errorf("unexpected filter (-want, +got) = %v", diff)
+	}
+	if diff := cmp.Diff([]types.UID{"sub-1"}, d.channelSubscriptions[eventingchannels.ChannelReference{Name: "test-channel", Namespace: "default"}]); diff != "" {
+		t.Errorf("unexpected subscriptions (-want, +got) = %v", diff)
+	}
+
+	// Changing the effective delivery of the subscription also restarts its consumer group
+	delivery := &eventingduckv1.DeliverySpec{Retry: ptr.Int32(5)}
+	channelConfig.Subscriptions = []Subscription{{UID: "sub-1", Filter: subFilter, Delivery: delivery}}
+	reconcile()
+	if len(handlers) != 3 {
+		t.Fatalf("Expected 3 started consumer groups, got %d", len(handlers))
+	}
+	if diff := cmp.Diff(delivery, handlers[2].(*consumerMessageHandler).sub.Delivery); diff != "" {
+		t.Errorf("unexpected delivery (-want, +got) = %v", diff)
+	}
+}
+
 func TestSubscribeError(t *testing.T) {
 	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
@@ -399,6 +681,609 @@ func TestSubscribeError(t *testing.T) {
 	}
 }
 
+func TestSubscribeDeadLetterTopic(t *testing.T) {
+	producer := &mockSyncProducer{}
+	producerCount := 0
+	newSyncProducerPlaceholder := newSyncProducer
+	newSyncProducer = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		producerCount++
+		if !config.Producer.Return.Successes {
+			t.Error("Expected Producer.Return.Successes to be enabled")
+		}
+		return producer, nil
+	}
+	defer func() { newSyncProducer = newSyncProducerPlaceholder }()
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		logger:               zap.NewNop().Sugar(),
+		topicFunc:            utils.TopicName,
+	}
+	channelRef := eventingchannels.ChannelReference{
+		Name:      "test-channel",
+		Namespace: "test-ns",
+	}
+
+	// Subscriptions without a dead letter topic do not create the producer
+	if err := d.subscribe(channelRef, Subscription{UID: "test-sub-1"}); err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	if producerCount != 0 {
+		t.Errorf("Expected no producer, got %d", producerCount)
+	}
+
+	// Subscriptions with a dead letter topic share a single producer
+	for _, uid := range []types.UID{"test-sub-2", "test-sub-3"} {
+		if err := d.subscribe(channelRef, Subscription{UID: uid, DeadLetterTopic: "test-dlq"}); err != nil {
+			t.Fatalf("Subscribe error: %v", err)
+		}
+	}
+	if producerCount != 1 {
+		t.Errorf("Expected 1 producer, got %d", producerCount)
+	}
+	if d.kafkaSyncProducer != producer {
+		t.Errorf("Expected the dispatcher's producer to be set")
+	}
+
+	// Failing to create the producer fails the subscription
+	d.kafkaSyncProducer = nil
+	newSyncProducer = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		return nil, errors.New("error creating producer")
+	}
+	if err := d.subscribe(channelRef, Subscription{UID: "test-sub-4", DeadLetterTopic: "test-dlq"}); err == nil {
+		t.Error("Expected error creating producer, got nil")
+	}
+}
+
+func TestSubscribeStartPosition(t *testing.T) {
+	const (
+		topic   = "knative-messaging-kafka.test-ns.test-channel"
+		groupID = "kafka.test-ns.test-channel.test-sub-1"
+	)
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, groupID, broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset(groupID, topic, 0, -1, "", sarama.ErrNoError),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(topic, 0, sarama.OffsetOldest, 4),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		brokers:              []string{broker.Addr()},
+		config:               config,
+		logger:               zap.NewNop().Sugar(),
+		topicFunc:            utils.TopicName,
+	}
+	channelRef := eventingchannels.ChannelReference{
+		Name:      "test-channel",
+		Namespace: "test-ns",
+	}
+
+	// The offsets of the new consumer group are initialized and recorded for the status
+	if err := d.subscribe(channelRef, Subscription{UID: "test-sub-1", StartPosition: consumer.StartPositionEarliest}); err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	want := map[types.UID]string{"test-sub-1": "started from earliest at partition:offset 0:4"}
+	if diff := cmp.Diff(want, d.SubscriptionStartPositions()); diff != "" {
+		t.Errorf("Unexpected start positions (-want, +got): %s", diff)
+	}
+
+	// Unsubscribing removes the start position
+	if err := d.unsubscribe(channelRef, d.subscriptions["test-sub-1"], true); err != nil {
+		t.Fatalf("Unsubscribe error: %v", err)
+	}
+	if len(d.SubscriptionStartPositions()) != 0 {
+		t.Errorf("Expected no start positions, got %v", d.SubscriptionStartPositions())
+	}
+
+	// Failing to initialize the offsets fails the subscription
+	newClientPlaceholder := newClient
+	newClient = func([]string, *sarama.Config) (sarama.Client, error) {
+		return nil, errors.New("error creating client")
+	}
+	defer func() { newClient = newClientPlaceholder }()
+	if err := d.subscribe(channelRef, Subscription{UID: "test-sub-2", StartPosition: consumer.StartPositionLatest}); err == nil {
+		t.Error("Expected error initializing offsets, got nil")
+	}
+}
+
+func TestUnsubscribeDeletesConsumerGroup(t *testing.T) {
+	const groupID = "kafka.test-ns.test-channel.test-sub-1"
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, groupID, broker),
+		"DeleteGroupsRequest": sarama.NewMockDeleteGroupsRequest(t).
+			SetDeletedGroups([]string{groupID}),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	logger := zap.NewNop().Sugar()
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		subsStartedFrom:      make(map[types.UID]string),
+		groupCleaner:         consumer.NewGroupCleaner(logger, []string{broker.Addr()}, config, 0, reportCleanupFailure),
+		logger:               logger,
+		topicFunc:            utils.TopicName,
+	}
+	channelRef := eventingchannels.ChannelReference{
+		Name:      "test-channel",
+		Namespace: "test-ns",
+	}
+
+	if err := d.subscribe(channelRef, Subscription{UID: "test-sub-1"}); err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	if err := d.unsubscribe(channelRef, d.subscriptions["test-sub-1"], true); err != nil {
+		t.Fatalf("Unsubscribe error: %v", err)
+	}
+
+	// The closed consumer group is deleted after the grace period
+	deadline := time.Now().Add(5 * time.Second)
+	for !deleteGroupsRequested(broker, groupID) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Consumer group %s was not deleted", groupID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if failures := d.groupCleaner.Failures(); len(failures) != 0 {
+		t.Errorf("Unexpected cleanup failures: %v", failures)
+	}
+}
+
+// deleteGroupsRequested returns whether the broker received a request to delete the consumer group.
+func deleteGroupsRequested(broker *sarama.MockBroker, groupID string) bool {
+	for _, exchange := range broker.History() {
+		if request, ok := exchange.Request.(*sarama.DeleteGroupsRequest); ok {
+			for _, group := range request.Groups {
+				if group == groupID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func TestWriteDeadLetter(t *testing.T) {
+	consumerMessage := &sarama.ConsumerMessage{Topic: "knative-messaging-kafka.test-ns.test-channel", Partition: 2, Offset: 10, Value: []byte("value")}
+	deliveryErr := errors.New("delivery failed")
+
+	producer := &mockSyncProducer{}
+	handler := consumerMessageHandler{
+		logger:             zap.NewNop().Sugar(),
+		sub:                Subscription{UID: "test-sub", DeadLetterTopic: "test-dlq"},
+		deadLetterProducer: producer,
+	}
+	if err := handler.writeDeadLetter(consumerMessage, 3, deliveryErr); err != nil {
+		t.Fatalf("writeDeadLetter error: %v", err)
+	}
+	if len(producer.messages) != 1 {
+		t.Fatalf("Expected 1 dead letter message, got %d", len(producer.messages))
+	}
+	deadLetterMessage := producer.messages[0]
+	if deadLetterMessage.Topic != "test-dlq" {
+		t.Errorf("Expected topic %q, got %q", "test-dlq", deadLetterMessage.Topic)
+	}
+	consumed := &sarama.ConsumerMessage{}
+	for i := range deadLetterMessage.Headers {
+		consumed.Headers = append(consumed.Headers, &deadLetterMessage.Headers[i])
+	}
+	entry := dlq.ParseEntry(consumed)
+	want := dlq.Entry{
+		Error:             "delivery failed",
+		Attempts:          3,
+		Subscriber:        "test-sub",
+		ChannelTopic:      consumerMessage.Topic,
+		OriginalTopic:     consumerMessage.Topic,
+		OriginalPartition: 2,
+		OriginalOffset:    10,
+	}
+	if diff := cmp.Diff(want, entry, cmpopts.IgnoreFields(dlq.Entry{}, "Message")); diff != "" {
+		t.Errorf("Unexpected dead letter headers (-want, +got): %s", diff)
+	}
+
+	// Failing to write to the dead letter topic returns an error
+	handler.deadLetterProducer = &mockSyncProducer{err: errors.New("produce failed")}
+	if err := handler.writeDeadLetter(consumerMessage, 3, deliveryErr); err == nil {
+		t.Error("Expected error writing dead letter, got nil")
+	}
+}
+
+func TestHandleFilter(t *testing.T) {
+	record = func(ctx context.Context, ms stats.Measurement, _ ...stats.Options) {
+		stats.Record(ctx, ms)
+	}
+	defer func() { record = metrics.Record }()
+
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&received, 1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	subscriber, _ := url.Parse(server.URL)
+
+	consumerMessage := &sarama.ConsumerMessage{
+		Topic: "knative-messaging-kafka.test-ns.test-channel",
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+			{Key: []byte("ce_id"), Value: []byte("1")},
+			{Key: []byte("ce_type"), Value: []byte("com.example.order.created")},
+			{Key: []byte("ce_source"), Value: []byte("/orders")},
+		},
+	}
+
+	testCases := map[string]struct {
+		filter        *filter.Filter
+		wantDelivered bool
+	}{
+		"no filter":    {wantDelivered: true},
+		"matching":     {filter: &filter.Filter{Prefix: map[string]string{"type": "com.example.order."}}, wantDelivered: true},
+		"not matching": {filter: &filter.Filter{Exact: map[string]string{"type": "com.example.order.deleted"}}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&received, 0)
+			handler := consumerMessageHandler{
+				logger:     zap.NewNop().Sugar(),
+				sub:        Subscription{UID: "test-sub", Subscription: fanout.Subscription{Subscriber: subscriber}, Filter: tc.filter},
+				dispatcher: eventingchannels.NewMessageDispatcher(zap.NewNop()),
+			}
+			marked, err := handler.Handle(context.Background(), consumerMessage)
+			if err != nil || !marked {
+				t.Fatalf("Handle() = %t, %v, want true, nil", marked, err)
+			}
+			if delivered := atomic.LoadInt32(&received) == 1; delivered != tc.wantDelivered {
+				t.Errorf("delivered = %t, want %t", delivered, tc.wantDelivered)
+			}
+		})
+	}
+
+	rows, err := view.RetrieveData(filteredMessageCountM.Name())
+	if err != nil {
+		t.Fatalf("RetrieveData() = %v", err)
+	}
+	if len(rows) != 1 || rows[0].Data.(*view.CountData).Value != 1 {
+		t.Errorf("Expected 1 filtered message, got %v", rows)
+	}
+}
+
+// errAckDelayed maps the topics whose messages are acknowledged after ackDelay in the errs of
+// newMockAsyncProducer.
+var errAckDelayed = errors.New("acknowledgement delayed")
+
+const ackDelay = 50 * time.Millisecond
+
+// mockAsyncProducer acknowledges the produced messages, failing the messages of the topics in errs.
+// The zero value never accepts messages.
+type mockAsyncProducer struct {
+	sarama.AsyncProducer
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+	// produced receives the acknowledged messages, when not full
+	produced chan *sarama.ProducerMessage
+}
+
+func newMockAsyncProducer(ctx context.Context, errs map[string]error) *mockAsyncProducer {
+	p := &mockAsyncProducer{
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+		produced:  make(chan *sarama.ProducerMessage, 10),
+	}
+	go func() {
+		for {
+			select {
+			case message := <-p.input:
+				// Topics with a nil error are never acknowledged
+				err, ok := errs[message.Topic]
+				if !ok {
+					p.successes <- message
+					select {
+					case p.produced <- message:
+					default:
+					}
+				} else if err == errAckDelayed {
+					time.Sleep(ackDelay)
+					p.successes <- message
+				} else if err != nil {
+					p.errors <- &sarama.ProducerError{Msg: message, Err: err}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return p
+}
+
+func (p *mockAsyncProducer) Input() chan<- *sarama.ProducerMessage     { return p.input }
+func (p *mockAsyncProducer) Successes() <-chan *sarama.ProducerMessage { return p.successes }
+func (p *mockAsyncProducer) Errors() <-chan *sarama.ProducerError      { return p.errors }
+
+func TestProduce(t *testing.T) {
+	record = func(ctx context.Context, ms stats.Measurement, _ ...stats.Options) {
+		stats.Record(ctx, ms)
+	}
+	defer func() { record = metrics.Record }()
+	initialErrorCount, initialLatencyCount := producedCounts(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	produceErr := errors.New("not enough replicas")
+	d := &KafkaDispatcher{
+		kafkaAsyncProducer: newMockAsyncProducer(ctx, map[string]error{
+			"knative-messaging-kafka.test-ns.failing-channel": produceErr,
+			"knative-messaging-kafka.test-ns.delayed-channel": errAckDelayed,
+		}),
+		produceQueueTimeout: time.Second,
+		acknowledgerDone:    make(chan struct{}),
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	go d.acknowledgeProduced(ctx)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	produce := func(ctx context.Context, channel string) error {
+		return d.produce(ctx, eventingchannels.ChannelReference{Name: channel, Namespace: "test-ns"}, binding.ToMessage(&event), nil)
+	}
+
+	// The ingress waits for the acknowledgement of Kafka
+	if err := produce(context.Background(), "test-channel"); err != nil {
+		t.Errorf("produce() = %v, want nil", err)
+	}
+	if err := produce(context.Background(), "failing-channel"); err != produceErr {
+		t.Errorf("produce() = %v, want %v", err, produceErr)
+	}
+
+	// Requests which are cancelled once queued still wait for the acknowledgement, as the event is written
+	requestCtx, cancelRequest := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelRequest()
+	if err := produce(requestCtx, "delayed-channel"); err != nil {
+		t.Errorf("produce() = %v, want nil", err)
+	}
+
+	// Queued events which are no longer acknowledged fail when the dispatcher stops
+	ackCtx, stopAcknowledger := context.WithCancel(context.Background())
+	d = &KafkaDispatcher{
+		kafkaAsyncProducer: newMockAsyncProducer(ctx, map[string]error{
+			// Never acknowledged
+			"knative-messaging-kafka.test-ns.slow-channel": nil,
+		}),
+		produceQueueTimeout: time.Second,
+		acknowledgerDone:    make(chan struct{}),
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	go d.acknowledgeProduced(ackCtx)
+	time.AfterFunc(10*time.Millisecond, stopAcknowledger)
+	if err := produce(context.Background(), "slow-channel"); err != errProducerClosed {
+		t.Errorf("produce() = %v, want %v", err, errProducerClosed)
+	}
+
+	// Events are rejected when the producer queue stays full
+	d = &KafkaDispatcher{
+		kafkaAsyncProducer:  &mockAsyncProducer{},
+		produceQueueTimeout: 10 * time.Millisecond,
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	if err := produce(context.Background(), "test-channel"); err != errProducerQueueFull {
+		t.Errorf("produce() = %v, want %v", err, errProducerQueueFull)
+	}
+
+	errorCount, latencyCount := producedCounts(t)
+	if errorCount-initialErrorCount != 2 {
+		t.Errorf("Expected 2 produce errors, got %d", errorCount-initialErrorCount)
+	}
+	if latencyCount-initialLatencyCount != 4 {
+		t.Errorf("Expected 4 produce latencies, got %d", latencyCount-initialLatencyCount)
+	}
+}
+
+func TestIngressStatusCodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	produceErr := errors.New("not enough replicas")
+	d := &KafkaDispatcher{
+		kafkaAsyncProducer: newMockAsyncProducer(ctx, map[string]error{
+			"knative-messaging-kafka.test-ns.failing-channel": produceErr,
+		}),
+		produceQueueTimeout: time.Second,
+		acknowledgerDone:    make(chan struct{}),
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	go d.acknowledgeProduced(ctx)
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{
+		"test-channel.test-ns.svc.cluster.local":    {Name: "test-channel", Namespace: "test-ns"},
+		"failing-channel.test-ns.svc.cluster.local": {Name: "failing-channel", Namespace: "test-ns"},
+	})
+	receiver, err := eventingchannels.NewMessageReceiver(d.receive, zap.NewNop(),
+		eventingchannels.ResolveMessageChannelFromHostHeader(d.getChannelReferenceFromHost))
+	if err != nil {
+		t.Fatalf("Error creating new message receiver. Error:%s", err)
+	}
+	d.receiver = receiver
+
+	send := func(host string) int {
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Host = host
+		request.Header.Set("ce-specversion", "1.0")
+		request.Header.Set("ce-id", "1")
+		request.Header.Set("ce-type", "com.example.order.created")
+		request.Header.Set("ce-source", "/orders")
+		response := httptest.NewRecorder()
+		d.ServeHTTP(response, request)
+		return response.Code
+	}
+
+	if code := send("test-channel.test-ns.svc.cluster.local"); code != http.StatusAccepted {
+		t.Errorf("written event status = %d, want %d", code, http.StatusAccepted)
+	}
+	if code := send("failing-channel.test-ns.svc.cluster.local"); code != http.StatusInternalServerError {
+		t.Errorf("failed event status = %d, want %d", code, http.StatusInternalServerError)
+	}
+
+	// Events rejected because the producer queue is full are answered with 503 Service Unavailable
+	d.kafkaAsyncProducer = &mockAsyncProducer{}
+	d.produceQueueTimeout = 10 * time.Millisecond
+	if code := send("test-channel.test-ns.svc.cluster.local"); code != http.StatusServiceUnavailable {
+		t.Errorf("rejected event status = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestProducePartitionKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	producer := newMockAsyncProducer(ctx, nil)
+	d := &KafkaDispatcher{
+		kafkaAsyncProducer:  producer,
+		produceQueueTimeout: time.Second,
+		acknowledgerDone:    make(chan struct{}),
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	go d.acknowledgeProduced(ctx)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	event.SetSubject("order-1")
+	event.SetExtension("partitionkey", "customer-1")
+	channelRef := eventingchannels.ChannelReference{Name: "test-channel", Namespace: "test-ns"}
+	produceKey := func() string {
+		t.Helper()
+		if err := d.produce(context.Background(), channelRef, binding.ToMessage(&event), nil); err != nil {
+			t.Fatalf("produce() = %v", err)
+		}
+		key, _ := (<-producer.produced).Key.Encode()
+		return string(key)
+	}
+
+	// The records are keyed by the partitionkey extension by default
+	if key := produceKey(); key != "customer-1" {
+		t.Errorf("Key = %q, want %q", key, "customer-1")
+	}
+
+	// The channel may key its records with another attribute
+	if _, err := d.ReconcileChannel(&ChannelConfig{Namespace: channelRef.Namespace, Name: channelRef.Name, HostName: "a.b.c.d", PartitionKeyAttribute: "subject"}); err != nil {
+		t.Fatalf("ReconcileChannel() = %v", err)
+	}
+	if key := produceKey(); key != "order-1" {
+		t.Errorf("Key = %q, want %q", key, "order-1")
+	}
+
+	// Cleaning up the channel restores the default
+	if err := d.CleanupChannel(channelRef.Name, channelRef.Namespace); err != nil {
+		t.Fatalf("CleanupChannel() = %v", err)
+	}
+	if key := produceKey(); key != "customer-1" {
+		t.Errorf("Key = %q, want %q", key, "customer-1")
+	}
+}
+
+func TestProduceAdoptedTopic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	producer := newMockAsyncProducer(ctx, nil)
+	d := &KafkaDispatcher{
+		kafkaAsyncProducer:  producer,
+		produceQueueTimeout: time.Second,
+		acknowledgerDone:    make(chan struct{}),
+		topicFunc:           utils.TopicName,
+		logger:              zaptest.NewLogger(t).Sugar(),
+	}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	go d.acknowledgeProduced(ctx)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	channelRef := eventingchannels.ChannelReference{Name: "test-channel", Namespace: "test-ns"}
+	produceTopic := func() string {
+		t.Helper()
+		if err := d.produce(context.Background(), channelRef, binding.ToMessage(&event), nil); err != nil {
+			t.Fatalf("produce() = %v", err)
+		}
+		return (<-producer.produced).Topic
+	}
+
+	// The records are written to the topic created for the channel by default
+	if topic, want := produceTopic(), utils.TopicName(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name); topic != want {
+		t.Errorf("Topic = %q, want %q", topic, want)
+	}
+
+	// The channel may adopt an existing topic
+	if _, err := d.ReconcileChannel(&ChannelConfig{Namespace: channelRef.Namespace, Name: channelRef.Name, HostName: "a.b.c.d", Topic: "existing-topic"}); err != nil {
+		t.Fatalf("ReconcileChannel() = %v", err)
+	}
+	if topic := produceTopic(); topic != "existing-topic" {
+		t.Errorf("Topic = %q, want %q", topic, "existing-topic")
+	}
+
+	// Cleaning up the channel forgets the adopted topic
+	if err := d.CleanupChannel(channelRef.Name, channelRef.Namespace); err != nil {
+		t.Fatalf("CleanupChannel() = %v", err)
+	}
+	if _, ok := d.adoptedTopics[channelRef]; ok {
+		t.Errorf("adoptedTopics still contains %v", channelRef)
+	}
+}
+
+// producedCounts returns the number of recorded produce errors and latencies.
+func producedCounts(t *testing.T) (errorCount int64, latencyCount int64) {
+	t.Helper()
+	rows, err := view.RetrieveData(produceErrorCountM.Name())
+	if err != nil {
+		t.Fatalf("RetrieveData() = %v", err)
+	}
+	for _, row := range rows {
+		errorCount += row.Data.(*view.CountData).Value
+	}
+	rows, err = view.RetrieveData(produceLatencyM.Name())
+	if err != nil {
+		t.Fatalf("RetrieveData() = %v", err)
+	}
+	for _, row := range rows {
+		latencyCount += row.Data.(*view.DistributionData).Count
+	}
+	return errorCount, latencyCount
+}
+
 func TestUnsubscribeUnknownSub(t *testing.T) {
 	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
@@ -415,7 +1300,7 @@ func TestUnsubscribeUnknownSub(t *testing.T) {
 		UID:          "test-sub",
 		Subscription: fanout.Subscription{},
 	}
-	if err := d.unsubscribe(channelRef, subRef); err != nil {
+	if err := d.unsubscribe(channelRef, subRef, true); err != nil {
 		t.Errorf("Unsubscribe error: %v", err)
 	}
 }
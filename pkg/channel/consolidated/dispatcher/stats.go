@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	// filteredMessageCountM is the number of events which were not delivered to a subscriber
+	// because they did not match the subscription's filter.
+	filteredMessageCountM = stats.Int64(
+		"filtered_msg_count",
+		"Number of events excluded by a subscription's filter",
+		stats.UnitDimensionless)
+
+	// consumerGroupCleanupFailureCountM is the number of failed attempts to delete the consumer
+	// group of a removed subscription.
+	consumerGroupCleanupFailureCountM = stats.Int64(
+		"consumer_group_cleanup_failure_count",
+		"Number of failed attempts to delete the consumer group of a removed subscription",
+		stats.UnitDimensionless)
+
+	// produceLatencyM is the time taken to write an event received by the ingress to Kafka.
+	produceLatencyM = stats.Float64(
+		"produce_latencies",
+		"Time taken to write an event to Kafka",
+		stats.UnitMilliseconds)
+
+	// produceErrorCountM is the number of events received by the ingress which could not be
+	// written to Kafka.
+	produceErrorCountM = stats.Int64(
+		"produce_error_count",
+		"Number of events which could not be written to Kafka",
+		stats.UnitDimensionless)
+
+	topicTagKey         = tag.MustNewKey("topic")
+	resultTagKey        = tag.MustNewKey("result")
+	subscriptionTagKey  = tag.MustNewKey("subscription_uid")
+	consumerGroupTagKey = tag.MustNewKey("consumer_group")
+)
+
+// record is metrics.Record, which may be replaced in tests to record without a metrics exporter.
+var record = metrics.Record
+
+func init() {
+	err := view.Register(&view.View{
+		Description: filteredMessageCountM.Description(),
+		Measure:     filteredMessageCountM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topicTagKey, subscriptionTagKey},
+	}, &view.View{
+		Description: consumerGroupCleanupFailureCountM.Description(),
+		Measure:     consumerGroupCleanupFailureCountM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{consumerGroupTagKey},
+	}, &view.View{
+		Description: produceLatencyM.Description(),
+		Measure:     produceLatencyM,
+		Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1ms - 10s
+		TagKeys:     []tag.Key{topicTagKey, resultTagKey},
+	}, &view.View{
+		Description: produceErrorCountM.Description(),
+		Measure:     produceErrorCountM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topicTagKey},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// reportFiltered records an event on the specified topic which was excluded by the filter of
+// the specified subscription.
+func reportFiltered(ctx context.Context, topic string, uid types.UID) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(topicTagKey, topic),
+		tag.Insert(subscriptionTagKey, string(uid)))
+	if err != nil {
+		return err
+	}
+	record(ctx, filteredMessageCountM.M(1))
+	return nil
+}
+
+// reportCleanupFailure records a failed attempt to delete the specified consumer group.
+func reportCleanupFailure(groupID string, _ error) {
+	ctx, err := tag.New(context.Background(), tag.Insert(consumerGroupTagKey, groupID))
+	if err != nil {
+		return
+	}
+	record(ctx, consumerGroupCleanupFailureCountM.M(1))
+}
+
+// reportProduced records the latency of an event written to the specified topic, and whether
+// writing it failed.
+func reportProduced(topic string, latency time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	ctx, tagErr := tag.New(context.Background(),
+		tag.Insert(topicTagKey, topic),
+		tag.Insert(resultTagKey, result))
+	if tagErr != nil {
+		return
+	}
+	record(ctx, produceLatencyM.M(float64(latency)/float64(time.Millisecond)))
+	if err != nil {
+		record(ctx, produceErrorCountM.M(1))
+	}
+}
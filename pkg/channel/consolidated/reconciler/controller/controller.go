@@ -37,6 +37,7 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/system"
 
 	kafkaChannelClient "knative.dev/eventing-kafka/pkg/client/injection/client"
@@ -86,6 +87,8 @@ func NewController(
 
 	impl := kafkaChannelReconciler.NewImpl(ctx, r)
 
+	r.uriResolver = resolver.NewURIResolver(ctx, impl.EnqueueKey)
+
 	// Get and Watch the Kakfa config map and dynamically update Kafka configuration.
 	if _, err := kubeclient.Get(ctx).CoreV1().ConfigMaps(system.Namespace()).Get(ctx, "config-kafka", metav1.GetOptions{}); err == nil {
 		cmw.Watch("config-kafka", func(configMap *v1.ConfigMap) {
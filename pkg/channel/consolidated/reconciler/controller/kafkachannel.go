@@ -48,6 +48,7 @@ import (
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller/resources"
@@ -71,6 +72,7 @@ const (
 	dispatcherServiceFailed         = "DispatcherServiceFailed"
 	dispatcherServiceAccountCreated = "DispatcherServiceAccountCreated"
 	dispatcherRoleBindingCreated    = "DispatcherRoleBindingCreated"
+	topicRetained                   = "TopicRetained"
 
 	dispatcherName = "kafka-ch-dispatcher"
 )
@@ -95,6 +97,10 @@ func newRoleBindingWarn(err error) pkgreconciler.Event {
 	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "DispatcherRoleBindingFailed", "Reconciling dispatcher RoleBinding failed: %s", err)
 }
 
+func newDeadLetterSinkWarn(err error) pkgreconciler.Event {
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "DeadLetterSinkResolveFailed", "Resolving the dead letter sink of the channel delivery failed: %s", err)
+}
+
 func init() {
 	// Add run types to the default Kubernetes Scheme so Events can be
 	// logged for run types.
@@ -125,6 +131,9 @@ type Reconciler struct {
 	endpointsLister      corev1listers.EndpointsLister
 	serviceAccountLister corev1listers.ServiceAccountLister
 	roleBindingLister    rbacv1listers.RoleBindingLister
+
+	// uriResolver resolves the dead letter sink of the channel delivery.
+	uriResolver *resolver.URIResolver
 }
 
 var (
@@ -174,7 +183,12 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 	// 4. Dispatcher endpoints to ensure that there's something backing the Service.
 	// 5. K8s service representing the channel that will use ExternalName to point to the Dispatcher k8s service.
 
-	if err := r.createTopic(ctx, kc, kafkaClusterAdmin); err != nil {
+	if topicName := kc.AdoptedTopicName(); topicName != "" {
+		if err := r.verifyTopic(ctx, topicName, kafkaClusterAdmin); err != nil {
+			kc.Status.MarkTopicFailed("TopicVerificationFailed", "error while verifying adopted topic: %s", err)
+			return err
+		}
+	} else if err := r.createTopic(ctx, kc, kafkaClusterAdmin); err != nil {
 		kc.Status.MarkTopicFailed("TopicCreateFailed", "error while creating topic: %s", err)
 		return err
 	}
@@ -243,11 +257,32 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 		return err
 	}
 
+	// Resolve the dead letter sink of the channel delivery, which the dispatcher uses for
+	// the subscribers without a delivery of their own.
+	if err := r.reconcileDeadLetterSink(ctx, kc); err != nil {
+		logger.Errorw("Unable to resolve the dead letter sink of the channel delivery", zap.Error(err))
+		return newDeadLetterSinkWarn(err)
+	}
+
 	// Ok, so now the Dispatcher Deployment & Service have been created, we're golden since the
 	// dispatcher watches the Channel and where it needs to dispatch events to.
 	return newReconciledNormal(kc.Namespace, kc.Name)
 }
 
+func (r *Reconciler) reconcileDeadLetterSink(ctx context.Context, kc *v1beta1.KafkaChannel) error {
+	if kc.Spec.Delivery == nil || kc.Spec.Delivery.DeadLetterSink == nil {
+		kc.Status.SetDeadLetterSinkURI(nil)
+		return nil
+	}
+	uri, err := r.uriResolver.URIFromDestinationV1(ctx, *kc.Spec.Delivery.DeadLetterSink, kc)
+	if err != nil {
+		kc.Status.SetDeadLetterSinkURI(nil)
+		return err
+	}
+	kc.Status.SetDeadLetterSinkURI(uri)
+	return nil
+}
+
 func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope string, dispatcherNamespace string, kc *v1beta1.KafkaChannel) (*appsv1.Deployment, error) {
 	if scope == scopeNamespace {
 		// Configure RBAC in namespace to access the configmaps
@@ -496,6 +531,26 @@ func (r *Reconciler) createTopic(ctx context.Context, channel *v1beta1.KafkaChan
 	return err
 }
 
+// verifyTopic checks that the existing topic adopted by a channel exists, adopted topics are never
+// created nor deleted by the controller.
+func (r *Reconciler) verifyTopic(ctx context.Context, topicName string, kafkaClusterAdmin sarama.ClusterAdmin) error {
+	metadata, err := kafkaClusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Error describing adopted topic", zap.String("topic", topicName), zap.Error(err))
+		return err
+	}
+	for _, topic := range metadata {
+		if topic.Name != topicName {
+			continue
+		}
+		if topic.Err != sarama.ErrNoError {
+			return fmt.Errorf("topic %q: %w", topicName, topic.Err)
+		}
+		return nil
+	}
+	return fmt.Errorf("topic %q: %w", topicName, sarama.ErrUnknownTopicOrPartition)
+}
+
 func (r *Reconciler) deleteTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
@@ -524,10 +579,26 @@ func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.Co
 	r.kafkaConfigError = err
 }
 
+// topicDeletionPolicy returns the deletion policy of the channel's topic, falling back to the
+// policy configured for the cluster.
+func (r *Reconciler) topicDeletionPolicy(kc *v1beta1.KafkaChannel) v1beta1.TopicDeletionPolicy {
+	var defaultPolicy v1beta1.TopicDeletionPolicy
+	if r.kafkaConfig != nil {
+		defaultPolicy = v1beta1.TopicDeletionPolicy(r.kafkaConfig.TopicDeletionPolicy)
+	}
+	return kc.TopicDeletionPolicyOrDefault(defaultPolicy)
+}
+
 func (r *Reconciler) FinalizeKind(ctx context.Context, kc *v1beta1.KafkaChannel) pkgreconciler.Event {
 	// Do not attempt retrying creating the client because it might be a permanent error
 	// in which case the finalizer will never get removed.
-	if kafkaClusterAdmin, err := r.createClient(ctx, kc); err == nil && r.kafkaConfig != nil {
+	if topicName := kc.AdoptedTopicName(); topicName != "" {
+		logging.FromContext(ctx).Infow("Keeping adopted topic on Kafka cluster", zap.String("topic", topicName))
+	} else if r.topicDeletionPolicy(kc) == v1beta1.TopicDeletionPolicyRetain {
+		topicName := utils.TopicName(utils.KafkaChannelSeparator, kc.Namespace, kc.Name)
+		logging.FromContext(ctx).Infow("Retaining topic on Kafka cluster", zap.String("topic", topicName))
+		return pkgreconciler.NewEvent(corev1.EventTypeNormal, topicRetained, "Retained topic %q of KafkaChannel: \"%s/%s\"", topicName, kc.Namespace, kc.Name)
+	} else if kafkaClusterAdmin, err := r.createClient(ctx, kc); err == nil && r.kafkaConfig != nil {
 		if err := r.deleteTopic(ctx, kc, kafkaClusterAdmin); err != nil {
 			return err
 		}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
 
 	"go.uber.org/zap"
 
@@ -29,12 +30,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingClient "knative.dev/eventing/pkg/client/injection/client"
 
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -42,6 +48,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/network"
 	. "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/resolver"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller/resources"
@@ -610,9 +617,342 @@ func TestDeploymentMoreThanOneReplicas(t *testing.T) {
 	}, zap.L()))
 }
 
+func TestAdoptedTopic(t *testing.T) {
+	kcKey := testNS + "/" + kcName
+	table := TableTest{
+		{
+			Name: "adopted topic exists, not created",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				makeReadyDeployment(),
+				makeService(),
+				makeReadyEndpoints(),
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelAdoptedTopic("existing-topic")),
+				makeChannelService(reconcilertesting.NewKafkaChannel(kcName, testNS)),
+			},
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaChannelAdoptedTopic("existing-topic"),
+					reconcilertesting.WithInitKafkaChannelConditions,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelConfigReady(),
+					reconcilertesting.WithKafkaChannelTopicReady(),
+					reconcilertesting.WithKafkaChannelDeploymentReady(),
+					reconcilertesting.WithKafkaChannelServiceReady(),
+					reconcilertesting.WithKafkaChannelEndpointsReady(),
+					reconcilertesting.WithKafkaChannelChannelServiceReady(),
+					reconcilertesting.WithKafkaChannelAddress(channelServiceAddress),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "KafkaChannelReconciled", `KafkaChannel reconciled: "test-namespace/test-kc"`),
+			},
+		}, {
+			Name: "adopted topic does not exist",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelAdoptedTopic("missing-topic")),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaChannelAdoptedTopic("missing-topic"),
+					reconcilertesting.WithInitKafkaChannelConditions,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelConfigReady(),
+					reconcilertesting.WithKafkaChannelTopicNotReady("TopicVerificationFailed",
+						`error while verifying adopted topic: topic "missing-topic": `+sarama.ErrUnknownTopicOrPartition.Error()),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", `topic "missing-topic": `+sarama.ErrUnknownTopicOrPartition.Error()),
+			},
+		}, {
+			Name: "deleting, adopted topic not deleted",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithInitKafkaChannelConditions,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelAdoptedTopic("existing-topic"),
+					reconcilertesting.WithKafkaChannelDeleted),
+			},
+			WantErr: false,
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchRemoveFinalizers(testNS, kcName),
+			},
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, "KafkaChannelReconciled", `KafkaChannel reconciled: "test-namespace/test-kc"`),
+			},
+		},
+	}
+
+	table.Test(t, reconcilertesting.MakeFactory(func(ctx context.Context, listers *reconcilertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+
+		r := &Reconciler{
+			systemNamespace: testNS,
+			dispatcherImage: testDispatcherImage,
+			kafkaConfig: &KafkaConfig{
+				Brokers: []string{brokerName},
+			},
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			endpointsLister:      listers.GetEndpointsLister(),
+			kafkaClusterAdmin: &mockClusterAdmin{
+				mockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+					return fmt.Errorf("unexpected creation of topic %q", topic)
+				},
+				mockDeleteTopicFunc: func(topic string) error {
+					return fmt.Errorf("unexpected deletion of topic %q", topic)
+				},
+				mockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					metadata := make([]*sarama.TopicMetadata, 0, len(topics))
+					for _, topic := range topics {
+						if topic == "missing-topic" {
+							metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Err: sarama.ErrUnknownTopicOrPartition})
+						} else {
+							metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Err: sarama.ErrNoError})
+						}
+					}
+					return metadata, nil
+				},
+			},
+			kafkaClientSet:    fakekafkaclient.Get(ctx),
+			KubeClientSet:     kubeclient.Get(ctx),
+			EventingClientSet: eventingClient.Get(ctx),
+		}
+		return kafkachannel.NewReconciler(ctx, logging.FromContext(ctx), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, zap.L()))
+}
+
+func TestTopicDeletionPolicy(t *testing.T) {
+	kcKey := testNS + "/" + kcName
+	topicName := TopicName(KafkaChannelSeparator, testNS, kcName)
+	retainedEvent := Eventf(corev1.EventTypeNormal, topicRetained, `Retained topic %q of KafkaChannel: "test-namespace/test-kc"`, topicName)
+	reconciledEvent := Eventf(corev1.EventTypeNormal, "KafkaChannelReconciled", `KafkaChannel reconciled: "test-namespace/test-kc"`)
+
+	// deletedTopics records the topics deleted by the reconciler of the current row
+	var deletedTopics []string
+	wantDeletedTopics := func(want ...string) []func(*testing.T, *TableRow) {
+		return []func(*testing.T, *TableRow){func(t *testing.T, _ *TableRow) {
+			if diff := cmp.Diff(want, deletedTopics); diff != "" {
+				t.Errorf("unexpected deleted topics (-want, +got) = %v", diff)
+			}
+		}}
+	}
+	deletedChannel := func(opts ...reconcilertesting.KafkaChannelOption) runtime.Object {
+		opts = append([]reconcilertesting.KafkaChannelOption{
+			reconcilertesting.WithInitKafkaChannelConditions,
+			reconcilertesting.WithKafkaFinalizer(finalizerName),
+			reconcilertesting.WithKafkaChannelDeleted,
+		}, opts...)
+		return reconcilertesting.NewKafkaChannel(kcName, testNS, opts...)
+	}
+
+	testCases := map[string]struct {
+		clusterPolicy string
+		table         TableTest
+	}{
+		"no cluster policy": {
+			table: TableTest{{
+				Name:           "topic retained by the channel policy",
+				Key:            kcKey,
+				Objects:        []runtime.Object{deletedChannel(reconcilertesting.WithKafkaChannelTopicDeletionPolicy(v1beta1.TopicDeletionPolicyRetain))},
+				WantPatches:    []clientgotesting.PatchActionImpl{patchRemoveFinalizers(testNS, kcName)},
+				WantEvents:     []string{finalizerUpdatedEvent, retainedEvent},
+				PostConditions: wantDeletedTopics(),
+			}, {
+				Name:           "topic deleted by default",
+				Key:            kcKey,
+				Objects:        []runtime.Object{deletedChannel()},
+				WantPatches:    []clientgotesting.PatchActionImpl{patchRemoveFinalizers(testNS, kcName)},
+				WantEvents:     []string{finalizerUpdatedEvent, reconciledEvent},
+				PostConditions: wantDeletedTopics(topicName),
+			}},
+		},
+		"cluster policy retains topics": {
+			clusterPolicy: "Retain",
+			table: TableTest{{
+				Name:           "topic retained by the cluster policy",
+				Key:            kcKey,
+				Objects:        []runtime.Object{deletedChannel()},
+				WantPatches:    []clientgotesting.PatchActionImpl{patchRemoveFinalizers(testNS, kcName)},
+				WantEvents:     []string{finalizerUpdatedEvent, retainedEvent},
+				PostConditions: wantDeletedTopics(),
+			}, {
+				Name:           "channel policy overrides the cluster policy",
+				Key:            kcKey,
+				Objects:        []runtime.Object{deletedChannel(reconcilertesting.WithKafkaChannelTopicDeletionPolicy(v1beta1.TopicDeletionPolicyDelete))},
+				WantPatches:    []clientgotesting.PatchActionImpl{patchRemoveFinalizers(testNS, kcName)},
+				WantEvents:     []string{finalizerUpdatedEvent, reconciledEvent},
+				PostConditions: wantDeletedTopics(topicName),
+			}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			tc.table.Test(t, reconcilertesting.MakeFactory(func(ctx context.Context, listers *reconcilertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+				deletedTopics = nil
+
+				r := &Reconciler{
+					systemNamespace: testNS,
+					dispatcherImage: testDispatcherImage,
+					kafkaConfig: &KafkaConfig{
+						Brokers:             []string{brokerName},
+						TopicDeletionPolicy: tc.clusterPolicy,
+					},
+					kafkachannelLister:   listers.GetKafkaChannelLister(),
+					kafkachannelInformer: nil,
+					deploymentLister:     listers.GetDeploymentLister(),
+					serviceLister:        listers.GetServiceLister(),
+					endpointsLister:      listers.GetEndpointsLister(),
+					kafkaClusterAdmin: &mockClusterAdmin{
+						mockDeleteTopicFunc: func(topic string) error {
+							deletedTopics = append(deletedTopics, topic)
+							return nil
+						},
+					},
+					kafkaClientSet:    fakekafkaclient.Get(ctx),
+					KubeClientSet:     kubeclient.Get(ctx),
+					EventingClientSet: eventingClient.Get(ctx),
+				}
+				return kafkachannel.NewReconciler(ctx, logging.FromContext(ctx), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+			}, zap.L()))
+		})
+	}
+}
+
+func TestDeadLetterSink(t *testing.T) {
+	kcKey := testNS + "/" + kcName
+	readyChannel := func(opts ...reconcilertesting.KafkaChannelOption) runtime.Object {
+		opts = append([]reconcilertesting.KafkaChannelOption{
+			reconcilertesting.WithInitKafkaChannelConditions,
+			reconcilertesting.WithKafkaFinalizer(finalizerName),
+			reconcilertesting.WithKafkaChannelConfigReady(),
+			reconcilertesting.WithKafkaChannelTopicReady(),
+			reconcilertesting.WithKafkaChannelDeploymentReady(),
+			reconcilertesting.WithKafkaChannelServiceReady(),
+			reconcilertesting.WithKafkaChannelEndpointsReady(),
+			reconcilertesting.WithKafkaChannelChannelServiceReady(),
+			reconcilertesting.WithKafkaChannelAddress(channelServiceAddress),
+		}, opts...)
+		return reconcilertesting.NewKafkaChannel(kcName, testNS, opts...)
+	}
+	delivery := func(dls duckv1.Destination) *eventingduckv1.DeliverySpec {
+		return &eventingduckv1.DeliverySpec{DeadLetterSink: &dls, Retry: pointer.Int32Ptr(3)}
+	}
+	uriDelivery := delivery(duckv1.Destination{URI: apis.HTTP("dls.example.com")})
+	refDelivery := delivery(duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Namespace: testNS, Name: "dls"}})
+	relativeDelivery := delivery(duckv1.Destination{URI: &apis.URL{Path: "/dls"}})
+
+	table := TableTest{
+		{
+			Name: "dead letter sink URI",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				makeReadyDeployment(),
+				makeService(),
+				makeReadyEndpoints(),
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelDelivery(uriDelivery)),
+				makeChannelService(reconcilertesting.NewKafkaChannel(kcName, testNS)),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: readyChannel(
+					reconcilertesting.WithKafkaChannelDelivery(uriDelivery),
+					reconcilertesting.WithKafkaChannelDeadLetterSinkURI(apis.HTTP("dls.example.com")),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "KafkaChannelReconciled", `KafkaChannel reconciled: "test-namespace/test-kc"`),
+			},
+		}, {
+			Name: "dead letter sink reference",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				makeReadyDeployment(),
+				makeService(),
+				makeReadyEndpoints(),
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelDelivery(refDelivery)),
+				makeChannelService(reconcilertesting.NewKafkaChannel(kcName, testNS)),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: readyChannel(
+					reconcilertesting.WithKafkaChannelDelivery(refDelivery),
+					reconcilertesting.WithKafkaChannelDeadLetterSinkURI(&apis.URL{
+						Scheme: "http",
+						Host:   network.GetServiceHostname("dls", testNS),
+						Path:   "/",
+					}),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "KafkaChannelReconciled", `KafkaChannel reconciled: "test-namespace/test-kc"`),
+			},
+		}, {
+			Name: "dead letter sink not resolvable",
+			Key:  kcKey,
+			Objects: []runtime.Object{
+				makeReadyDeployment(),
+				makeService(),
+				makeReadyEndpoints(),
+				reconcilertesting.NewKafkaChannel(kcName, testNS,
+					reconcilertesting.WithKafkaFinalizer(finalizerName),
+					reconcilertesting.WithKafkaChannelDelivery(relativeDelivery),
+					reconcilertesting.WithKafkaChannelDeadLetterSinkURI(apis.HTTP("old-dls.example.com"))),
+				makeChannelService(reconcilertesting.NewKafkaChannel(kcName, testNS)),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: readyChannel(
+					reconcilertesting.WithKafkaChannelDelivery(relativeDelivery),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "DeadLetterSinkResolveFailed", `Resolving the dead letter sink of the channel delivery failed: URI is not absolute(both scheme and host should be non-empty): "/dls"`),
+			},
+		},
+	}
+
+	table.Test(t, reconcilertesting.MakeFactory(func(ctx context.Context, listers *reconcilertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = addressable.WithDuck(ctx)
+
+		r := &Reconciler{
+			systemNamespace: testNS,
+			dispatcherImage: testDispatcherImage,
+			kafkaConfig: &KafkaConfig{
+				Brokers: []string{brokerName},
+			},
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			endpointsLister:      listers.GetEndpointsLister(),
+			kafkaClusterAdmin:    &mockClusterAdmin{},
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			KubeClientSet:        kubeclient.Get(ctx),
+			EventingClientSet:    eventingClient.Get(ctx),
+			uriResolver:          resolver.NewURIResolver(ctx, func(types.NamespacedName) {}),
+		}
+		return kafkachannel.NewReconciler(ctx, logging.FromContext(ctx), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, zap.L()))
+}
+
 type mockClusterAdmin struct {
-	mockCreateTopicFunc func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
-	mockDeleteTopicFunc func(topic string) error
+	mockCreateTopicFunc    func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
+	mockDeleteTopicFunc    func(topic string) error
+	mockDescribeTopicsFunc func(topics []string) ([]*sarama.TopicMetadata, error)
 }
 
 func (ca *mockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -646,6 +986,9 @@ func (ca *mockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (ca *mockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
+	if ca.mockDescribeTopicsFunc != nil {
+		return ca.mockDescribeTopicsFunc(topics)
+	}
 	return nil, nil
 }
 
@@ -796,3 +1139,12 @@ func patchFinalizers(namespace, name string) clientgotesting.PatchActionImpl {
 	action.Patch = []byte(patch)
 	return action
 }
+
+func patchRemoveFinalizers(namespace, name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	patch := `{"metadata":{"finalizers":[],"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
@@ -114,6 +114,13 @@ func makeEnv(args DispatcherArgs) []corev1.EnvVar {
 	}, {
 		Name:  "CONFIG_LEADERELECTION_NAME",
 		Value: "config-leader-election-kafka",
+	}, {
+		Name: "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
 	}}
 
 	if args.DispatcherScope == "namespace" {
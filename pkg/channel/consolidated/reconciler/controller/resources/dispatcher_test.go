@@ -78,6 +78,13 @@ func TestNewDispatcher(t *testing.T) {
 							}, {
 								Name:  "CONFIG_LEADERELECTION_NAME",
 								Value: "config-leader-election-kafka",
+							}, {
+								Name: "POD_NAME",
+								ValueFrom: &corev1.EnvVarSource{
+									FieldRef: &corev1.ObjectFieldSelector{
+										FieldPath: "metadata.name",
+									},
+								},
 							},
 							},
 							Ports: []corev1.ContainerPort{{
@@ -161,6 +168,13 @@ func TestNewNamespaceDispatcher(t *testing.T) {
 							}, {
 								Name:  "CONFIG_LEADERELECTION_NAME",
 								Value: "config-leader-election-kafka",
+							}, {
+								Name: "POD_NAME",
+								ValueFrom: &corev1.EnvVarSource{
+									FieldRef: &corev1.ObjectFieldSelector{
+										FieldPath: "metadata.name",
+									},
+								},
 							}, {
 								Name: "NAMESPACE",
 								ValueFrom: &corev1.EnvVarSource{
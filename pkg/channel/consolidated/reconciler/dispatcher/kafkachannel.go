@@ -19,10 +19,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
@@ -32,14 +32,17 @@ import (
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/tracing"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/dispatcher"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/sharding"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkaScheme "knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
@@ -47,6 +50,19 @@ import (
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/partition"
+)
+
+const (
+	// podNameEnvKey is the environment variable holding the name of the dispatcher pod, which
+	// shards the subscriptions across the dispatcher replicas when set.
+	podNameEnvKey = "POD_NAME"
+
+	// dispatcherGroup is the group of the membership leases of the dispatcher replicas.
+	dispatcherGroup = "kafka-ch-dispatcher"
 )
 
 func init() {
@@ -58,6 +74,9 @@ func init() {
 // Reconciler reconciles Kafka Channels.
 type Reconciler struct {
 	kafkaDispatcher *dispatcher.KafkaDispatcher
+	// membership shards the subscriptions across the dispatcher replicas, and collects the statuses
+	// of the subscriptions from the replicas owning them.  It is nil when the dispatcher is not sharded.
+	membership *sharding.Membership
 
 	kafkaClientSet       kafkaclientset.Interface
 	kafkachannelLister   listers.KafkaChannelLister
@@ -68,6 +87,10 @@ type Reconciler struct {
 // Check that our Reconciler implements controller.Reconciler.
 var _ kafkachannelreconciler.Interface = (*Reconciler)(nil)
 
+// Check that our Reconciler implements the ReadOnlyInterface, so that every replica consumes its
+// share of the subscriptions, not only the leader.
+var _ kafkachannelreconciler.ReadOnlyInterface = (*Reconciler)(nil)
+
 // NewController initializes the controller and is called by the generated code.
 // Registers event handlers to enqueue events.
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -94,12 +117,38 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	}
 
 	kafkaChannelInformer := kafkachannel.Get(ctx)
+	r := &Reconciler{
+		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
+		kafkachannelLister:   kafkaChannelInformer.Lister(),
+		kafkachannelInformer: kafkaChannelInformer.Informer(),
+	}
+
+	// The subscriptions are sharded across the dispatcher replicas, all channels are reconciled
+	// again when replicas come and go to hand over the subscriptions of their shards, and when
+	// replicas publish new subscription statuses for the leader to update the channels' status.
+	var membership *sharding.Membership
+	if podName := os.Getenv(podNameEnvKey); podName != "" {
+		leaseNamespace := system.Namespace()
+		if injection.HasNamespaceScope(ctx) {
+			leaseNamespace = injection.GetNamespaceScope(ctx)
+		}
+		membership = sharding.NewMembership(logger, kubeclient.Get(ctx), leaseNamespace, dispatcherGroup, podName, func() {
+			r.impl.GlobalResync(kafkaChannelInformer.Informer())
+		})
+	}
+
 	args := &dispatcher.KafkaDispatcherArgs{
-		KnCEConnectionArgs: connectionArgs,
-		ClientID:           "kafka-ch-dispatcher",
-		Brokers:            kafkaConfig.Brokers,
-		TopicFunc:          utils.TopicName,
-		Logger:             logger,
+		KnCEConnectionArgs:              connectionArgs,
+		ClientID:                        "kafka-ch-dispatcher",
+		Brokers:                         kafkaConfig.Brokers,
+		TopicFunc:                       utils.TopicName,
+		Logger:                          logger,
+		ConsumerGroupCleanupGracePeriod: kafkaConfig.ConsumerGroupCleanupGracePeriod,
+	}
+	if membership != nil {
+		args.OwnsSubscription = func(uid types.UID) bool {
+			return membership.Owns(string(uid))
+		}
 	}
 	kafkaDispatcher, err := dispatcher.NewDispatcher(ctx, args)
 	if err != nil {
@@ -108,12 +157,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	logger.Info("Starting the Kafka dispatcher")
 	logger.Infow("Kafka broker configuration", zap.Strings(utils.BrokerConfigMapKey, kafkaConfig.Brokers))
 
-	r := &Reconciler{
-		kafkaDispatcher:      kafkaDispatcher,
-		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
-		kafkachannelLister:   kafkaChannelInformer.Lister(),
-		kafkachannelInformer: kafkaChannelInformer.Informer(),
-	}
+	r.kafkaDispatcher = kafkaDispatcher
+	r.membership = membership
 	r.impl = kafkachannelreconciler.NewImpl(ctx, r)
 
 	logger.Info("Setting up event handlers")
@@ -125,6 +170,12 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 			Handler:    controller.HandleAll(r.impl.Enqueue),
 		})
 
+	// Deleted channels are no longer reconciled, so they are cleaned up from the delete
+	// notifications, including the tombstones of deletions missed by the informer.
+	kafkaChannelInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: r.channelDeleted(logger),
+	})
+
 	logger.Info("Starting dispatcher.")
 	go func() {
 		if err := kafkaDispatcher.Start(ctx); err != nil {
@@ -132,6 +183,16 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		}
 	}()
 
+	if membership != nil {
+		// Join the replicas before the first reconciliation, to not consume the subscriptions
+		// of the other replicas in the meantime.
+		if err := membership.Sync(ctx); err != nil {
+			logger.Warnw("Failed to synchronize the dispatcher replicas", zap.Error(err))
+		}
+		logger.Infow("Sharding the subscriptions across the dispatcher replicas", zap.Strings("replicas", membership.Members()))
+		go membership.Run(ctx)
+	}
+
 	return r.impl
 }
 
@@ -143,46 +204,126 @@ func filterWithAnnotation(namespaced bool) func(obj interface{}) bool {
 }
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel) pkgreconciler.Event {
-	channels, err := r.kafkachannelLister.List(labels.Everything())
+	if !kc.Status.IsReady() {
+		return r.cleanupChannel(ctx, kc)
+	}
+
+	failedSubscriptions, err := r.reconcileChannel(ctx, kc)
 	if err != nil {
-		logging.FromContext(ctx).Error("Error listing kafka channels")
 		return err
 	}
-
-	// TODO: revisit this code. Instead of reading all channels and updating consumers and hostToChannel map for all
-	// why not just reconcile the current channel. With this the UpdateKafkaConsumers can now return SubscribableStatus
-	// for the subscriptions on the channel that is being reconciled.
-	kafkaChannels := make([]*v1beta1.KafkaChannel, 0)
-	for _, channel := range channels {
-		if channel.Status.IsReady() {
-			kafkaChannels = append(kafkaChannels, channel)
-		}
+	// Only the leader updates the status, which reports the subscriptions owned by the other
+	// replicas as they published them.
+	r.reportSubscriptions(kc, failedSubscriptions)
+	kc.Status.SubscribableStatus = r.createSubscribableStatus(&kc.Spec.SubscribableSpec, failedSubscriptions, r.kafkaDispatcher.SubscriptionStartPositions())
+	if len(failedSubscriptions) > 0 {
+		logging.FromContext(ctx).Error("Some kafka subscriptions failed to subscribe")
+		return fmt.Errorf("Some kafka subscriptions failed to subscribe")
 	}
-	config := r.newConfigFromKafkaChannels(kafkaChannels)
-	if err := r.kafkaDispatcher.UpdateHostToChannelMap(config); err != nil {
-		logging.FromContext(ctx).Error("Error updating host to channel map in dispatcher")
-		return err
+	return nil
+}
+
+// ObserveKind applies the channel to the dispatcher on the replicas which are not the leader,
+// without updating its status.
+func (r *Reconciler) ObserveKind(ctx context.Context, kc *v1beta1.KafkaChannel) pkgreconciler.Event {
+	if !kc.Status.IsReady() {
+		return r.cleanupChannel(ctx, kc)
 	}
 
-	failedSubscriptions, err := r.kafkaDispatcher.UpdateKafkaConsumers(config)
+	failedSubscriptions, err := r.reconcileChannel(ctx, kc)
 	if err != nil {
-		logging.FromContext(ctx).Error("Error updating kafka consumers in dispatcher")
 		return err
 	}
-	kc.Status.SubscribableStatus = r.createSubscribableStatus(&kc.Spec.SubscribableSpec, failedSubscriptions)
+	r.reportSubscriptions(kc, failedSubscriptions)
 	if len(failedSubscriptions) > 0 {
-		logging.FromContext(ctx).Error("Some kafka subscriptions failed to subscribe")
+		logging.FromContext(ctx).Errorw("Some kafka subscriptions failed to subscribe", zap.Any("subscriptions", failedSubscriptions))
 		return fmt.Errorf("Some kafka subscriptions failed to subscribe")
 	}
 	return nil
 }
 
-func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.SubscribableSpec, failedSubscriptions map[types.UID]error) eventingduckv1.SubscribableStatus {
+// reconcileChannel applies only the reconciled channel to the dispatcher, returning the
+// subscriptions which failed to subscribe.
+func (r *Reconciler) reconcileChannel(ctx context.Context, kc *v1beta1.KafkaChannel) (map[types.UID]error, error) {
+	failedSubscriptions, err := r.kafkaDispatcher.ReconcileChannel(r.newChannelConfigFromKafkaChannel(ctx, kc))
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Error updating the channel in dispatcher", zap.Error(err))
+		return nil, err
+	}
+	return failedSubscriptions, nil
+}
+
+// cleanupChannel removes the channel from the dispatcher, channels which are not ready neither
+// receive nor dispatch events.
+func (r *Reconciler) cleanupChannel(ctx context.Context, kc *v1beta1.KafkaChannel) error {
+	r.forgetSubscriptions(kc)
+	if err := r.kafkaDispatcher.CleanupChannel(kc.Name, kc.Namespace); err != nil {
+		logging.FromContext(ctx).Errorw("Error cleaning up the channel in dispatcher", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// channelDeleted returns the informer delete handler cleaning up the deleted channel in the dispatcher.
+func (r *Reconciler) channelDeleted(logger *zap.SugaredLogger) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		kc, ok := obj.(*v1beta1.KafkaChannel)
+		if !ok {
+			return
+		}
+		r.forgetSubscriptions(kc)
+		if err := r.kafkaDispatcher.CleanupChannel(kc.Name, kc.Namespace); err != nil {
+			logger.Errorw("Error cleaning up the deleted channel in dispatcher", zap.String("channel", kc.Namespace+"/"+kc.Name), zap.Error(err))
+		}
+	}
+}
+
+// reportSubscriptions publishes the statuses of the channel's subscriptions owned by this replica
+// to the leader, which updates the status of the channel.
+func (r *Reconciler) reportSubscriptions(kc *v1beta1.KafkaChannel, failedSubscriptions map[types.UID]error) {
+	if r.membership == nil {
+		return
+	}
+	status := r.createSubscribableStatus(&kc.Spec.SubscribableSpec, failedSubscriptions, r.kafkaDispatcher.SubscriptionStartPositions())
+	for _, sub := range status.Subscribers {
+		if !r.membership.Owns(string(sub.UID)) {
+			r.membership.Forget(string(sub.UID))
+			continue
+		}
+		r.membership.Report(string(sub.UID), sharding.KeyStatus{
+			Generation: sub.ObservedGeneration,
+			Ready:      sub.Ready == corev1.ConditionTrue,
+			Message:    sub.Message,
+		})
+	}
+}
+
+// forgetSubscriptions stops publishing the statuses of the subscriptions of a removed channel.
+func (r *Reconciler) forgetSubscriptions(kc *v1beta1.KafkaChannel) {
+	if r.membership == nil {
+		return
+	}
+	for _, sub := range kc.Spec.SubscribableSpec.Subscribers {
+		r.membership.Forget(string(sub.UID))
+	}
+}
+
+// createSubscribableStatus reports the subscriptions owned by this replica from the results of
+// subscribing them, and the subscriptions owned by other replicas as they published them: such a
+// subscription is Unknown until its owner published the status of its current generation.
+func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.SubscribableSpec, failedSubscriptions map[types.UID]error, startPositions map[types.UID]string) eventingduckv1.SubscribableStatus {
 	if subscribable == nil {
 		return eventingduckv1.SubscribableStatus{}
 	}
 	subscriberStatus := make([]eventingduckv1.SubscriberStatus, 0)
 	for _, sub := range subscribable.Subscribers {
+		if r.membership != nil && !r.membership.Owns(string(sub.UID)) {
+			subscriberStatus = append(subscriberStatus, r.publishedSubscriberStatus(sub))
+			continue
+		}
 		status := eventingduckv1.SubscriberStatus{
 			UID:                sub.UID,
 			ObservedGeneration: sub.Generation,
@@ -191,6 +332,8 @@ func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.Subsc
 		if err, ok := failedSubscriptions[sub.UID]; ok {
 			status.Ready = corev1.ConditionFalse
 			status.Message = err.Error()
+		} else if startedFrom, ok := startPositions[sub.UID]; ok {
+			status.Message = startedFrom
 		}
 		subscriberStatus = append(subscriberStatus, status)
 	}
@@ -199,21 +342,58 @@ func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.Subsc
 	}
 }
 
-// newConfigFromKafkaChannels creates a new Config from the list of kafka channels.
-func (r *Reconciler) newChannelConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
+// publishedSubscriberStatus returns the status of a subscription owned by another replica.
+func (r *Reconciler) publishedSubscriberStatus(sub eventingduckv1.SubscriberSpec) eventingduckv1.SubscriberStatus {
+	status := eventingduckv1.SubscriberStatus{
+		UID:                sub.UID,
+		ObservedGeneration: sub.Generation,
+		Ready:              corev1.ConditionUnknown,
+		Message:            "Waiting for the dispatcher replica owning the subscription",
+	}
+	published, ok := r.membership.Status(string(sub.UID))
+	if !ok || published.Generation < sub.Generation {
+		return status
+	}
+	status.Ready = corev1.ConditionFalse
+	if published.Ready {
+		status.Ready = corev1.ConditionTrue
+	}
+	status.Message = published.Message
+	return status
+}
+
+// newChannelConfigFromKafkaChannel creates a new ChannelConfig from the kafka channel.
+func (r *Reconciler) newChannelConfigFromKafkaChannel(ctx context.Context, c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
 	channelConfig := dispatcher.ChannelConfig{
-		Namespace: c.Namespace,
-		Name:      c.Name,
-		HostName:  c.Status.Address.URL.Host,
+		Namespace:             c.Namespace,
+		Name:                  c.Name,
+		HostName:              c.Status.Address.URL.Host,
+		PartitionKeyAttribute: partition.KeyAttribute(c.Annotations),
+		Topic:                 c.AdoptedTopicName(),
 	}
 	if c.Spec.SubscribableSpec.Subscribers != nil {
 		newSubs := make([]dispatcher.Subscription, 0, len(c.Spec.SubscribableSpec.Subscribers))
 		for _, source := range c.Spec.SubscribableSpec.Subscribers {
+			// Subscribers without a delivery of their own use the delivery of the channel.
+			source.Delivery = c.SubscriberDelivery(source)
 			innerSub, _ := fanout.SubscriberSpecToFanoutConfig(source)
 
+			subFilter, err := filter.FromAnnotations(c.Annotations, source.UID)
+			if err != nil {
+				logging.FromContext(ctx).Warnw("Ignoring invalid subscription filter", zap.Any("subscription", source.UID), zap.Error(err))
+			}
+			startPosition, err := consumer.StartPositionFromAnnotations(c.Annotations, source.UID)
+			if err != nil {
+				logging.FromContext(ctx).Warnw("Ignoring invalid subscription start position", zap.Any("subscription", source.UID), zap.Error(err))
+			}
+
 			newSubs = append(newSubs, dispatcher.Subscription{
-				Subscription: *innerSub,
-				UID:          source.UID,
+				Subscription:    *innerSub,
+				UID:             source.UID,
+				DeadLetterTopic: dlq.DeadLetterTopic(c.Annotations, source.UID),
+				Filter:          subFilter,
+				StartPosition:   startPosition,
+				Delivery:        source.Delivery,
 			})
 		}
 		channelConfig.Subscriptions = newSubs
@@ -221,15 +401,3 @@ func (r *Reconciler) newChannelConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *
 
 	return &channelConfig
 }
-
-// newConfigFromKafkaChannels creates a new Config from the list of kafka channels.
-func (r *Reconciler) newConfigFromKafkaChannels(channels []*v1beta1.KafkaChannel) *dispatcher.Config {
-	cc := make([]dispatcher.ChannelConfig, 0)
-	for _, c := range channels {
-		channelConfig := r.newChannelConfigFromKafkaChannel(c)
-		cc = append(cc, *channelConfig)
-	}
-	return &dispatcher.Config{
-		ChannelConfigs: cc,
-	}
-}
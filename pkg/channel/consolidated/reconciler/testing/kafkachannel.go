@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -133,3 +134,33 @@ func WithKafkaFinalizer(finalizerName string) KafkaChannelOption {
 		nc.SetFinalizers(finalizers.List())
 	}
 }
+
+func WithKafkaChannelTopicNotReady(reason, message string) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Status.MarkTopicFailed(reason, message)
+	}
+}
+
+func WithKafkaChannelAdoptedTopic(name string) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Spec.Topic = &v1beta1.KafkaChannelTopic{Name: name, Adopt: true}
+	}
+}
+
+func WithKafkaChannelTopicDeletionPolicy(policy v1beta1.TopicDeletionPolicy) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Spec.TopicDeletionPolicy = policy
+	}
+}
+
+func WithKafkaChannelDelivery(delivery *eventingduckv1.DeliverySpec) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Spec.Delivery = delivery
+	}
+}
+
+func WithKafkaChannelDeadLetterSinkURI(uri *apis.URL) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Status.SetDeadLetterSinkURI(uri)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// MemberLabelKey labels the membership leases, its value is the name of the group of replicas.
+	MemberLabelKey = "messaging.knative.dev/dispatcher-member"
+
+	// DefaultLeaseDuration is the time after which a replica which no longer renews its lease is
+	// removed from the ring.
+	DefaultLeaseDuration = 15 * time.Second
+
+	// DefaultRenewInterval is the time between the renewals of the lease of a replica, and between
+	// the refreshes of the members of the ring.
+	DefaultRenewInterval = 5 * time.Second
+)
+
+// now returns the current time, it is a variable to facilitate testing.
+var now = time.Now
+
+// Membership tracks the replicas of a group through one coordination Lease per replica, and owns
+// the keys which the consistent hash ring of the live replicas assigns to this replica.  Each
+// replica publishes the statuses of the keys it owns in its lease, so that any replica can report
+// the status of all keys.
+type Membership struct {
+	logger        *zap.SugaredLogger
+	kubeClient    kubernetes.Interface
+	namespace     string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	onChange      func()
+
+	mutex sync.RWMutex
+	ring  *Ring
+	// statuses are the statuses reported for the keys owned by this replica.
+	statuses map[string]KeyStatus
+	// memberStatuses are the statuses published by the other members, by member.
+	memberStatuses map[string]map[string]KeyStatus
+}
+
+// NewMembership creates the Membership of the replica named identity (usually the pod name) in the
+// group of replicas, whose leases live in the namespace.  The optional onChange function is called
+// whenever the members of the group, or the statuses published by the other members, change.
+// Until the first Sync, the replica owns all keys.
+func NewMembership(logger *zap.SugaredLogger, kubeClient kubernetes.Interface, namespace, group, identity string, onChange func()) *Membership {
+	return &Membership{
+		logger:        logger,
+		kubeClient:    kubeClient,
+		namespace:     namespace,
+		group:         group,
+		identity:      identity,
+		leaseDuration: DefaultLeaseDuration,
+		renewInterval: DefaultRenewInterval,
+		onChange:      onChange,
+		ring:          NewRing([]string{identity}, DefaultVirtualNodes),
+		statuses:      make(map[string]KeyStatus),
+	}
+}
+
+// Owns returns whether this replica owns the key.
+func (m *Membership) Owns(key string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.ring.Owner(key) == m.identity
+}
+
+// Members returns the sorted live members of the group.
+func (m *Membership) Members() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.ring.Members()
+}
+
+// Run renews the lease of this replica and refreshes the members of the group until the context is
+// done, then deletes the lease so that the other replicas take over its keys without waiting for
+// the lease to expire.
+func (m *Membership) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.renewInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.Sync(ctx); err != nil {
+			m.logger.Warnw("Failed to synchronize the dispatcher replicas", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			err := m.kubeClient.CoordinationV1().Leases(m.namespace).Delete(context.Background(), m.identity, metav1.DeleteOptions{})
+			if err != nil && !apierrs.IsNotFound(err) {
+				m.logger.Warnw("Failed to delete the dispatcher replica lease", zap.String("lease", m.identity), zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync renews the lease of this replica, publishing the statuses it reported, deletes the expired
+// leases of the group and rebuilds the ring from the live members, calling onChange if they, or the
+// statuses they published, changed.
+func (m *Membership) Sync(ctx context.Context) error {
+	if err := m.renew(ctx); err != nil {
+		return err
+	}
+
+	leases, err := m.kubeClient.CoordinationV1().Leases(m.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{MemberLabelKey: m.group}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	members := []string{m.identity}
+	memberStatuses := make(map[string]map[string]KeyStatus)
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if lease.Name == m.identity {
+			continue
+		}
+		if m.expired(lease) {
+			// Leases of replicas which did not shut down cleanly, a replica which renews its
+			// lease again recreates it.
+			err := m.kubeClient.CoordinationV1().Leases(m.namespace).Delete(ctx, lease.Name, metav1.DeleteOptions{})
+			if err != nil && !apierrs.IsNotFound(err) {
+				m.logger.Warnw("Failed to delete an expired dispatcher replica lease", zap.String("lease", lease.Name), zap.Error(err))
+			}
+			continue
+		}
+		members = append(members, lease.Name)
+		statuses, err := decodeStatuses(lease)
+		if err != nil {
+			m.logger.Warnw("Ignoring the invalid statuses of a dispatcher replica", zap.String("lease", lease.Name), zap.Error(err))
+		} else if len(statuses) > 0 {
+			memberStatuses[lease.Name] = statuses
+		}
+	}
+
+	ring := NewRing(members, DefaultVirtualNodes)
+	m.mutex.Lock()
+	changed := !reflect.DeepEqual(ring.Members(), m.ring.Members())
+	if changed {
+		m.ring = ring
+	}
+	statusesChanged := !reflect.DeepEqual(memberStatuses, m.memberStatuses)
+	m.memberStatuses = memberStatuses
+	m.mutex.Unlock()
+
+	if changed {
+		m.logger.Infow("Dispatcher replicas changed", zap.Strings("members", ring.Members()))
+	}
+	if (changed || statusesChanged) && m.onChange != nil {
+		m.onChange()
+	}
+	return nil
+}
+
+// renew creates or renews the lease of this replica, publishing the statuses it reported.
+func (m *Membership) renew(ctx context.Context) error {
+	leases := m.kubeClient.CoordinationV1().Leases(m.namespace)
+	renewTime := metav1.NewMicroTime(now())
+	leaseDurationSeconds := int32(m.leaseDuration / time.Second)
+	statuses, err := m.encodeStatuses()
+	if err != nil {
+		return err
+	}
+
+	lease, err := leases.Get(ctx, m.identity, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        m.identity,
+				Namespace:   m.namespace,
+				Labels:      map[string]string{MemberLabelKey: m.group},
+				Annotations: map[string]string{StatusAnnotationKey: statuses},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &m.identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	lease = lease.DeepCopy()
+	if lease.Annotations == nil {
+		lease.Annotations = make(map[string]string)
+	}
+	lease.Annotations[StatusAnnotationKey] = statuses
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.RenewTime = &renewTime
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// expired returns whether the lease has not been renewed within its duration.
+func (m *Membership) expired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+	duration := m.leaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return lease.Spec.RenewTime.Add(duration).Before(now())
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testNamespace = "knative-eventing"
+	testGroup     = "kafka-ch-dispatcher"
+)
+
+func memberLease(name, group string, renewed time.Time) *coordinationv1.Lease {
+	renewTime := metav1.NewMicroTime(renewed)
+	duration := int32(DefaultLeaseDuration / time.Second)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{MemberLabelKey: group},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &name,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewTime,
+		},
+	}
+}
+
+func TestMembershipSync(t *testing.T) {
+	start := time.Now()
+	now = func() time.Time { return start }
+	t.Cleanup(func() { now = time.Now })
+
+	kubeClient := fake.NewSimpleClientset(
+		memberLease("dispatcher-b", testGroup, start.Add(-time.Second)),
+		memberLease("dispatcher-expired", testGroup, start.Add(-time.Minute)),
+		memberLease("other-dispatcher", "other-group", start),
+	)
+	changes := 0
+	m := NewMembership(zap.NewNop().Sugar(), kubeClient, testNamespace, testGroup, "dispatcher-a", func() { changes++ })
+
+	// Until the first Sync, the replica owns all keys
+	for i := 0; i < 100; i++ {
+		if key := fmt.Sprintf("subscription-%d", i); !m.Owns(key) {
+			t.Fatalf("Owns(%q) = false before the first Sync", key)
+		}
+	}
+
+	if err := m.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if diff := cmp.Diff([]string{"dispatcher-a", "dispatcher-b"}, m.Members()); diff != "" {
+		t.Errorf("Members() (-want, +got) = %v", diff)
+	}
+	if changes != 1 {
+		t.Errorf("onChange called %d times, want 1", changes)
+	}
+	lease, err := kubeClient.CoordinationV1().Leases(testNamespace).Get(context.Background(), "dispatcher-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("lease of the replica was not created: %v", err)
+	}
+	if lease.Labels[MemberLabelKey] != testGroup || !lease.Spec.RenewTime.Time.Equal(metav1.NewMicroTime(start).Time) {
+		t.Errorf("unexpected lease %v", lease)
+	}
+	if _, err := kubeClient.CoordinationV1().Leases(testNamespace).Get(context.Background(), "dispatcher-expired", metav1.GetOptions{}); !apierrs.IsNotFound(err) {
+		t.Errorf("expired lease was not deleted: %v", err)
+	}
+
+	// The keys are shared with the other replica
+	ring := NewRing([]string{"dispatcher-a", "dispatcher-b"}, DefaultVirtualNodes)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("subscription-%d", i)
+		if got, want := m.Owns(key), ring.Owner(key) == "dispatcher-a"; got != want {
+			t.Errorf("Owns(%q) = %v, want %v", key, got, want)
+		}
+	}
+
+	// Renewing the lease without membership changes does not call onChange
+	later := start.Add(DefaultRenewInterval)
+	now = func() time.Time { return later }
+	if err := m.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if changes != 1 {
+		t.Errorf("onChange called %d times, want 1", changes)
+	}
+	lease, _ = kubeClient.CoordinationV1().Leases(testNamespace).Get(context.Background(), "dispatcher-a", metav1.GetOptions{})
+	if !lease.Spec.RenewTime.Time.Equal(metav1.NewMicroTime(later).Time) {
+		t.Errorf("lease renewed at %v, want %v", lease.Spec.RenewTime, later)
+	}
+
+	// A replica whose lease expires leaves the ring
+	now = func() time.Time { return start.Add(time.Minute) }
+	if err := m.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if diff := cmp.Diff([]string{"dispatcher-a"}, m.Members()); diff != "" {
+		t.Errorf("Members() (-want, +got) = %v", diff)
+	}
+	if changes != 2 {
+		t.Errorf("onChange called %d times, want 2", changes)
+	}
+}
+
+func TestMembershipStatuses(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	changes := 0
+	a := NewMembership(zap.NewNop().Sugar(), kubeClient, testNamespace, testGroup, "dispatcher-a", func() { changes++ })
+	b := NewMembership(zap.NewNop().Sugar(), kubeClient, testNamespace, testGroup, "dispatcher-b", nil)
+	sync := func(members ...*Membership) {
+		t.Helper()
+		for _, m := range members {
+			if err := m.Sync(context.Background()); err != nil {
+				t.Fatalf("Sync() = %v", err)
+			}
+		}
+	}
+	sync(a, b, a)
+
+	// Find a key owned by each replica
+	var keyA, keyB string
+	for i := 0; keyA == "" || keyB == ""; i++ {
+		if key := fmt.Sprintf("subscription-%d", i); a.Owns(key) {
+			keyA = key
+		} else {
+			keyB = key
+		}
+	}
+
+	// The statuses of the keys are unknown until their owners report them
+	if status, ok := a.Status(keyB); ok {
+		t.Errorf("Status(%q) = %v before it was reported", keyB, status)
+	}
+
+	// The replicas read the statuses published by the owners of the keys
+	statusA := KeyStatus{Generation: 1, Ready: true}
+	statusB := KeyStatus{Generation: 2, Message: "failed to subscribe"}
+	a.Report(keyA, statusA)
+	b.Report(keyB, statusB)
+	changesBefore := changes
+	sync(b, a)
+	if status, ok := a.Status(keyA); !ok || status != statusA {
+		t.Errorf("Status(%q) = %v, %v, want %v", keyA, status, ok, statusA)
+	}
+	if status, ok := a.Status(keyB); !ok || status != statusB {
+		t.Errorf("Status(%q) = %v, %v, want %v", keyB, status, ok, statusB)
+	}
+	if changes != changesBefore+1 {
+		t.Errorf("onChange called %d times, want %d", changes, changesBefore+1)
+	}
+
+	// A key whose status is forgotten by its owner is unknown again
+	b.Forget(keyB)
+	sync(b, a)
+	if status, ok := a.Status(keyB); ok {
+		t.Errorf("Status(%q) = %v after it was forgotten", keyB, status)
+	}
+}
+
+func TestMembershipRunDeletesLease(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	m := NewMembership(zap.NewNop().Sugar(), kubeClient, testNamespace, testGroup, "dispatcher-a", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := kubeClient.CoordinationV1().Leases(testNamespace).Get(context.Background(), "dispatcher-a", metav1.GetOptions{}); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("lease of the replica was not created")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return")
+	}
+	if _, err := kubeClient.CoordinationV1().Leases(testNamespace).Get(context.Background(), "dispatcher-a", metav1.GetOptions{}); !apierrs.IsNotFound(err) {
+		t.Errorf("lease of the replica was not deleted: %v", err)
+	}
+}
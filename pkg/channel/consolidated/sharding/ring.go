@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding distributes the subscriptions of the consolidated dispatcher across its
+// replicas, using a consistent hash ring of the replicas which currently hold a membership lease.
+package sharding
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// DefaultVirtualNodes is the number of points each member has on the ring, which evens out the
+// share of the keys owned by each member.
+const DefaultVirtualNodes = 100
+
+// Ring is an immutable consistent hash ring, adding or removing a member only moves the keys
+// owned by that member.
+type Ring struct {
+	members []string
+	points  []uint64
+	owners  map[uint64]string
+}
+
+// NewRing creates a ring of the specified members, each with virtualNodes points on the ring.
+func NewRing(members []string, virtualNodes int) *Ring {
+	r := &Ring{
+		members: append([]string(nil), members...),
+		points:  make([]uint64, 0, len(members)*virtualNodes),
+		owners:  make(map[uint64]string, len(members)*virtualNodes),
+	}
+	sort.Strings(r.members)
+	for _, member := range r.members {
+		for i := 0; i < virtualNodes; i++ {
+			point := hash(member + "#" + strconv.Itoa(i))
+			if _, ok := r.owners[point]; ok {
+				// Collisions are resolved in favor of the first member in sorted order
+				continue
+			}
+			r.owners[point] = member
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// Owner returns the member owning the key, which is the member of the first point following the
+// key's hash on the ring.  An empty ring returns an empty string.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// Members returns the sorted members of the ring.
+func (r *Ring) Members() []string {
+	return append([]string(nil), r.members...)
+}
+
+// hash returns the FNV-1a hash of the string, followed by the finalizer of MurmurHash3 which
+// spreads the hashes of similar strings (such as the points of a member) across the ring.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testKeys = 10000
+
+func TestRingDistribution(t *testing.T) {
+	members := []string{"dispatcher-a", "dispatcher-b", "dispatcher-c", "dispatcher-d"}
+	ring := NewRing(members, DefaultVirtualNodes)
+
+	owned := make(map[string]int)
+	for i := 0; i < testKeys; i++ {
+		owned[ring.Owner(fmt.Sprintf("subscription-%d", i))]++
+	}
+	if len(owned) != len(members) {
+		t.Fatalf("keys owned by %v, want all of %v", owned, members)
+	}
+	fair := testKeys / len(members)
+	for member, count := range owned {
+		if count < fair/2 || count > fair*3/2 {
+			t.Errorf("%s owns %d keys, want about %d", member, count, fair)
+		}
+	}
+}
+
+func TestRingStability(t *testing.T) {
+	before := NewRing([]string{"dispatcher-a", "dispatcher-b", "dispatcher-c"}, DefaultVirtualNodes)
+	after := NewRing([]string{"dispatcher-c", "dispatcher-a", "dispatcher-b", "dispatcher-d"}, DefaultVirtualNodes)
+
+	moved := 0
+	for i := 0; i < testKeys; i++ {
+		key := fmt.Sprintf("subscription-%d", i)
+		if owner := after.Owner(key); owner != before.Owner(key) {
+			moved++
+			// Only the keys taken over by the new member move
+			if owner != "dispatcher-d" {
+				t.Fatalf("%s moved from %s to %s", key, before.Owner(key), owner)
+			}
+		}
+	}
+	if moved == 0 || moved > testKeys/2 {
+		t.Errorf("%d keys moved to the new member, want about %d", moved, testKeys/4)
+	}
+}
+
+func TestRingMembers(t *testing.T) {
+	ring := NewRing([]string{"b", "a"}, DefaultVirtualNodes)
+	if diff := cmp.Diff([]string{"a", "b"}, ring.Members()); diff != "" {
+		t.Errorf("Members() (-want, +got) = %v", diff)
+	}
+	if owner := NewRing(nil, DefaultVirtualNodes).Owner("key"); owner != "" {
+		t.Errorf("Owner() of an empty ring = %q, want none", owner)
+	}
+}
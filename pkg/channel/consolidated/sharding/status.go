@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"encoding/json"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+)
+
+// StatusAnnotationKey annotates the membership lease of a replica with the JSON encoded statuses of
+// the keys it owns.
+const StatusAnnotationKey = "messaging.knative.dev/dispatcher-status"
+
+// KeyStatus is the status of a key, as reported by the replica owning it.
+type KeyStatus struct {
+	// Generation is the generation of the key's configuration which the status reports on.
+	Generation int64 `json:"generation,omitempty"`
+	// Ready is whether the replica is processing the key.
+	Ready bool `json:"ready"`
+	// Message describes the status, usually the reason the key is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+// Report sets the status of a key owned by this replica, which is published to the other replicas
+// with the next renewal of its lease.
+func (m *Membership) Report(key string, status KeyStatus) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.statuses[key] = status
+}
+
+// Forget stops publishing the status of a key which this replica no longer owns or which was
+// removed.
+func (m *Membership) Forget(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.statuses, key)
+}
+
+// Status returns the status of the key reported by the replica owning it, if that replica has
+// reported one yet.
+func (m *Membership) Status(key string) (KeyStatus, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	owner := m.ring.Owner(key)
+	if owner == m.identity {
+		status, ok := m.statuses[key]
+		return status, ok
+	}
+	status, ok := m.memberStatuses[owner][key]
+	return status, ok
+}
+
+// encodeStatuses returns the annotation value publishing the statuses reported by this replica.
+func (m *Membership) encodeStatuses() (string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	value, err := json.Marshal(m.statuses)
+	return string(value), err
+}
+
+// decodeStatuses returns the statuses published in the lease of a member, nil if there are none.
+func decodeStatuses(lease *coordinationv1.Lease) (map[string]KeyStatus, error) {
+	value, ok := lease.Annotations[StatusAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var statuses map[string]KeyStatus
+	if err := json.Unmarshal([]byte(value), &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
@@ -20,10 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	"knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/pkg/configmap"
 )
 
@@ -31,6 +33,12 @@ const (
 	BrokerConfigMapKey           = "bootstrapServers"
 	MaxIdleConnectionsKey        = "maxIdleConns"
 	MaxIdleConnectionsPerHostKey = "maxIdleConnsPerHost"
+	// ConsumerGroupCleanupGracePeriodKey is the time after which the consumer group of a removed
+	// subscription is deleted, a negative duration disables the deletion.
+	ConsumerGroupCleanupGracePeriodKey = "consumerGroupCleanupGracePeriod"
+	// TopicDeletionPolicyKey is the deletion policy of the topics of the channels which do not
+	// specify one, either Delete (the default) or Retain.
+	TopicDeletionPolicyKey = "topicDeletionPolicy"
 
 	KafkaChannelSeparator = "."
 
@@ -47,9 +55,12 @@ const (
 )
 
 type KafkaConfig struct {
-	Brokers             []string
-	MaxIdleConns        int32
-	MaxIdleConnsPerHost int32
+	Brokers                         []string
+	MaxIdleConns                    int32
+	MaxIdleConnsPerHost             int32
+	ConsumerGroupCleanupGracePeriod time.Duration
+	// TopicDeletionPolicy is the default deletion policy of the topics, empty for Delete.
+	TopicDeletionPolicy string
 }
 
 // GetKafkaConfig returns the details of the Kafka cluster.
@@ -59,8 +70,9 @@ func GetKafkaConfig(configMap map[string]string) (*KafkaConfig, error) {
 	}
 
 	config := &KafkaConfig{
-		MaxIdleConns:        DefaultMaxIdleConns,
-		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		MaxIdleConns:                    DefaultMaxIdleConns,
+		MaxIdleConnsPerHost:             DefaultMaxIdleConnsPerHost,
+		ConsumerGroupCleanupGracePeriod: consumer.DefaultGroupCleanupGracePeriod,
 	}
 
 	var bootstrapServers string
@@ -69,11 +81,19 @@ func GetKafkaConfig(configMap map[string]string) (*KafkaConfig, error) {
 		configmap.AsString(BrokerConfigMapKey, &bootstrapServers),
 		configmap.AsInt32(MaxIdleConnectionsKey, &config.MaxIdleConns),
 		configmap.AsInt32(MaxIdleConnectionsPerHostKey, &config.MaxIdleConnsPerHost),
+		configmap.AsDuration(ConsumerGroupCleanupGracePeriodKey, &config.ConsumerGroupCleanupGracePeriod),
+		configmap.AsString(TopicDeletionPolicyKey, &config.TopicDeletionPolicy),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	switch config.TopicDeletionPolicy {
+	case "", "Delete", "Retain":
+	default:
+		return nil, fmt.Errorf("invalid %s value %q in configuration, expected either Delete or Retain", TopicDeletionPolicyKey, config.TopicDeletionPolicy)
+	}
+
 	if bootstrapServers == "" {
 		return nil, errors.New("missing or empty key bootstrapServers in configuration")
 	}
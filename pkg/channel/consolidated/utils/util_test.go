@@ -18,6 +18,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -74,74 +75,113 @@ func TestGetKafkaConfig(t *testing.T) {
 			name: "single bootstrapServers",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "multiple bootstrapServers",
 			data: map[string]string{"bootstrapServers": "kafkabroker1.kafka:9092,kafkabroker2.kafka:9092"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker1.kafka:9092", "kafkabroker2.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker1.kafka:9092", "kafkabroker2.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "partition consumer",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "partitions"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "default multiplex",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "multiplex"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "default multiplex from invalid consumerMode",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "foo"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "default multiplex from invalid consumerMode elevated max idle connections",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "foo", "maxIdleConns": "9000"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        9000,
-				MaxIdleConnsPerHost: 100,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    9000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "default multiplex from invalid consumerMode elevated max idle connections per host",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "foo", "maxIdleConnsPerHost": "900"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        1000,
-				MaxIdleConnsPerHost: 900,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             900,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
 		{
 			name: "default multiplex from invalid consumerMode elevated max idle values",
 			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerMode": "foo", "maxIdleConns": "9000", "maxIdleConnsPerHost": "600"},
 			expected: &KafkaConfig{
-				Brokers:             []string{"kafkabroker.kafka:9092"},
-				MaxIdleConns:        9000,
-				MaxIdleConnsPerHost: 600,
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    9000,
+				MaxIdleConnsPerHost:             600,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
 			},
 		},
+		{
+			name: "consumer group cleanup grace period",
+			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerGroupCleanupGracePeriod": "30s"},
+			expected: &KafkaConfig{
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 30 * time.Second,
+			},
+		},
+		{
+			name:     "invalid consumer group cleanup grace period",
+			data:     map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "consumerGroupCleanupGracePeriod": "soon"},
+			getError: `failed to parse "consumerGroupCleanupGracePeriod": time: invalid duration "soon"`,
+		},
+		{
+			name: "topic deletion policy",
+			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "topicDeletionPolicy": "Retain"},
+			expected: &KafkaConfig{
+				Brokers:                         []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:                    1000,
+				MaxIdleConnsPerHost:             100,
+				ConsumerGroupCleanupGracePeriod: 5 * time.Minute,
+				TopicDeletionPolicy:             "Retain",
+			},
+		},
+		{
+			name:     "invalid topic deletion policy",
+			data:     map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "topicDeletionPolicy": "Orphan"},
+			getError: `invalid topicDeletionPolicy value "Orphan" in configuration, expected either Delete or Retain`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7,6 +7,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/injection/sharedmain"
@@ -22,6 +23,26 @@ type EKKubernetesConfig struct {
 	MemoryLimit   resource.Quantity `json:"memoryLimit,omitempty"`
 	MemoryRequest resource.Quantity `json:"memoryRequest,omitempty"`
 	Replicas      int               `json:"replicas,omitempty"`
+
+	Autoscaling         EKAutoscalingConfig         `json:"autoscaling,omitempty"`
+	PodDisruptionBudget EKPodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+}
+
+// EKAutoscalingConfig contains the optional HorizontalPodAutoscaler settings for a Deployment, which
+// is only autoscaled if MaxReplicas is set.  The target is the average CPU utilization and / or the
+// average value of a custom (per-Pod) metric.
+type EKAutoscalingConfig struct {
+	MinReplicas                    int32             `json:"minReplicas,omitempty"`
+	MaxReplicas                    int32             `json:"maxReplicas,omitempty"`
+	TargetCpuUtilizationPercentage int32             `json:"targetCpuUtilizationPercentage,omitempty"`
+	CustomMetricName               string            `json:"customMetricName,omitempty"`
+	CustomMetricTargetAverageValue resource.Quantity `json:"customMetricTargetAverageValue,omitempty"`
+}
+
+// EKPodDisruptionBudgetConfig contains the optional PodDisruptionBudget settings for a Deployment, which
+// only has a PodDisruptionBudget if MinAvailable (a number or percentage of Pods) is set.
+type EKPodDisruptionBudgetConfig struct {
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 }
 
 // The Channel config has nothing in it except the base Kubernetes fields (Cpu, Memory, Replicas)
@@ -32,19 +53,29 @@ type EKChannelConfig struct {
 // The Dispatcher config has the base Kubernetes fields and some retry settings
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+	ConsumerGroupCleanupGracePeriodMillis int64 `json:"consumerGroupCleanupGracePeriodMillis,omitempty"`
 }
 
 // EKKafkaTopicConfig contains some defaults that are only used if not provided by the channel spec
 type EKKafkaTopicConfig struct {
-	DefaultNumPartitions     int32 `json:"defaultNumPartitions,omitempty"`
-	DefaultReplicationFactor int16 `json:"defaultReplicationFactor,omitempty"`
-	DefaultRetentionMillis   int64 `json:"defaultRetentionMillis,omitempty"`
+	DefaultNumPartitions     int32  `json:"defaultNumPartitions,omitempty"`
+	DefaultReplicationFactor int16  `json:"defaultReplicationFactor,omitempty"`
+	DefaultRetentionMillis   int64  `json:"defaultRetentionMillis,omitempty"`
+	DefaultDeletionPolicy    string `json:"defaultDeletionPolicy,omitempty"`
+}
+
+// EKStrimziConfig contains the settings used by the "strimzi" AdminType when managing KafkaTopic custom resources
+type EKStrimziConfig struct {
+	ClusterName        string `json:"clusterName,omitempty"`
+	Namespace          string `json:"namespace,omitempty"`
+	ReadyTimeoutMillis int64  `json:"readyTimeoutMillis,omitempty"`
 }
 
 // EKKafkaConfig contains items relevant to Kafka specifically
 type EKKafkaConfig struct {
 	Topic     EKKafkaTopicConfig `json:"topic,omitempty"`
 	AdminType string             `json:"adminType,omitempty"`
+	Strimzi   EKStrimziConfig    `json:"strimzi,omitempty"`
 }
 
 // EventingKafkaConfig is the main struct that holds the Channel, Dispatcher, and Kafka sub-items
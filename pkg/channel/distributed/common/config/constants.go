@@ -4,7 +4,13 @@ package config
 const (
 	// The name of the configmap used to hold eventing-kafka settings
 	SettingsConfigMapName = "config-eventing-kafka"
+	// The label of the eventing-kafka configmap selecting it for validation by the webhook
+	SettingsConfigMapLabel = "eventing-kafka.knative.dev/settings"
 	// The name of the keys in the Data section of the eventing-kafka configmap that holds Sarama and Eventing-Kafka configuration YAML
 	SaramaSettingsConfigKey        = "sarama"
 	EventingKafkaSettingsConfigKey = "eventing-kafka"
+	// The name of the key in the Data section of the eventing-kafka configmap that holds the version of its schema
+	VersionConfigKey = "version"
+	// The supported version of the eventing-kafka configmap schema (an unspecified version is treated as v1)
+	SettingsVersionV1 = "v1"
 )
@@ -1,8 +1,13 @@
 package constants
 
+import kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+
 // Constants
 const (
 
 	// Knative Eventing Namespace
 	KnativeEventingNamespace = "knative-eventing"
+
+	// KafkaChannel Label Selecting The Dispatcher Pool Shared By The (Low Traffic) KafkaChannel Instead Of A Dedicated Dispatcher
+	DispatcherPoolLabel = kafkav1beta1.DispatcherPoolLabel
 )
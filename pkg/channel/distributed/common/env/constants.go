@@ -21,6 +21,7 @@ const (
 	KnativeLoggingConfigMapNameEnvVarKey = "CONFIG_LOGGING_NAME" // Note - Matches value of configMapNameEnv constant in Knative.dev/pkg/logging !
 
 	// Dispatcher Configuration
-	ChannelKeyEnvVarKey  = "CHANNEL_KEY"
-	ServiceNameEnvVarKey = "SERVICE_NAME"
+	ChannelKeyEnvVarKey             = "CHANNEL_KEY"
+	ServiceNameEnvVarKey            = "SERVICE_NAME"
+	DispatcherPoolSelectorEnvVarKey = "DISPATCHER_POOL_SELECTOR" // Label Selector Of The KafkaChannels Served By A Pooled Dispatcher
 )
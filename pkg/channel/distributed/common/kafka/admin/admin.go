@@ -9,14 +9,31 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 )
 
+//
 // Sarama ClusterAdmin Wrapping Interface To Facilitate Other Implementations (e.g. Azure EventHubs)
+//
+// Topic scoped operations return a Sarama TopicError (ErrNoError == Success) while the remaining
+// operations return a standard error (see DescribeTopic for the one exception).  Implementations
+// which are unable to support an operation (e.g. Azure EventHubs) will return ErrUnsupportedOperation,
+// or a TopicError with the sarama.ErrUnsupportedVersion KError for topic scoped operations.
+//
 type AdminClientInterface interface {
 	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
 	DeleteTopic(context.Context, string) *sarama.TopicError
+	// DescribeTopic Returns A Nil TopicError (Rather Than ErrNoError) Along With The TopicDetail On Success
+	DescribeTopic(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError)
+	ListTopics(context.Context) (map[string]sarama.TopicDetail, error)
+	CreatePartitions(context.Context, string, int32) *sarama.TopicError
+	AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError
+	ListConsumerGroupOffsets(context.Context, string, map[string][]int32) (map[string]map[int32]int64, error)
+	DeleteConsumerGroup(context.Context, string) error
 	Close() error
 	GetKafkaSecretName(topicName string) string
 }
 
+// Error Returned By AdminClient Implementations For Operations They Do Not Support
+var ErrUnsupportedOperation = errors.New("operation not supported by AdminClient type")
+
 // AdminClient Type Enumeration
 type AdminClientType int
 
@@ -24,6 +41,7 @@ const (
 	Kafka AdminClientType = iota
 	EventHub
 	Custom
+	Strimzi
 	Unknown
 )
 
@@ -50,6 +68,12 @@ const (
 //
 // * If no authorization is required (local dev instance) then specify username and password as the empty string ""
 //
+// For the Strimzi use case the single Kafka Secret is as described for the normal Kafka use case, and the
+// "kafka.strimzi" section of the config-eventing-kafka ConfigMap identifies the Strimzi cluster and namespace.
+//
+// Multiple Kafka clusters are supported by scoping the context to a single named Kafka Secret (see
+// util.WithKafkaSecretName()) in which case only that Secret is considered by the above AdminClients.
+//
 func CreateAdminClient(ctx context.Context, saramaConfig *sarama.Config, clientId string, adminClientType AdminClientType) (AdminClientInterface, error) {
 	switch adminClientType {
 	case Kafka:
@@ -58,6 +82,8 @@ func CreateAdminClient(ctx context.Context, saramaConfig *sarama.Config, clientI
 		return NewEventHubAdminClientWrapper(ctx, constants.KnativeEventingNamespace)
 	case Custom:
 		return NewCustomAdminClientWrapper(ctx, constants.KnativeEventingNamespace)
+	case Strimzi:
+		return NewStrimziAdminClientWrapper(ctx, constants.KnativeEventingNamespace)
 	case Unknown:
 		return nil, errors.New("received unknown AdminClientType") // Should Never Happen But...
 	default:
@@ -79,3 +105,8 @@ var NewEventHubAdminClientWrapper = func(ctx context.Context, namespace string)
 var NewCustomAdminClientWrapper = func(ctx context.Context, namespace string) (AdminClientInterface, error) {
 	return NewCustomAdminClient(ctx, namespace)
 }
+
+// New Strimzi AdminClient Wrapper To Facilitate Unit Testing
+var NewStrimziAdminClientWrapper = func(ctx context.Context, namespace string) (AdminClientInterface, error) {
+	return NewStrimziAdminClient(ctx, namespace)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 
@@ -124,6 +125,7 @@ func (c *CustomAdminClient) CreateTopic(_ context.Context, topicName string, top
 	// Populate Required Headers
 	request.Header.Set("Content-Type", "application/json")
 	request.Header.Set(custom.TopicNameHeader, topicName)
+	request.Header.Set(custom.ProtocolVersionHeader, custom.ProtocolVersion)
 
 	// Make The HTTP Request
 	response, err := c.httpClient.Do(request)
@@ -159,6 +161,9 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for creation of topic '%s'", topicName))
 	}
 
+	// Populate Required Headers
+	request.Header.Set(custom.ProtocolVersionHeader, custom.ProtocolVersion)
+
 	// Make The HTTP Request
 	response, err := c.httpClient.Do(request)
 	defer c.safeCloseHTTPResponseBody(response)
@@ -171,6 +176,129 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Describing A Single Topic
+func (c *CustomAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		c.logger.Warn("Received Empty/Nil Topic Name")
+		return nil, adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Perform The HTTP GET Request & Parse The Custom TopicDetail Response
+	customTopicDetail := &custom.TopicDetail{}
+	topicError := c.sidecarRequest("describe", http.MethodGet, c.sidecarTopicsUrl(topicName), nil, customTopicDetail)
+	if topicError.Err != sarama.ErrNoError {
+		return nil, topicError
+	}
+
+	// Return The Sarama TopicDetail - Success (A Nil TopicError, As With The Other AdminClient Implementations)
+	return customTopicDetail.ToSaramaTopicDetail(), nil
+}
+
+// Custom REST Pass-Through Function For Listing All Topics
+func (c *CustomAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+
+	// Perform The HTTP GET Request & Parse The Custom TopicDetail Map Response
+	customTopicDetails := make(map[string]*custom.TopicDetail)
+	topicError := c.sidecarRequest("list", http.MethodGet, c.sidecarTopicsUrl(""), nil, &customTopicDetails)
+	if topicError.Err != sarama.ErrNoError {
+		return nil, topicError
+	}
+
+	// Convert The Custom TopicDetails Into Sarama TopicDetails
+	topicDetails := make(map[string]sarama.TopicDetail, len(customTopicDetails))
+	for topicName, customTopicDetail := range customTopicDetails {
+		if customTopicDetail != nil {
+			topicDetails[topicName] = *customTopicDetail.ToSaramaTopicDetail()
+		}
+	}
+
+	// Return The Sarama TopicDetails - Success
+	return topicDetails, nil
+}
+
+// Custom REST Pass-Through Function For Increasing A Topic's Partition Count
+func (c *CustomAdminClient) CreatePartitions(_ context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+
+	// Validate The Topic
+	if len(topicName) <= 0 || numPartitions <= 0 {
+		c.logger.Warn("Received Empty/Nil Topic Name Or Invalid Partition Count", zap.String("TopicName", topicName), zap.Int32("NumPartitions", numPartitions))
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name and / or invalid partition count")
+	}
+
+	// Perform The HTTP POST Request
+	url := c.sidecarTopicsUrl(topicName) + custom.PartitionsPath
+	return c.sidecarRequest("partitions", http.MethodPost, url, custom.NewCreatePartitionsRequest(numPartitions), nil)
+}
+
+// Custom REST Pass-Through Function For Altering A Topic's Configuration
+func (c *CustomAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		c.logger.Warn("Received Empty/Nil Topic Name")
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Perform The HTTP PUT Request
+	url := c.sidecarTopicsUrl(topicName) + custom.ConfigPath
+	return c.sidecarRequest("config", http.MethodPut, url, configEntries, nil)
+}
+
+// Custom REST Pass-Through Function For Listing A ConsumerGroup's Committed Offsets (Nil TopicPartitions == All)
+func (c *CustomAdminClient) ListConsumerGroupOffsets(_ context.Context, groupId string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+
+	// Validate The ConsumerGroup
+	if len(groupId) <= 0 {
+		c.logger.Warn("Received Empty/Nil ConsumerGroup ID")
+		return nil, errors.New("received empty/nil consumer group id")
+	}
+
+	// Perform The HTTP GET Request & Parse The ConsumerGroupOffsets Response
+	groupOffsets := make(custom.ConsumerGroupOffsets)
+	topicError := c.sidecarRequest("offsets", http.MethodGet, c.sidecarConsumerGroupsUrl(groupId)+custom.OffsetsPath, nil, &groupOffsets)
+	if topicError.Err != sarama.ErrNoError {
+		return nil, topicError
+	}
+
+	// Return All Offsets If No Filter Was Specified
+	if topicPartitions == nil {
+		return groupOffsets, nil
+	}
+
+	// Otherwise Filter The Offsets Down To The Requested Topics / Partitions
+	offsets := make(map[string]map[int32]int64)
+	for topic, partitions := range topicPartitions {
+		if partitionOffsets, ok := groupOffsets[topic]; ok {
+			offsets[topic] = make(map[int32]int64)
+			for _, partition := range partitions {
+				if offset, ok := partitionOffsets[partition]; ok {
+					offsets[topic][partition] = offset
+				}
+			}
+		}
+	}
+	return offsets, nil
+}
+
+// Custom REST Pass-Through Function For Deleting ConsumerGroups
+func (c *CustomAdminClient) DeleteConsumerGroup(_ context.Context, groupId string) error {
+
+	// Validate The ConsumerGroup
+	if len(groupId) <= 0 {
+		c.logger.Warn("Received Empty/Nil ConsumerGroup ID")
+		return errors.New("received empty/nil consumer group id")
+	}
+
+	// Perform The HTTP DELETE Request
+	topicError := c.sidecarRequest("deletegroup", http.MethodDelete, c.sidecarConsumerGroupsUrl(groupId), nil, nil)
+	if topicError.Err != sarama.ErrNoError {
+		return topicError
+	}
+	return nil
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
@@ -200,6 +328,75 @@ func (c *CustomAdminClient) sidecarTopicsUrl(topicName string) string {
 	return topicsUrl
 }
 
+// Get The Expected ConsumerGroups URL For The Custom Sidecar Implementation
+func (c *CustomAdminClient) sidecarConsumerGroupsUrl(groupId string) string {
+	return "http://" + custom.SidecarHost + ":" + custom.SidecarPort + custom.ConsumerGroupsPath + "/" + groupId
+}
+
+//
+// Utility Function For Performing A Generic JSON Request Against The Sidecar
+//
+// The optional requestBody will be marshalled to JSON, and the optional responseBody
+// will be populated by unmarshalling the JSON response of a successful request.  The
+// resulting TopicError always has a non-nil Err (ErrNoError == Success).
+//
+func (c *CustomAdminClient) sidecarRequest(operation string, method string, url string, requestBody interface{}, responseBody interface{}) *sarama.TopicError {
+
+	// Create An Updated Logger With Operation & URL
+	logger := c.logger.With(zap.String("Operation", operation), zap.String("URL", url))
+
+	// Marshal The Request Body If Specified
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			logger.Error("Failed To Marshall Request Body", zap.Any("Body", requestBody), zap.Error(err))
+			return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("failed to marshal request body for '%s' operation", operation))
+		}
+		bodyReader = bytes.NewBuffer(requestBodyBytes)
+	}
+
+	// Create The HTTP Request
+	request, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		logger.Error("Failed To Create New HTTP Request", zap.String("Method", method), zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for '%s' operation", operation))
+	}
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set(custom.ProtocolVersionHeader, custom.ProtocolVersion)
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		logger.Error("HTTP Request To Sidecar Failed", zap.String("Method", method), zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to make http request for '%s' operation", operation))
+	}
+
+	// Read The Response Body
+	responseBodyBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		c.logger.Warn("Failed To Parse Response Body", zap.Error(err))
+	}
+
+	// Map The HTTP Response Into A Sarama TopicError
+	topicError := c.mapStatusCode(operation, response.StatusCode, string(responseBodyBytes))
+
+	// Unmarshal Successful Response Body If Requested
+	if topicError.Err == sarama.ErrNoError && responseBody != nil {
+		err = json.Unmarshal(responseBodyBytes, responseBody)
+		if err != nil {
+			logger.Error("Failed To Unmarshal Response Body", zap.Error(err))
+			return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to unmarshal response body for '%s' operation", operation))
+		}
+	}
+
+	// Return The TopicError
+	return topicError
+}
+
 //
 // Utility Function For Mapping Response Codes To Sarama TopicError Struct
 //
@@ -224,17 +421,8 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 		}
 		responseBodyString := string(responseBodyBytes)
 
-		// Separate Success & Error Response Codes
-		switch {
-		case statusCode >= 200 && statusCode <= 299:
-			return adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 404 && operation == "delete": // 404 Not Found Indicates Topic Does Not Exist In Delete Operation
-			return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
-			return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		default:
-			return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar topic '%s' operation failed with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		}
+		// Map The Status Code & Return
+		return c.mapStatusCode(operation, statusCode, responseBodyString)
 
 	} else {
 
@@ -242,3 +430,21 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 		return adminutil.NewTopicError(sarama.ErrUnknown, "received nil http response")
 	}
 }
+
+// Utility Function For Mapping A Sidecar Response's Status Code & Body To A Sarama TopicError
+func (c *CustomAdminClient) mapStatusCode(operation string, statusCode int, responseBodyString string) *sarama.TopicError {
+	switch {
+	case statusCode >= 200 && statusCode <= 299:
+		return adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 404 && (operation == "offsets" || operation == "deletegroup"): // 404 Not Found Indicates ConsumerGroup Does Not Exist
+		return adminutil.NewTopicError(sarama.ErrGroupIDNotFound, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 404 && operation != "create": // 404 Not Found Indicates Topic Does Not Exist In Delete / Describe / Partitions / Config Operations
+		return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
+		return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 501: // 501 Not Implemented Indicates The Sidecar Does Not Support The Operation
+		return adminutil.NewTopicError(sarama.ErrUnsupportedVersion, fmt.Sprintf("custom sidecar topic '%s' operation not implemented, returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	default:
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar topic '%s' operation failed with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	}
+}
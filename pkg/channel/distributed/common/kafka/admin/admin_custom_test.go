@@ -181,6 +181,194 @@ func TestCustomAdminClientDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The Custom AdminClient DescribeTopic() Functionality
+func TestCustomAdminClientDescribeTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	customTopicDetail := custom.NewTopicDetail(4, 2, nil, map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis})
+
+	// Create & Start The Test Sidecar HTTP Server (Success Response With TopicDetail Body) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.responseBody, _ = json.Marshal(customTopicDetail)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+
+	// Verify The Results (A Nil TopicError On Success, As With The Kafka & Strimzi AdminClients)
+	assert.Nil(t, resultTopicError)
+	assert.Equal(t, customTopicDetail.ToSaramaTopicDetail(), topicDetail)
+	assert.Equal(t, 1, len(mockSidecarServer.requests))
+	for request, body := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, custom.TopicsPath+"/"+topicName, request.URL.Path)
+		assert.Empty(t, body)
+	}
+}
+
+// Test The Custom AdminClient DescribeTopic() Not Found Functionality
+func TestCustomAdminClientDescribeTopicNotFound(t *testing.T) {
+
+	// Create & Start The Test Sidecar HTTP Server (Not Found Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusNotFound)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, "TestTopicName")
+
+	// Verify The Results
+	assert.Nil(t, topicDetail)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, resultTopicError.Err)
+}
+
+// Test The Custom AdminClient ListTopics() Functionality
+func TestCustomAdminClientListTopics(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	customTopicDetails := map[string]*custom.TopicDetail{topicName: custom.NewTopicDetail(4, 2, nil, nil)}
+
+	// Create & Start The Test Sidecar HTTP Server (Success Response With TopicDetails Body) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.responseBody, _ = json.Marshal(customTopicDetails)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	topicDetails, err := adminClient.ListTopics(ctx)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]sarama.TopicDetail{topicName: {NumPartitions: 4, ReplicationFactor: 2}}, topicDetails)
+	for request := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, custom.TopicsPath, request.URL.Path)
+	}
+}
+
+// Test The Custom AdminClient CreatePartitions() Functionality
+func TestCustomAdminClientCreatePartitions(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	numPartitions := int32(8)
+
+	// Create & Start The Test Sidecar HTTP Server (Success Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	resultTopicError := adminClient.CreatePartitions(ctx, topicName, numPartitions)
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, 1, len(mockSidecarServer.requests))
+	for request, body := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, custom.TopicsPath+"/"+topicName+custom.PartitionsPath, request.URL.Path)
+		partitionsRequest := &custom.CreatePartitionsRequest{}
+		assert.Nil(t, json.Unmarshal(body, partitionsRequest))
+		assert.Equal(t, numPartitions, partitionsRequest.NumPartitions)
+	}
+}
+
+// Test The Custom AdminClient AlterTopicConfig() Functionality
+func TestCustomAdminClientAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	configEntries := map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis}
+
+	// Create & Start The Test Sidecar HTTP Server (Not Implemented Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusNotImplemented)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	resultTopicError := adminClient.AlterTopicConfig(ctx, topicName, configEntries)
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, resultTopicError.Err)
+	assert.Equal(t, 1, len(mockSidecarServer.requests))
+	for request, body := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodPut, request.Method)
+		assert.Equal(t, custom.TopicsPath+"/"+topicName+custom.ConfigPath, request.URL.Path)
+		actualConfigEntries := make(map[string]*string)
+		assert.Nil(t, json.Unmarshal(body, &actualConfigEntries))
+		assert.Equal(t, configEntries, actualConfigEntries)
+	}
+}
+
+// Test The Custom AdminClient ListConsumerGroupOffsets() Functionality
+func TestCustomAdminClientListConsumerGroupOffsets(t *testing.T) {
+
+	// Test Data
+	groupId := "TestGroupId"
+	topicName := "TestTopicName"
+	groupOffsets := custom.ConsumerGroupOffsets{
+		topicName:        {0: 123, 1: 456},
+		"TestOtherTopic": {0: 789},
+	}
+
+	// Create & Start The Test Sidecar HTTP Server (Success Response With Offsets Body) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.responseBody, _ = json.Marshal(groupOffsets)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test (Filtered To A Single Partition Of A Single Topic)
+	ctx, adminClient := createTestCustomAdminClient(t)
+	offsets, err := adminClient.ListConsumerGroupOffsets(ctx, groupId, map[string][]int32{topicName: {1}})
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[int32]int64{topicName: {1: 456}}, offsets)
+	for request := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, custom.ConsumerGroupsPath+"/"+groupId+custom.OffsetsPath, request.URL.Path)
+	}
+}
+
+// Test The Custom AdminClient DeleteConsumerGroup() Functionality
+func TestCustomAdminClientDeleteConsumerGroup(t *testing.T) {
+
+	// Test Data
+	groupId := "TestGroupId"
+
+	// Create & Start The Test Sidecar HTTP Server (Not Found Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusNotFound)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Perform The Test
+	ctx, adminClient := createTestCustomAdminClient(t)
+	err := adminClient.DeleteConsumerGroup(ctx, groupId)
+
+	// Verify The Results
+	assert.NotNil(t, err)
+	topicError, ok := err.(*sarama.TopicError)
+	assert.True(t, ok)
+	assert.Equal(t, sarama.ErrGroupIDNotFound, topicError.Err)
+	for request := range mockSidecarServer.requests {
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, custom.ConsumerGroupsPath+"/"+groupId, request.URL.Path)
+	}
+}
+
 // Test The Custom AdminClient Close() Functionality
 func TestCustomAdminClientClose(t *testing.T) {
 
@@ -214,16 +402,29 @@ func TestCustomAdminClientGetKafkaSecretName(t *testing.T) {
 	assert.Equal(t, secretName, actualSecretName)
 }
 
+// Utility Function For Creating A Custom AdminClient With Test Logger & Valid Kafka Secret
+func createTestCustomAdminClient(t *testing.T) (context.Context, AdminClientInterface) {
+	namespace := "TestNamespace"
+	kafkaSecret := createKafkaSecret("Name", namespace, "Brokers", "Username", "Password")
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret))
+	adminClient, err := NewCustomAdminClient(ctx, namespace)
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	return ctx, adminClient
+}
+
 //
 // Test HTTP Server - Pretending To Be The Custom Sidecar
 //
 
 // MockSidecarServer Struct
 type MockSidecarServer struct {
-	t          *testing.T
-	statusCode int
-	server     *httptest.Server
-	requests   map[*http.Request][]byte // Map Of Request Pointers To BodyBytes For Tracking Requests For Subsequent Validation
+	t            *testing.T
+	statusCode   int
+	responseBody []byte // Optional Response Body To Return With The StatusCode
+	server       *httptest.Server
+	requests     map[*http.Request][]byte // Map Of Request Pointers To BodyBytes For Tracking Requests For Subsequent Validation
 }
 
 // MockSidecarServer Constructor
@@ -273,8 +474,12 @@ func (s *MockSidecarServer) ServeHTTP(responseWriter http.ResponseWriter, reques
 	// Track The Received HTTP Request & Body For Future Validation
 	s.requests[request] = bodyBytes
 
-	// Return The Desired StatusCode
+	// Return The Desired StatusCode & Optional Body
 	responseWriter.WriteHeader(s.statusCode)
+	if s.responseBody != nil {
+		_, err = responseWriter.Write(s.responseBody)
+		assert.Nil(s.t, err)
+	}
 }
 
 // Utility Function For Verifying The Inbound HTTP Request (What Is Sent To The Sidecar)
@@ -282,6 +487,7 @@ func verifySidecarRequest(t *testing.T, request *http.Request, body []byte, topi
 
 	// Verify Common Request Data
 	assert.Equal(t, custom.SidecarHost+":"+custom.SidecarPort, request.Host)
+	assert.Equal(t, custom.ProtocolVersion, request.Header.Get(custom.ProtocolVersionHeader))
 
 	// Verify Method Specific Request Data
 	switch request.Method {
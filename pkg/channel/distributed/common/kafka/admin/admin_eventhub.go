@@ -52,8 +52,8 @@ func NewEventHubAdminClient(ctx context.Context, namespace string) (AdminClientI
 	// Create A New Cache Via the Wrapper
 	cache := NewCacheWrapper(ctx, namespace)
 
-	// Initialize The EventHub Namespace Cache
-	err := cache.Update(context.TODO())
+	// Initialize The EventHub Namespace Cache (Honoring Any Kafka Secret Scoping Of The Context)
+	err := cache.Update(ctx)
 	if err != nil {
 		logger.Error("Failed To Initialize EventHub Cache", zap.Error(err))
 		return nil, err
@@ -170,6 +170,45 @@ func (c *EventHubAdminClient) DeleteTopic(ctx context.Context, topicName string)
 	return adminutil.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
 }
 
+//
+// Unsupported Operations
+//
+// The Azure EventHub go-client only exposes basic Put / Delete / List management of EventHubs and does
+// not provide any equivalent of the Kafka Describe, Partition, Config or ConsumerGroup APIs.  These
+// operations therefore return ErrUnsupportedOperation (or an ErrUnsupportedVersion TopicError for
+// topic scoped operations) so that callers can detect and skip the functionality.
+//
+
+// Kafka AdminClient DescribeTopic Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) DescribeTopic(_ context.Context, _ string) (*sarama.TopicDetail, *sarama.TopicError) {
+	return nil, adminutil.NewUnsupportedOperationTopicError("describe")
+}
+
+// Kafka AdminClient ListTopics Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Kafka AdminClient CreatePartitions Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) CreatePartitions(_ context.Context, _ string, _ int32) *sarama.TopicError {
+	return adminutil.NewUnsupportedOperationTopicError("partitions")
+}
+
+// Kafka AdminClient AlterTopicConfig Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) AlterTopicConfig(_ context.Context, _ string, _ map[string]*string) *sarama.TopicError {
+	return adminutil.NewUnsupportedOperationTopicError("config")
+}
+
+// Kafka AdminClient ListConsumerGroupOffsets Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) ListConsumerGroupOffsets(_ context.Context, _ string, _ map[string][]int32) (map[string]map[int32]int64, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Kafka AdminClient DeleteConsumerGroup Implementation - Not Supported By Azure EventHubs
+func (c *EventHubAdminClient) DeleteConsumerGroup(_ context.Context, _ string) error {
+	return ErrUnsupportedOperation
+}
+
 // Get The K8S Secret With Kafka Credentials For The Specified Topic (EventHub)
 func (c *EventHubAdminClient) GetKafkaSecretName(topicName string) string {
 
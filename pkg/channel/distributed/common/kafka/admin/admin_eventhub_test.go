@@ -543,6 +543,31 @@ func TestEventHubAdminClientClose(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// Test The EventHub AdminClient Unsupported Operations
+func TestEventHubAdminClientUnsupportedOperations(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+
+	// Create A New EventHub AdminClient To Test
+	adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Tests & Verify The Results
+	topicDetail, topicError := adminClient.DescribeTopic(ctx, topicName)
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, topicError.Err)
+	topicDetails, err := adminClient.ListTopics(ctx)
+	assert.Nil(t, topicDetails)
+	assert.Equal(t, ErrUnsupportedOperation, err)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, adminClient.CreatePartitions(ctx, topicName, 2).Err)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, adminClient.AlterTopicConfig(ctx, topicName, nil).Err)
+	offsets, err := adminClient.ListConsumerGroupOffsets(ctx, "TestGroupId", nil)
+	assert.Nil(t, offsets)
+	assert.Equal(t, ErrUnsupportedOperation, err)
+	assert.Equal(t, ErrUnsupportedOperation, adminClient.DeleteConsumerGroup(ctx, "TestGroupId"))
+}
+
 //
 // Mock EventHub Cache
 //
@@ -121,6 +121,135 @@ func (k KafkaAdminClient) DeleteTopic(_ context.Context, topicName string) *sara
 	}
 }
 
+// Sarama Pass-Through Function For Describing A Single Topic (Partitions, Replicas & Non-Default Configuration)
+func (k KafkaAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, adminutil.NewUnknownTopicError("unable to describe topic due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// Get The Topic's Metadata (Partitions & Replicas)
+	topicMetadatas, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return nil, adminutil.PromoteErrorToTopicError(err)
+	}
+	if len(topicMetadatas) != 1 || topicMetadatas[0] == nil {
+		return nil, adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("no metadata returned for topic '%s'", topicName))
+	}
+	topicMetadata := topicMetadatas[0]
+	if topicMetadata.Err != sarama.ErrNoError {
+		return nil, adminutil.NewTopicError(topicMetadata.Err, fmt.Sprintf("failed to describe topic '%s'", topicName))
+	}
+
+	// Build The TopicDetail From The Metadata (Mirrors The Sarama ListTopics() Implementation)
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions: int32(len(topicMetadata.Partitions)),
+		ConfigEntries: make(map[string]*string),
+	}
+	if len(topicMetadata.Partitions) > 0 {
+		topicDetail.ReplicaAssignment = make(map[int32][]int32)
+		for _, partition := range topicMetadata.Partitions {
+			topicDetail.ReplicaAssignment[partition.ID] = partition.Replicas
+		}
+		topicDetail.ReplicationFactor = int16(len(topicMetadata.Partitions[0].Replicas))
+	}
+
+	// Get The Topic's Configuration & Include Only Non-Default / Non-Sensitive Entries
+	configEntries, err := k.clusterAdmin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName})
+	if err != nil {
+		return nil, adminutil.PromoteErrorToTopicError(err)
+	}
+	for _, configEntry := range configEntries {
+		if configEntry.Default || configEntry.Sensitive {
+			continue
+		}
+		value := configEntry.Value
+		topicDetail.ConfigEntries[configEntry.Name] = &value
+	}
+
+	// Return The TopicDetail - Success (Nil TopicError As With The Other Pass-Through Functions)
+	return topicDetail, nil
+}
+
+// Sarama Pass-Through Function For Listing All Topics
+func (k KafkaAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To List Topics Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, fmt.Errorf("unable to list topics due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		return k.clusterAdmin.ListTopics()
+	}
+}
+
+// Sarama Pass-Through Function For Increasing A Topic's Partition Count (Kafka Does Not Support Decreasing)
+func (k KafkaAdminClient) CreatePartitions(_ context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Create Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return adminutil.NewUnknownTopicError("unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		err := k.clusterAdmin.CreatePartitions(topicName, numPartitions, nil, false)
+		return adminutil.PromoteErrorToTopicError(err)
+	}
+}
+
+//
+// Sarama Pass-Through Function For Altering A Topic's Configuration
+//
+// Note - This uses the Kafka AlterConfigs API which replaces the entire set of
+//        topic level overrides, so callers should provide all desired entries.
+//
+func (k KafkaAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Alter Topic Config Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return adminutil.NewUnknownTopicError("unable to alter topic config due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		err := k.clusterAdmin.AlterConfig(sarama.TopicResource, topicName, configEntries, false)
+		return adminutil.PromoteErrorToTopicError(err)
+	}
+}
+
+// Sarama Pass-Through Function For Listing A ConsumerGroup's Committed Offsets (Nil TopicPartitions == All)
+func (k KafkaAdminClient) ListConsumerGroupOffsets(_ context.Context, groupId string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To List ConsumerGroup Offsets Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, fmt.Errorf("unable to list consumer group offsets due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// Fetch The ConsumerGroup's Offsets
+	offsetFetchResponse, err := k.clusterAdmin.ListConsumerGroupOffsets(groupId, topicPartitions)
+	if err != nil {
+		return nil, err
+	}
+	if offsetFetchResponse.Err != sarama.ErrNoError {
+		return nil, offsetFetchResponse.Err
+	}
+
+	// Flatten The Response Blocks Into A Simple Topic -> Partition -> Offset Map
+	offsets := make(map[string]map[int32]int64)
+	for topic, partitionBlocks := range offsetFetchResponse.Blocks {
+		offsets[topic] = make(map[int32]int64)
+		for partition, block := range partitionBlocks {
+			if block.Err != sarama.ErrNoError {
+				return nil, block.Err
+			}
+			offsets[topic][partition] = block.Offset
+		}
+	}
+
+	// Return The Offsets - Success
+	return offsets, nil
+}
+
+// Sarama Pass-Through Function For Deleting ConsumerGroups
+func (k KafkaAdminClient) DeleteConsumerGroup(_ context.Context, groupId string) error {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Delete ConsumerGroup Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return fmt.Errorf("unable to delete consumer group due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		return k.clusterAdmin.DeleteConsumerGroup(groupId)
+	}
+}
+
 // Sarama Pass-Through Function For Closing ClusterAdmin
 func (k KafkaAdminClient) Close() error {
 	if k.clusterAdmin == nil {
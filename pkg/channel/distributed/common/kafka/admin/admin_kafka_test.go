@@ -6,13 +6,14 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
 	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 	injectionclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
@@ -57,7 +58,7 @@ Metadata:
 	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret, kafkaConfig))
 
 	// Create A Mock Sarama ClusterAdmin To Test Against
-	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
 
 	// Mock The Sarama ClusterAdmin Creation For Testing
 	newClusterAdminWrapperPlaceholder := NewClusterAdminWrapper
@@ -83,6 +84,45 @@ Metadata:
 	assert.NotNil(t, adminClient)
 }
 
+// Test The Kafka AdminClient Creation With Multiple Kafka Secrets When Scoped To One Of Them
+func TestNewKafkaAdminClientScopedToKafkaSecret(t *testing.T) {
+
+	// Test Data
+	clientId := "TestClientId"
+	namespace := "TestNamespace"
+	kafkaSecretName1 := "TestKafkaSecretName1"
+	kafkaSecretName2 := "TestKafkaSecretName2"
+	kafkaSecretBrokers1 := "TestKafkaSecretBrokers1"
+	kafkaSecretBrokers2 := "TestKafkaSecretBrokers2"
+
+	// Create Test Kafka Secrets (One Per Kafka Cluster)
+	kafkaSecret1 := createKafkaSecret(kafkaSecretName1, namespace, kafkaSecretBrokers1, "", "")
+	kafkaSecret2 := createKafkaSecret(kafkaSecretName2, namespace, kafkaSecretBrokers2, "", "")
+
+	// Create A Context With Test Logger & K8S Client, Scoped To The Second Kafka Secret
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret1, kafkaSecret2))
+	ctx = adminutil.WithKafkaSecretName(ctx, kafkaSecretName2)
+
+	// Mock The Sarama ClusterAdmin Creation For Testing (Verifying The Scoped Kafka Secret's Brokers Are Used)
+	newClusterAdminWrapperPlaceholder := NewClusterAdminWrapper
+	NewClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+		assert.Equal(t, []string{kafkaSecretBrokers2}, brokers)
+		return &kafkatesting.MockClusterAdmin{}, nil
+	}
+	defer func() {
+		NewClusterAdminWrapper = newClusterAdminWrapperPlaceholder
+	}()
+
+	// Perform The Test
+	adminClient, err := NewKafkaAdminClient(ctx, commontesting.GetDefaultSaramaConfig(t), clientId, namespace)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	assert.Equal(t, kafkaSecretName2, adminClient.GetKafkaSecretName("TestTopicName"))
+}
+
 // Test The NewKafkaAdminClient() Constructor - No Kafka Secrets Path
 func TestNewKafkaAdminClientNoSecrets(t *testing.T) {
 
@@ -127,7 +167,7 @@ func TestKafkaAdminClientCreateTopic(t *testing.T) {
 	}
 
 	// Create A Mock Sarama ClusterAdmin To Test Against
-	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
 	mockClusterAdmin.On("CreateTopic", topicName, topicDetail).Return(testTopicError)
 
 	// Test Logger
@@ -199,7 +239,7 @@ func TestKafkaAdminClientDeleteTopic(t *testing.T) {
 	}
 
 	// Create A Mock Sarama ClusterAdmin To Test Against
-	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
 	mockClusterAdmin.On("DeleteTopic", topicName).Return(testTopicError)
 
 	// Test Logger
@@ -250,7 +290,7 @@ func TestKafkaAdminClientDeleteTopicInvalidAdminClient(t *testing.T) {
 func TestKafkaAdminClientClose(t *testing.T) {
 
 	// Create A Mock Sarama ClusterAdmin To Test Against
-	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
 	mockClusterAdmin.On("Close").Return(nil)
 
 	// Test Logger
@@ -307,128 +347,267 @@ func TestKafkaAdminClientGetKafkaSecretName(t *testing.T) {
 	assert.Equal(t, secretName, actualSecretName)
 }
 
-//
-// Utilities
-//
+// Test The Kafka AdminClient DescribeTopic() Functionality
+func TestKafkaAdminClientDescribeTopic(t *testing.T) {
 
-// Create K8S Kafka Secret With Specified Config
-func createKafkaSecret(name string, namespace string, brokers string, username string, password string) *corev1.Secret {
-	return &corev1.Secret{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Secret",
-			APIVersion: corev1.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				constants.KafkaSecretLabel: "true",
-			},
-		},
-		Data: map[string][]byte{
-			constants.KafkaSecretKeyBrokers:  []byte(brokers),
-			constants.KafkaSecretKeyUsername: []byte(username),
-			constants.KafkaSecretKeyPassword: []byte(password),
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	topicMetadata := &sarama.TopicMetadata{
+		Err:  sarama.ErrNoError,
+		Name: topicName,
+		Partitions: []*sarama.PartitionMetadata{
+			{ID: 0, Replicas: []int32{1, 2}},
+			{ID: 1, Replicas: []int32{2, 3}},
 		},
 	}
-}
+	configEntries := []sarama.ConfigEntry{
+		{Name: constants.TopicDetailConfigRetentionMs, Value: retentionMillis},
+		{Name: "cleanup.policy", Value: "delete", Default: true},
+	}
 
-// Create K8S Kafka ConfigMap With Specified Config
-func createKafkaConfig(name string, namespace string, saramaConfig string) *corev1.ConfigMap {
-	return commontesting.GetTestSaramaConfigMapNamespaced(name, namespace, saramaConfig, "")
-}
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{topicMetadata}, nil)
+	mockClusterAdmin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}).Return(configEntries, nil)
 
-//
-// Mock Sarama Kafka ClusterAdmin
-//
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
 
-// Verify The Mock Sarama ClusterAdmin Implements The Interface
-var _ sarama.ClusterAdmin = &MockClusterAdmin{}
+	// Perform The Test
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, topicName)
 
-// The Mock Sarama ClusterAdmin
-type MockClusterAdmin struct {
-	mock.Mock
+	// Verify The Results
+	assert.Nil(t, resultTopicError)
+	assert.NotNil(t, topicDetail)
+	assert.Equal(t, int32(2), topicDetail.NumPartitions)
+	assert.Equal(t, int16(2), topicDetail.ReplicationFactor)
+	assert.Equal(t, map[int32][]int32{0: {1, 2}, 1: {2, 3}}, topicDetail.ReplicaAssignment)
+	assert.Len(t, topicDetail.ConfigEntries, 1)
+	assert.Equal(t, retentionMillis, *topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs])
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) DescribeLogDirs(brokers []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
-	panic("implement me")
-}
+// Test The Kafka AdminClient DescribeTopic() Unknown Topic Functionality
+func TestKafkaAdminClientDescribeTopicUnknown(t *testing.T) {
 
-func (m *MockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
-	args := m.Called(topic, detail)
-	return args.Get(0).(*sarama.TopicError)
-}
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	topicMetadata := &sarama.TopicMetadata{Err: sarama.ErrUnknownTopicOrPartition, Name: topicName}
 
-func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
-	panic("implement me")
-}
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{topicMetadata}, nil)
 
-func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
-	panic("implement me")
-}
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
 
-func (m *MockClusterAdmin) DeleteTopic(topic string) error {
-	args := m.Called(topic)
-	return args.Get(0).(*sarama.TopicError)
-}
+	// Perform The Test
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, topicName)
 
-func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	// Verify The Results
+	assert.Nil(t, topicDetail)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, resultTopicError.Err)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
-	panic("implement me")
-}
+// Test The Kafka AdminClient ListTopics() Functionality
+func TestKafkaAdminClientListTopics(t *testing.T) {
 
-func (m *MockClusterAdmin) ListPartitionReassignments(topics string, partitions []int32) (topicStatus map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, err error) {
-	panic("implement me")
-}
+	// Test Data
+	topicDetails := map[string]sarama.TopicDetail{"TestTopicName": {NumPartitions: 4, ReplicationFactor: 1}}
 
-func (m *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
-	panic("implement me")
-}
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("ListTopics").Return(topicDetails, nil)
 
-func (m *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
-	panic("implement me")
-}
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
 
-func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
-	panic("implement me")
-}
+	// Perform The Test
+	result, err := adminClient.ListTopics(context.TODO())
 
-func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
-	panic("implement me")
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, topicDetails, result)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) ListAcls(filter sarama.AclFilter) ([]sarama.ResourceAcls, error) {
-	panic("implement me")
-}
+// Test The Kafka AdminClient CreatePartitions() Functionality
+func TestKafkaAdminClientCreatePartitions(t *testing.T) {
 
-func (m *MockClusterAdmin) DeleteACL(filter sarama.AclFilter, validateOnly bool) ([]sarama.MatchingAcl, error) {
-	panic("implement me")
+	// Test Data
+	topicName := "TestTopicName"
+	numPartitions := int32(8)
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("CreatePartitions", topicName, numPartitions).Return(nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultTopicError := adminClient.CreatePartitions(context.TODO(), topicName, numPartitions)
+
+	// Verify The Results
+	assert.Nil(t, resultTopicError)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) ListConsumerGroups() (map[string]string, error) {
-	panic("implement me")
+// Test The Kafka AdminClient AlterTopicConfig() Functionality
+func TestKafkaAdminClientAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	configEntries := map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("AlterConfig", sarama.TopicResource, topicName, configEntries).Return(sarama.ErrPolicyViolation)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultTopicError := adminClient.AlterTopicConfig(context.TODO(), topicName, configEntries)
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+	assert.Equal(t, sarama.ErrPolicyViolation.Error(), *resultTopicError.ErrMsg)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
-	panic("implement me")
+// Test The Kafka AdminClient ListConsumerGroupOffsets() Functionality
+func TestKafkaAdminClientListConsumerGroupOffsets(t *testing.T) {
+
+	// Test Data
+	groupId := "TestGroupId"
+	topicName := "TestTopicName"
+	topicPartitions := map[string][]int32{topicName: {0, 1}}
+	offsetFetchResponse := &sarama.OffsetFetchResponse{
+		Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{
+			topicName: {
+				0: {Offset: 123},
+				1: {Offset: 456},
+			},
+		},
+	}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("ListConsumerGroupOffsets", groupId, topicPartitions).Return(offsetFetchResponse, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	offsets, err := adminClient.ListConsumerGroupOffsets(context.TODO(), groupId, topicPartitions)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[int32]int64{topicName: {0: 123, 1: 456}}, offsets)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
-	panic("implement me")
+// Test The Kafka AdminClient DeleteConsumerGroup() Functionality
+func TestKafkaAdminClientDeleteConsumerGroup(t *testing.T) {
+
+	// Test Data
+	groupId := "TestGroupId"
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &kafkatesting.MockClusterAdmin{}
+	mockClusterAdmin.On("DeleteConsumerGroup", groupId).Return(nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	err := adminClient.DeleteConsumerGroup(context.TODO(), groupId)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	mockClusterAdmin.AssertExpectations(t)
 }
 
-func (m *MockClusterAdmin) DeleteConsumerGroup(group string) error {
-	panic("implement me")
+// Test The Kafka AdminClient New Operations Without ClusterAdmin Functionality
+func TestKafkaAdminClientInvalidAdminClient(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+
+	// Create A New Kafka AdminClient To Test (No ClusterAdmin)
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Tests & Verify The Results
+	topicDetail, topicError := adminClient.DescribeTopic(ctx, "TestTopicName")
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnknown, topicError.Err)
+	topicDetails, err := adminClient.ListTopics(ctx)
+	assert.Nil(t, topicDetails)
+	assert.NotNil(t, err)
+	assert.Equal(t, sarama.ErrUnknown, adminClient.CreatePartitions(ctx, "TestTopicName", 2).Err)
+	assert.Equal(t, sarama.ErrUnknown, adminClient.AlterTopicConfig(ctx, "TestTopicName", nil).Err)
+	offsets, err := adminClient.ListConsumerGroupOffsets(ctx, "TestGroupId", nil)
+	assert.Nil(t, offsets)
+	assert.NotNil(t, err)
+	assert.NotNil(t, adminClient.DeleteConsumerGroup(ctx, "TestGroupId"))
 }
 
-func (m *MockClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error) {
-	panic("implement me")
+//
+// Utilities
+//
+
+// Create K8S Kafka Secret With Specified Config
+func createKafkaSecret(name string, namespace string, brokers string, username string, password string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				constants.KafkaSecretLabel: "true",
+			},
+		},
+		Data: map[string][]byte{
+			constants.KafkaSecretKeyBrokers:  []byte(brokers),
+			constants.KafkaSecretKeyUsername: []byte(username),
+			constants.KafkaSecretKeyPassword: []byte(password),
+		},
+	}
 }
 
-func (m *MockClusterAdmin) Close() error {
-	args := m.Called()
-	return args.Error(0)
+// Create K8S Kafka ConfigMap With Specified Config
+func createKafkaConfig(name string, namespace string, saramaConfig string) *corev1.ConfigMap {
+	return commontesting.GetTestSaramaConfigMapNamespaced(name, namespace, saramaConfig, "")
 }
@@ -0,0 +1,401 @@
+package admin
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/strimzi"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/logging"
+)
+
+//
+// Strimzi Kafka AdminClient Implementation (KafkaTopic Custom Resources)
+//
+// When Kafka is deployed and managed by the Strimzi operator, the Topic Operator
+// owns the Kafka Topics and will fight any direct ClusterAdmin changes.  This
+// implementation instead creates, updates and deletes Strimzi KafkaTopic custom
+// resources via the dynamic Kubernetes client, and waits for the Topic Operator
+// to report them Ready.
+//
+// ConsumerGroups are not modelled by Strimzi custom resources so those operations
+// are not supported.
+//
+
+// Ensure The StrimziAdminClient Struct Implements The AdminClientInterface
+var _ AdminClientInterface = &StrimziAdminClient{}
+
+// Strimzi AdminClient Definition
+type StrimziAdminClient struct {
+	logger       *zap.Logger
+	namespace    string
+	kafkaSecret  string
+	clusterName  string
+	topicClient  dynamic.ResourceInterface
+	readyTimeout time.Duration
+}
+
+// Regular Expression Matching Characters Which Are Not Valid In A K8S Resource Name
+var invalidResourceNameCharsRegexp = regexp.MustCompile(`[^a-z0-9.-]`)
+
+// Create A New Strimzi AdminClient Based On The Kafka Secret In The Specified K8S Namespace
+func NewStrimziAdminClient(ctx context.Context, namespace string) (AdminClientInterface, error) {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Load The EventingKafka Configuration For The Strimzi Settings
+	_, configuration, err := kafkasarama.LoadSettings(ctx)
+	if err != nil {
+		logger.Error("Failed To Load Eventing-Kafka Settings", zap.Error(err))
+		return nil, err
+	}
+	strimziConfig := configuration.Kafka.Strimzi
+
+	// Validate The Strimzi Configuration
+	if len(strimziConfig.ClusterName) <= 0 || len(strimziConfig.Namespace) <= 0 {
+		logger.Error("Invalid Strimzi Configuration - ClusterName & Namespace Are Required", zap.Any("Strimzi", strimziConfig))
+		return nil, errors.New("invalid strimzi configuration - clusterName and namespace are required")
+	}
+
+	// Get A List Of The Kafka Secrets
+	kafkaSecrets, err := adminutil.GetKafkaSecrets(ctx, kubeclient.Get(ctx), namespace)
+	if err != nil {
+		logger.Error("Failed To Get Kafka Authentication Secrets", zap.Error(err))
+		return nil, err
+	}
+
+	// Currently Only Support One Kafka Secret (Used By The Receiver & Dispatcher Deployments)
+	var kafkaSecret corev1.Secret
+	if len(kafkaSecrets.Items) != 1 {
+		logger.Warn(fmt.Sprintf("Expected 1 Kafka Secret But Found %d - Kafka AdminClient Will Not Be Functional!", len(kafkaSecrets.Items)))
+		return nil, nil
+	} else {
+		logger.Info("Found 1 Kafka Secret", zap.String("Secret", kafkaSecrets.Items[0].Name))
+		kafkaSecret = kafkaSecrets.Items[0]
+	}
+
+	// Validate Secret Data
+	if !adminutil.ValidateKafkaSecret(logger, &kafkaSecret) {
+		err = errors.New("invalid Kafka Secret found")
+		return nil, err
+	}
+
+	// Determine The Ready Timeout
+	readyTimeout := strimzi.DefaultReadyTimeout
+	if strimziConfig.ReadyTimeoutMillis > 0 {
+		readyTimeout = time.Duration(strimziConfig.ReadyTimeoutMillis) * time.Millisecond
+	}
+
+	// Create The Strimzi AdminClient With The Dynamic KafkaTopic Resource Client
+	strimziAdminClient := &StrimziAdminClient{
+		logger:       logger.With(zap.String("StrimziCluster", strimziConfig.ClusterName)),
+		namespace:    namespace,
+		kafkaSecret:  kafkaSecret.Name,
+		clusterName:  strimziConfig.ClusterName,
+		topicClient:  dynamicclient.Get(ctx).Resource(strimzi.KafkaTopicGVR).Namespace(strimziConfig.Namespace),
+		readyTimeout: readyTimeout,
+	}
+
+	// Return The Strimzi AdminClient
+	logger.Debug("Successfully Created New Strimzi AdminClient")
+	return strimziAdminClient, nil
+}
+
+// Create A KafkaTopic Custom Resource For The Specified Topic & Wait For It To Become Ready
+func (s *StrimziAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+
+	// Validate Topic
+	if len(topicName) <= 0 || topicDetail == nil {
+		s.logger.Warn("Received Empty/Nil Topic Configuration", zap.String("TopicName", topicName), zap.Any("TopicDetail", topicDetail))
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name and / or detail")
+	}
+
+	// Create The KafkaTopic Custom Resource
+	kafkaTopic := s.newKafkaTopic(topicName, topicDetail)
+	_, err := s.topicClient.Create(ctx, kafkaTopic, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("strimzi KafkaTopic for topic '%s' already exists", topicName))
+		}
+		s.logger.Error("Failed To Create KafkaTopic", zap.String("TopicName", topicName), zap.Error(err))
+		return adminutil.NewUnknownTopicError(fmt.Sprintf("failed to create strimzi KafkaTopic for topic '%s': %v", topicName, err))
+	}
+
+	// Wait For The Topic Operator To Reconcile The KafkaTopic
+	return s.waitForReady(ctx, topicName)
+}
+
+// Delete The KafkaTopic Custom Resource For The Specified Topic (The Topic Operator Deletes The Kafka Topic)
+func (s *StrimziAdminClient) DeleteTopic(ctx context.Context, topicName string) *sarama.TopicError {
+	err := s.topicClient.Delete(ctx, kafkaTopicResourceName(topicName), metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("strimzi KafkaTopic for topic '%s' not found", topicName))
+		}
+		s.logger.Error("Failed To Delete KafkaTopic", zap.String("TopicName", topicName), zap.Error(err))
+		return adminutil.NewUnknownTopicError(fmt.Sprintf("failed to delete strimzi KafkaTopic for topic '%s': %v", topicName, err))
+	}
+	return adminutil.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
+}
+
+// Describe The Specified Topic From Its KafkaTopic Custom Resource Spec
+func (s *StrimziAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+	kafkaTopic, topicError := s.getKafkaTopic(ctx, topicName)
+	if topicError != nil {
+		return nil, topicError
+	}
+	return kafkaTopicToTopicDetail(kafkaTopic), nil
+}
+
+// List All Topics Managed By KafkaTopic Custom Resources Of The Configured Strimzi Cluster
+func (s *StrimziAdminClient) ListTopics(ctx context.Context) (map[string]sarama.TopicDetail, error) {
+	kafkaTopicList, err := s.topicClient.List(ctx, metav1.ListOptions{LabelSelector: strimzi.ClusterLabel + "=" + s.clusterName})
+	if err != nil {
+		s.logger.Error("Failed To List KafkaTopics", zap.Error(err))
+		return nil, err
+	}
+	topicDetails := make(map[string]sarama.TopicDetail, len(kafkaTopicList.Items))
+	for i := range kafkaTopicList.Items {
+		kafkaTopic := &kafkaTopicList.Items[i]
+		topicDetails[kafkaTopicName(kafkaTopic)] = *kafkaTopicToTopicDetail(kafkaTopic)
+	}
+	return topicDetails, nil
+}
+
+// Update The KafkaTopic Custom Resource's Partition Count & Wait For It To Become Ready
+func (s *StrimziAdminClient) CreatePartitions(ctx context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+	return s.updateKafkaTopic(ctx, topicName, func(kafkaTopic *unstructured.Unstructured) error {
+		return unstructured.SetNestedField(kafkaTopic.Object, int64(numPartitions), "spec", strimzi.SpecPartitions)
+	})
+}
+
+// Update The KafkaTopic Custom Resource's Configuration & Wait For It To Become Ready
+func (s *StrimziAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	return s.updateKafkaTopic(ctx, topicName, func(kafkaTopic *unstructured.Unstructured) error {
+		return unstructured.SetNestedField(kafkaTopic.Object, configEntriesToSpecConfig(configEntries), "spec", strimzi.SpecConfig)
+	})
+}
+
+// Strimzi ListConsumerGroupOffsets Implementation - Not Supported (No Strimzi Custom Resource)
+func (s *StrimziAdminClient) ListConsumerGroupOffsets(_ context.Context, _ string, _ map[string][]int32) (map[string]map[int32]int64, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Strimzi DeleteConsumerGroup Implementation - Not Supported (No Strimzi Custom Resource)
+func (s *StrimziAdminClient) DeleteConsumerGroup(_ context.Context, _ string) error {
+	return ErrUnsupportedOperation
+}
+
+// Strimzi Close Implementation - NoOp
+func (s *StrimziAdminClient) Close() error {
+	return nil // Nothing to "close" in the dynamic client so this is just a compatibility no-op.
+}
+
+// Get The K8S Secret With Kafka Credentials For The Specified Topic Name
+func (s *StrimziAdminClient) GetKafkaSecretName(_ string) string {
+	return s.kafkaSecret
+}
+
+// Get The KafkaTopic Custom Resource For The Specified Topic, Mapping Failures To TopicErrors
+func (s *StrimziAdminClient) getKafkaTopic(ctx context.Context, topicName string) (*unstructured.Unstructured, *sarama.TopicError) {
+	kafkaTopic, err := s.topicClient.Get(ctx, kafkaTopicResourceName(topicName), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("strimzi KafkaTopic for topic '%s' not found", topicName))
+		}
+		s.logger.Error("Failed To Get KafkaTopic", zap.String("TopicName", topicName), zap.Error(err))
+		return nil, adminutil.NewUnknownTopicError(fmt.Sprintf("failed to get strimzi KafkaTopic for topic '%s': %v", topicName, err))
+	}
+	return kafkaTopic, nil
+}
+
+// Apply The Specified Mutation To The KafkaTopic Custom Resource, Update It & Wait For It To Become Ready
+func (s *StrimziAdminClient) updateKafkaTopic(ctx context.Context, topicName string, mutate func(*unstructured.Unstructured) error) *sarama.TopicError {
+
+	// Get The Current KafkaTopic
+	kafkaTopic, topicError := s.getKafkaTopic(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+
+	// Apply The Mutation
+	err := mutate(kafkaTopic)
+	if err != nil {
+		return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("failed to update strimzi KafkaTopic spec for topic '%s': %v", topicName, err))
+	}
+
+	// Update The KafkaTopic
+	_, err = s.topicClient.Update(ctx, kafkaTopic, metav1.UpdateOptions{})
+	if err != nil {
+		s.logger.Error("Failed To Update KafkaTopic", zap.String("TopicName", topicName), zap.Error(err))
+		return adminutil.NewUnknownTopicError(fmt.Sprintf("failed to update strimzi KafkaTopic for topic '%s': %v", topicName, err))
+	}
+
+	// Wait For The Topic Operator To Reconcile The KafkaTopic
+	return s.waitForReady(ctx, topicName)
+}
+
+//
+// Wait For The KafkaTopic's Ready Condition
+//
+// The Topic Operator reports its progress via the status.conditions of the KafkaTopic.  A Ready
+// condition of "True" for the current generation indicates success, while "False" indicates the
+// operator rejected the KafkaTopic (e.g. invalid config).  Anything else is polled until timeout.
+//
+func (s *StrimziAdminClient) waitForReady(ctx context.Context, topicName string) *sarama.TopicError {
+
+	var notReadyMessage string
+	err := wait.PollImmediate(strimzi.ReadyPollInterval, s.readyTimeout, func() (bool, error) {
+		kafkaTopic, topicError := s.getKafkaTopic(ctx, topicName)
+		if topicError != nil {
+			return false, topicError
+		}
+		ready, reconciled, message := kafkaTopicReadyCondition(kafkaTopic)
+		if reconciled && !ready {
+			notReadyMessage = message
+			return false, errors.New(message)
+		}
+		return ready, nil
+	})
+
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			s.logger.Warn("Timed Out Waiting For KafkaTopic To Become Ready", zap.String("TopicName", topicName), zap.Duration("Timeout", s.readyTimeout))
+			return adminutil.NewTopicError(sarama.ErrRequestTimedOut, fmt.Sprintf("timed out waiting for strimzi KafkaTopic for topic '%s' to become ready", topicName))
+		} else if len(notReadyMessage) > 0 {
+			s.logger.Error("KafkaTopic Is Not Ready", zap.String("TopicName", topicName), zap.String("Message", notReadyMessage))
+			return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("strimzi KafkaTopic for topic '%s' is not ready: %s", topicName, notReadyMessage))
+		} else {
+			return adminutil.PromoteErrorToTopicError(err)
+		}
+	}
+
+	return adminutil.NewTopicError(sarama.ErrNoError, "strimzi KafkaTopic is ready")
+}
+
+// Create A New KafkaTopic Custom Resource For The Specified Topic Name & TopicDetail
+func (s *StrimziAdminClient) newKafkaTopic(topicName string, topicDetail *sarama.TopicDetail) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": strimzi.KafkaTopicGroup + "/" + strimzi.KafkaTopicVersion,
+			"kind":       strimzi.KafkaTopicKind,
+			"metadata": map[string]interface{}{
+				"name": kafkaTopicResourceName(topicName),
+				"labels": map[string]interface{}{
+					strimzi.ClusterLabel: s.clusterName,
+				},
+			},
+			"spec": map[string]interface{}{
+				strimzi.SpecTopicName:  topicName,
+				strimzi.SpecPartitions: int64(topicDetail.NumPartitions),
+				strimzi.SpecReplicas:   int64(topicDetail.ReplicationFactor),
+				strimzi.SpecConfig:     configEntriesToSpecConfig(topicDetail.ConfigEntries),
+			},
+		},
+	}
+}
+
+//
+// Get The K8S Resource Name For The KafkaTopic Of The Specified Topic Name
+//
+// Kafka Topic names allow upper-case and underscore characters which are not valid in K8S resource
+// names.  Such names are sanitized and suffixed with a short hash of the original name to avoid
+// collisions.  The actual Topic name is always stored in the KafkaTopic's spec.topicName field.
+//
+func kafkaTopicResourceName(topicName string) string {
+	resourceName := invalidResourceNameCharsRegexp.ReplaceAllString(strings.ToLower(topicName), "-")
+	if resourceName != topicName {
+		topicNameHash := sha1.Sum([]byte(topicName))
+		resourceName = strings.Trim(resourceName, ".-") + "-" + hex.EncodeToString(topicNameHash[:4])
+	}
+	return resourceName
+}
+
+// Get The Kafka Topic Name Of The Specified KafkaTopic (The spec.topicName Defaults To The Resource Name)
+func kafkaTopicName(kafkaTopic *unstructured.Unstructured) string {
+	topicName, found, err := unstructured.NestedString(kafkaTopic.Object, "spec", strimzi.SpecTopicName)
+	if err != nil || !found || len(topicName) <= 0 {
+		return kafkaTopic.GetName()
+	}
+	return topicName
+}
+
+// Convert The Spec Of The Specified KafkaTopic Into A Sarama TopicDetail
+func kafkaTopicToTopicDetail(kafkaTopic *unstructured.Unstructured) *sarama.TopicDetail {
+	topicDetail := &sarama.TopicDetail{ConfigEntries: make(map[string]*string)}
+	if partitions, found, err := unstructured.NestedInt64(kafkaTopic.Object, "spec", strimzi.SpecPartitions); err == nil && found {
+		topicDetail.NumPartitions = int32(partitions)
+	}
+	if replicas, found, err := unstructured.NestedInt64(kafkaTopic.Object, "spec", strimzi.SpecReplicas); err == nil && found {
+		topicDetail.ReplicationFactor = int16(replicas)
+	}
+	if config, found, err := unstructured.NestedMap(kafkaTopic.Object, "spec", strimzi.SpecConfig); err == nil && found {
+		for key, value := range config {
+			stringValue := fmt.Sprintf("%v", value)
+			topicDetail.ConfigEntries[key] = &stringValue
+		}
+	}
+	return topicDetail
+}
+
+// Convert Sarama Config Entries Into The KafkaTopic spec.config Map (Numeric Values Are Preserved As Numbers)
+func configEntriesToSpecConfig(configEntries map[string]*string) map[string]interface{} {
+	specConfig := make(map[string]interface{}, len(configEntries))
+	for key, value := range configEntries {
+		if value == nil {
+			continue
+		}
+		if intValue, err := strconv.ParseInt(*value, 10, 64); err == nil {
+			specConfig[key] = intValue
+		} else {
+			specConfig[key] = *value
+		}
+	}
+	return specConfig
+}
+
+// Get The Ready Condition State Of The Specified KafkaTopic (ready, reconciled, message)
+func kafkaTopicReadyCondition(kafkaTopic *unstructured.Unstructured) (bool, bool, string) {
+
+	// Ignore Status From Previous Generations Of The KafkaTopic
+	observedGeneration, found, err := unstructured.NestedInt64(kafkaTopic.Object, "status", "observedGeneration")
+	if err == nil && found && observedGeneration < kafkaTopic.GetGeneration() {
+		return false, false, ""
+	}
+
+	// Find The Ready Condition
+	conditions, _, _ := unstructured.NestedSlice(kafkaTopic.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["type"] != strimzi.ConditionReady {
+			continue
+		}
+		message, _ := conditionMap["message"].(string)
+		switch conditionMap["status"] {
+		case string(corev1.ConditionTrue):
+			return true, true, message
+		case string(corev1.ConditionFalse):
+			return false, true, message
+		}
+	}
+	return false, false, ""
+}
@@ -0,0 +1,307 @@
+package admin
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/strimzi"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
+	injectionclient "knative.dev/pkg/client/injection/kube/client"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+// Test Data
+const (
+	strimziClusterName = "TestStrimziCluster"
+	strimziNamespace   = "TestStrimziNamespace"
+)
+
+// Test The NewStrimziAdminClient() Constructor - Success Path
+func TestNewStrimziAdminClientSuccess(t *testing.T) {
+
+	// Test Data
+	namespace := "TestNamespace"
+	kafkaSecretName := "TestKafkaSecretName"
+	eventingKafkaSettings := `
+kafka:
+  adminType: strimzi
+  strimzi:
+    clusterName: ` + strimziClusterName + `
+    namespace: ` + strimziNamespace + `
+    readyTimeoutMillis: 5000
+`
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Create A Context With Test Logger, K8S Client & Dynamic Client
+	kafkaSecret := createKafkaSecret(kafkaSecretName, namespace, "Brokers", "Username", "Password")
+	kafkaConfig := commontesting.GetTestSaramaConfigMapNamespaced(config.SettingsConfigMapName, system.Namespace(), commontesting.SaramaDefaultConfigYaml, eventingKafkaSettings)
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret, kafkaConfig))
+	ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+
+	// Perform The Test
+	adminClient, err := NewStrimziAdminClient(ctx, namespace)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	strimziAdminClient, ok := adminClient.(*StrimziAdminClient)
+	assert.True(t, ok)
+	assert.Equal(t, kafkaSecretName, strimziAdminClient.GetKafkaSecretName("TestTopicName"))
+	assert.Equal(t, strimziClusterName, strimziAdminClient.clusterName)
+	assert.Equal(t, 5*time.Second, strimziAdminClient.readyTimeout)
+}
+
+// Test The NewStrimziAdminClient() Constructor - Missing Strimzi Configuration
+func TestNewStrimziAdminClientInvalidConfig(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Create A Context With Test Logger, K8S Client & Dynamic Client
+	kafkaConfig := commontesting.GetTestSaramaConfigMapNamespaced(config.SettingsConfigMapName, system.Namespace(), commontesting.SaramaDefaultConfigYaml, "kafka:\n  adminType: strimzi\n")
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaConfig))
+	ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+
+	// Perform The Test
+	adminClient, err := NewStrimziAdminClient(ctx, "TestNamespace")
+
+	// Verify The Results
+	assert.NotNil(t, err)
+	assert.Nil(t, adminClient)
+}
+
+// Test The Strimzi AdminClient CreateTopic() Functionality
+func TestStrimziAdminClientCreateTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions:     4,
+		ReplicationFactor: 2,
+		ConfigEntries:     map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis},
+	}
+
+	// Create A Fake Dynamic Client Which Marks Created KafkaTopics As Ready (Simulating The Topic Operator)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("create", strimzi.KafkaTopicResource, readyReactor("True", ""))
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Test
+	resultTopicError := adminClient.CreateTopic(context.TODO(), topicName, topicDetail)
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	kafkaTopic, err := dynamicClient.Resource(strimzi.KafkaTopicGVR).Namespace(strimziNamespace).Get(context.TODO(), kafkaTopicResourceName(topicName), metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, strimziClusterName, kafkaTopic.GetLabels()[strimzi.ClusterLabel])
+	assert.Equal(t, topicName, kafkaTopicName(kafkaTopic))
+	assert.Equal(t, topicDetail, kafkaTopicToTopicDetail(kafkaTopic))
+}
+
+// Test The Strimzi AdminClient CreateTopic() Functionality - Already Exists
+func TestStrimziAdminClientCreateTopicAlreadyExists(t *testing.T) {
+
+	// Test Data
+	topicName := "test-topic"
+	existingKafkaTopic := newTestKafkaTopic(topicName, 4, 1)
+
+	// Create The Strimzi AdminClient With Existing KafkaTopic
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), existingKafkaTopic)
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Test
+	resultTopicError := adminClient.CreateTopic(context.TODO(), topicName, &sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 1})
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrTopicAlreadyExists, resultTopicError.Err)
+}
+
+// Test The Strimzi AdminClient CreateTopic() Functionality - Rejected By Topic Operator
+func TestStrimziAdminClientCreateTopicNotReady(t *testing.T) {
+
+	// Create A Fake Dynamic Client Which Marks Created KafkaTopics As Failed (Simulating The Topic Operator)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("create", strimzi.KafkaTopicResource, readyReactor("False", "Invalid config"))
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Test
+	resultTopicError := adminClient.CreateTopic(context.TODO(), "test-topic", &sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 1})
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidConfig, resultTopicError.Err)
+	assert.Contains(t, *resultTopicError.ErrMsg, "Invalid config")
+}
+
+// Test The Strimzi AdminClient CreateTopic() Functionality - Timeout Waiting For Ready
+func TestStrimziAdminClientCreateTopicTimeout(t *testing.T) {
+
+	// Create A Fake Dynamic Client Which Never Marks KafkaTopics Ready
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, 10*time.Millisecond)
+
+	// Perform The Test
+	resultTopicError := adminClient.CreateTopic(context.TODO(), "test-topic", &sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 1})
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrRequestTimedOut, resultTopicError.Err)
+}
+
+// Test The Strimzi AdminClient DeleteTopic() Functionality
+func TestStrimziAdminClientDeleteTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "test-topic"
+
+	// Create The Strimzi AdminClient With Existing KafkaTopic
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), newTestKafkaTopic(topicName, 4, 1))
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Test (Twice To Verify The NotFound Mapping)
+	resultTopicError := adminClient.DeleteTopic(context.TODO(), topicName)
+	repeatTopicError := adminClient.DeleteTopic(context.TODO(), topicName)
+
+	// Verify The Results
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, repeatTopicError.Err)
+}
+
+// Test The Strimzi AdminClient DescribeTopic() & ListTopics() Functionality
+func TestStrimziAdminClientDescribeAndListTopics(t *testing.T) {
+
+	// Test Data
+	topicName := "test-topic"
+
+	// Create The Strimzi AdminClient With Existing KafkaTopic
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), newTestKafkaTopic(topicName, 4, 1))
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Tests
+	topicDetail, topicError := adminClient.DescribeTopic(context.TODO(), topicName)
+	unknownTopicDetail, unknownTopicError := adminClient.DescribeTopic(context.TODO(), "unknown-topic")
+	topicDetails, err := adminClient.ListTopics(context.TODO())
+
+	// Verify The Results
+	assert.Nil(t, topicError)
+	assert.Equal(t, int32(4), topicDetail.NumPartitions)
+	assert.Equal(t, int16(1), topicDetail.ReplicationFactor)
+	assert.Nil(t, unknownTopicDetail)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, unknownTopicError.Err)
+	assert.Nil(t, err)
+	assert.Len(t, topicDetails, 1)
+	assert.Equal(t, int32(4), topicDetails[topicName].NumPartitions)
+}
+
+// Test The Strimzi AdminClient CreatePartitions() & AlterTopicConfig() Functionality
+func TestStrimziAdminClientUpdateTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "test-topic"
+	retentionMillis := "3600000"
+
+	// Create The Strimzi AdminClient With Existing (Ready) KafkaTopic
+	kafkaTopic := newTestKafkaTopic(topicName, 4, 1)
+	assert.Nil(t, unstructured.SetNestedSlice(kafkaTopic.Object, []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}}, "status", "conditions"))
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), kafkaTopic)
+	adminClient := createTestStrimziAdminClient(t, dynamicClient, time.Second)
+
+	// Perform The Tests
+	partitionsTopicError := adminClient.CreatePartitions(context.TODO(), topicName, 8)
+	configTopicError := adminClient.AlterTopicConfig(context.TODO(), topicName, map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis})
+
+	// Verify The Results
+	assert.Equal(t, sarama.ErrNoError, partitionsTopicError.Err)
+	assert.Equal(t, sarama.ErrNoError, configTopicError.Err)
+	topicDetail, topicError := adminClient.DescribeTopic(context.TODO(), topicName)
+	assert.Nil(t, topicError)
+	assert.Equal(t, int32(8), topicDetail.NumPartitions)
+	assert.Equal(t, retentionMillis, *topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs])
+}
+
+// Test The Strimzi AdminClient Unsupported Operations
+func TestStrimziAdminClientUnsupportedOperations(t *testing.T) {
+	adminClient := &StrimziAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+	offsets, err := adminClient.ListConsumerGroupOffsets(context.TODO(), "TestGroupId", nil)
+	assert.Nil(t, offsets)
+	assert.Equal(t, ErrUnsupportedOperation, err)
+	assert.Equal(t, ErrUnsupportedOperation, adminClient.DeleteConsumerGroup(context.TODO(), "TestGroupId"))
+	assert.Nil(t, adminClient.Close())
+}
+
+// Test The kafkaTopicResourceName() Functionality
+func TestKafkaTopicResourceName(t *testing.T) {
+	assert.Equal(t, "knative-messaging-kafka.default.my-channel", kafkaTopicResourceName("knative-messaging-kafka.default.my-channel"))
+	assert.Regexp(t, "^my-topic-[0-9a-f]{8}$", kafkaTopicResourceName("My_Topic"))
+	assert.NotEqual(t, kafkaTopicResourceName("My_Topic"), kafkaTopicResourceName("my_topic"))
+}
+
+//
+// Utilities
+//
+
+// Create A Strimzi AdminClient Using The Specified Fake Dynamic Client
+func createTestStrimziAdminClient(t *testing.T, dynamicClient *dynamicfake.FakeDynamicClient, readyTimeout time.Duration) *StrimziAdminClient {
+	return &StrimziAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		kafkaSecret:  "TestKafkaSecretName",
+		clusterName:  strimziClusterName,
+		topicClient:  dynamicClient.Resource(strimzi.KafkaTopicGVR).Namespace(strimziNamespace),
+		readyTimeout: readyTimeout,
+	}
+}
+
+// Create A Test KafkaTopic Custom Resource
+func newTestKafkaTopic(topicName string, partitions int64, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": strimzi.KafkaTopicGroup + "/" + strimzi.KafkaTopicVersion,
+			"kind":       strimzi.KafkaTopicKind,
+			"metadata": map[string]interface{}{
+				"name":      kafkaTopicResourceName(topicName),
+				"namespace": strimziNamespace,
+				"labels": map[string]interface{}{
+					strimzi.ClusterLabel: strimziClusterName,
+				},
+			},
+			"spec": map[string]interface{}{
+				strimzi.SpecTopicName:  topicName,
+				strimzi.SpecPartitions: partitions,
+				strimzi.SpecReplicas:   replicas,
+			},
+		},
+	}
+}
+
+// Create A Reactor Which Sets The Ready Condition On Created KafkaTopics Before Passing Them To The Tracker
+func readyReactor(status string, message string) clienttesting.ReactionFunc {
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		kafkaTopic := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		condition := map[string]interface{}{"type": strimzi.ConditionReady, "status": status, "message": message}
+		_ = unstructured.SetNestedSlice(kafkaTopic.Object, []interface{}{condition}, "status", "conditions")
+		return false, nil, nil
+	}
+}
@@ -98,6 +98,33 @@ func TestCreateAdminClientCustom(t *testing.T) {
 	assert.Equal(t, mockAdminClient, adminClient)
 }
 
+// Test The CreateAdminClient Strimzi Functionality
+func TestCreateAdminClientStrimzi(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	clientId := "TestClientId"
+	adminClientType := Strimzi
+	mockAdminClient = &MockAdminClient{}
+
+	// Replace the NewStrimziAdminClientWrapper To Provide Mock AdminClient & Defer Reset
+	NewStrimziAdminClientWrapperRef := NewStrimziAdminClientWrapper
+	NewStrimziAdminClientWrapper = func(ctxArg context.Context, namespaceArg string) (AdminClientInterface, error) {
+		assert.Equal(t, ctx, ctxArg)
+		assert.Equal(t, constants.KnativeEventingNamespace, namespaceArg)
+		return mockAdminClient, nil
+	}
+	defer func() { NewStrimziAdminClientWrapper = NewStrimziAdminClientWrapperRef }()
+
+	// Perform The Test
+	adminClient, err := CreateAdminClient(ctx, commontesting.GetDefaultSaramaConfig(t), clientId, adminClientType)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	assert.Equal(t, mockAdminClient, adminClient)
+}
+
 // Test The CreateAdminClient Custom Functionality
 func TestCreateAdminClientUnknown(t *testing.T) {
 
@@ -137,6 +164,30 @@ func (c MockAdminClient) DeleteTopic(context.Context, string) *sarama.TopicError
 	return nil
 }
 
+func (c MockAdminClient) DescribeTopic(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError) {
+	return nil, nil
+}
+
+func (c MockAdminClient) ListTopics(context.Context) (map[string]sarama.TopicDetail, error) {
+	return nil, nil
+}
+
+func (c MockAdminClient) CreatePartitions(context.Context, string, int32) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) ListConsumerGroupOffsets(context.Context, string, map[string][]int32) (map[string]map[int32]int64, error) {
+	return nil, nil
+}
+
+func (c MockAdminClient) DeleteConsumerGroup(context.Context, string) error {
+	return nil
+}
+
 func (c MockAdminClient) Close() error {
 	return nil
 }
@@ -9,9 +9,26 @@ import "time"
 //        custom sidecars, do not remove due to "unused" status in IDE!
 //
 const (
-	SidecarHost     = "localhost"      // The Host name used when making requests to the K8S sidecar.
-	SidecarPort     = "8888"           // The HTTP port on which the sidecar must be listening for POST / DELETE requests.
-	TopicsPath      = "/topics"        // The HTTP request path for Kafka Topic creation / deletion to be implemented by the sidecar.
-	TopicNameHeader = "Slug"           // The HTTP Header key used to identify the TopicName in the POST request.
-	SidecarTimeout  = 30 * time.Second // How long to wait for the sidecar's server to respond.
+	SidecarHost        = "localhost"       // The Host name used when making requests to the K8S sidecar.
+	SidecarPort        = "8888"            // The HTTP port on which the sidecar must be listening for POST / DELETE requests.
+	TopicsPath         = "/topics"         // The HTTP request path for Kafka Topic creation / deletion to be implemented by the sidecar.
+	PartitionsPath     = "/partitions"     // The HTTP request sub-path (of a specific Topic) for increasing the Topic's partition count.
+	ConfigPath         = "/config"         // The HTTP request sub-path (of a specific Topic) for altering the Topic's configuration.
+	ConsumerGroupsPath = "/consumergroups" // The HTTP request path for Kafka ConsumerGroup operations to be implemented by the sidecar.
+	OffsetsPath        = "/offsets"        // The HTTP request sub-path (of a specific ConsumerGroup) for listing committed offsets.
+	TopicNameHeader    = "Slug"            // The HTTP Header key used to identify the TopicName in the POST request.
+	SidecarTimeout     = 30 * time.Second  // How long to wait for the sidecar's server to respond.
+)
+
+//
+// Custom REST Sidecar Protocol Versioning
+//
+// The sidecar protocol is formally defined by the OpenAPI specification in
+// openapi.yaml (in this package).  Every request made by the eventing-kafka
+// AdminClient includes the ProtocolVersionHeader so that sidecars can reject
+// versions they do not understand (requests without the header are v1).
+//
+const (
+	ProtocolVersion       = "v1"                                // The current version of the sidecar REST protocol.
+	ProtocolVersionHeader = "X-Eventing-Kafka-Sidecar-Protocol" // The HTTP Header key used to identify the protocol version of a request.
 )
@@ -0,0 +1,24 @@
+package custom
+
+//
+//  This Golang Type can be used by third party implementers of the eventing-kafka
+//  "custom" AdminClient Type when handling the POST request for increasing the
+//  number of partitions of an existing Topic.
+//
+
+// Custom CreatePartitionsRequest Struct
+type CreatePartitionsRequest struct {
+	NumPartitions int32 `json:"numPartitions"` // The new total number of partitions (must be greater than current)
+}
+
+// Custom CreatePartitionsRequest Constructor
+func NewCreatePartitionsRequest(numPartitions int32) *CreatePartitionsRequest {
+	return &CreatePartitionsRequest{NumPartitions: numPartitions}
+}
+
+//
+//  The ConsumerGroup offsets returned by the GET request of the sidecar's
+//  "/consumergroups/<group>/offsets" endpoint, keyed by Topic name and then
+//  by Partition, with the committed offset as the value.
+//
+type ConsumerGroupOffsets map[string]map[int32]int64
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+)
+
+//
+// Custom Sidecar Backend Errors
+//
+// Backend implementations should return (or wrap) these errors so that the Server
+// can map them to the HTTP status codes defined in the sidecar protocol (see
+// ../openapi.yaml).  Any other error is returned as a 500 Internal Server Error.
+//
+var (
+	ErrTopicAlreadyExists    = errors.New("topic already exists")      // 409 Conflict
+	ErrTopicNotFound         = errors.New("topic not found")           // 404 Not Found
+	ErrConsumerGroupNotFound = errors.New("consumer group not found")  // 404 Not Found
+	ErrInvalidRequest        = errors.New("invalid request")           // 400 Bad Request
+	ErrNotImplemented        = errors.New("operation not implemented") // 501 Not Implemented
+)
+
+//
+// Custom Sidecar Backend Interface
+//
+// This is the extension point for third-party sidecar implementers.  The Server
+// handles all of the HTTP / JSON protocol details and delegates the actual Topic
+// and ConsumerGroup administration to the Backend.  Operations which are not
+// supported should return ErrNotImplemented.
+//
+type Backend interface {
+	CreateTopic(ctx context.Context, topicName string, topicDetail *custom.TopicDetail) error
+	DeleteTopic(ctx context.Context, topicName string) error
+	DescribeTopic(ctx context.Context, topicName string) (*custom.TopicDetail, error)
+	ListTopics(ctx context.Context) (map[string]*custom.TopicDetail, error)
+	CreatePartitions(ctx context.Context, topicName string, numPartitions int32) error
+	AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) error
+	ListConsumerGroupOffsets(ctx context.Context, groupId string) (custom.ConsumerGroupOffsets, error)
+	DeleteConsumerGroup(ctx context.Context, groupId string) error
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+)
+
+//
+// In-Memory Sidecar Backend
+//
+// A simple thread-safe Backend which tracks Topics and ConsumerGroup offsets in
+// memory.  It is intended for testing the custom AdminClient / sidecar protocol
+// and as an example for implementers - it does not talk to Kafka at all!
+//
+
+// Ensure The MemoryBackend Struct Implements The Backend Interface
+var _ Backend = &MemoryBackend{}
+
+// In-Memory Backend Definition
+type MemoryBackend struct {
+	mutex          sync.RWMutex
+	topics         map[string]*custom.TopicDetail
+	consumerGroups map[string]custom.ConsumerGroupOffsets
+}
+
+// Create A New Empty In-Memory Backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		topics:         make(map[string]*custom.TopicDetail),
+		consumerGroups: make(map[string]custom.ConsumerGroupOffsets),
+	}
+}
+
+// Create The Specified Topic If It Does Not Already Exist
+func (m *MemoryBackend) CreateTopic(_ context.Context, topicName string, topicDetail *custom.TopicDetail) error {
+	if len(topicName) <= 0 || topicDetail == nil || topicDetail.NumPartitions <= 0 {
+		return fmt.Errorf("%w: topic name and a positive partition count are required", ErrInvalidRequest)
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.topics[topicName]; ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicAlreadyExists, topicName)
+	}
+	m.topics[topicName] = copyTopicDetail(topicDetail)
+	return nil
+}
+
+// Delete The Specified Topic
+func (m *MemoryBackend) DeleteTopic(_ context.Context, topicName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.topics[topicName]; !ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	delete(m.topics, topicName)
+	return nil
+}
+
+// Describe The Specified Topic
+func (m *MemoryBackend) DescribeTopic(_ context.Context, topicName string) (*custom.TopicDetail, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	topicDetail, ok := m.topics[topicName]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	return copyTopicDetail(topicDetail), nil
+}
+
+// List All Topics
+func (m *MemoryBackend) ListTopics(_ context.Context) (map[string]*custom.TopicDetail, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	topicDetails := make(map[string]*custom.TopicDetail, len(m.topics))
+	for topicName, topicDetail := range m.topics {
+		topicDetails[topicName] = copyTopicDetail(topicDetail)
+	}
+	return topicDetails, nil
+}
+
+// Increase The Partition Count Of The Specified Topic
+func (m *MemoryBackend) CreatePartitions(_ context.Context, topicName string, numPartitions int32) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	topicDetail, ok := m.topics[topicName]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	if numPartitions <= topicDetail.NumPartitions {
+		return fmt.Errorf("%w: partition count can only be increased (current %d, requested %d)", ErrInvalidRequest, topicDetail.NumPartitions, numPartitions)
+	}
+	topicDetail.NumPartitions = numPartitions
+	return nil
+}
+
+// Replace The Configuration Overrides Of The Specified Topic
+func (m *MemoryBackend) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	topicDetail, ok := m.topics[topicName]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	topicDetail.ConfigEntries = copyConfigEntries(configEntries)
+	return nil
+}
+
+// List The Committed Offsets Of The Specified ConsumerGroup
+func (m *MemoryBackend) ListConsumerGroupOffsets(_ context.Context, groupId string) (custom.ConsumerGroupOffsets, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	groupOffsets, ok := m.consumerGroups[groupId]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrConsumerGroupNotFound, groupId)
+	}
+	return copyConsumerGroupOffsets(groupOffsets), nil
+}
+
+// Delete The Specified ConsumerGroup
+func (m *MemoryBackend) DeleteConsumerGroup(_ context.Context, groupId string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.consumerGroups[groupId]; !ok {
+		return fmt.Errorf("%w: '%s'", ErrConsumerGroupNotFound, groupId)
+	}
+	delete(m.consumerGroups, groupId)
+	return nil
+}
+
+// Set The Committed Offsets Of The Specified ConsumerGroup (There Are No Consumers To Commit Them!)
+func (m *MemoryBackend) SetConsumerGroupOffsets(groupId string, groupOffsets custom.ConsumerGroupOffsets) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.consumerGroups[groupId] = copyConsumerGroupOffsets(groupOffsets)
+}
+
+// Create A Deep Copy Of The Specified TopicDetail
+func copyTopicDetail(topicDetail *custom.TopicDetail) *custom.TopicDetail {
+	var replicaAssignment map[int32][]int32
+	if topicDetail.ReplicaAssignment != nil {
+		replicaAssignment = make(map[int32][]int32, len(topicDetail.ReplicaAssignment))
+		for partition, replicas := range topicDetail.ReplicaAssignment {
+			replicaAssignment[partition] = append([]int32(nil), replicas...)
+		}
+	}
+	return custom.NewTopicDetail(topicDetail.NumPartitions, topicDetail.ReplicationFactor, replicaAssignment, copyConfigEntries(topicDetail.ConfigEntries))
+}
+
+// Create A Deep Copy Of The Specified Config Entries
+func copyConfigEntries(configEntries map[string]*string) map[string]*string {
+	if configEntries == nil {
+		return nil
+	}
+	configEntriesCopy := make(map[string]*string, len(configEntries))
+	for key, value := range configEntries {
+		if value != nil {
+			valueCopy := *value
+			configEntriesCopy[key] = &valueCopy
+		} else {
+			configEntriesCopy[key] = nil
+		}
+	}
+	return configEntriesCopy
+}
+
+// Create A Deep Copy Of The Specified ConsumerGroupOffsets
+func copyConsumerGroupOffsets(groupOffsets custom.ConsumerGroupOffsets) custom.ConsumerGroupOffsets {
+	groupOffsetsCopy := make(custom.ConsumerGroupOffsets, len(groupOffsets))
+	for topic, partitionOffsets := range groupOffsets {
+		groupOffsetsCopy[topic] = make(map[int32]int64, len(partitionOffsets))
+		for partition, offset := range partitionOffsets {
+			groupOffsetsCopy[topic][partition] = offset
+		}
+	}
+	return groupOffsetsCopy
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+)
+
+// Test That The MemoryBackend Isolates Its State From Caller Modifications
+func TestMemoryBackendCopies(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	topicDetail := custom.NewTopicDetail(4, 1, map[int32][]int32{0: {1}}, map[string]*string{"retention.ms": &retentionMillis})
+	memoryBackend := NewMemoryBackend()
+
+	// Create The Topic & Modify The Original TopicDetail
+	assert.Nil(t, memoryBackend.CreateTopic(ctx, topicName, topicDetail))
+	topicDetail.NumPartitions = 10
+	retentionMillis = "0"
+
+	// Verify The Stored TopicDetail Is Unaffected
+	describedTopicDetail, err := memoryBackend.DescribeTopic(ctx, topicName)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), describedTopicDetail.NumPartitions)
+	assert.Equal(t, "86400000", *describedTopicDetail.ConfigEntries["retention.ms"])
+
+	// Modify The Described TopicDetail & Verify The Stored TopicDetail Is Unaffected
+	describedTopicDetail.ReplicaAssignment[0][0] = 2
+	describedTopicDetail, err = memoryBackend.DescribeTopic(ctx, topicName)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), describedTopicDetail.ReplicaAssignment[0][0])
+}
+
+// Test The MemoryBackend Validation
+func TestMemoryBackendInvalidRequests(t *testing.T) {
+	ctx := context.TODO()
+	memoryBackend := NewMemoryBackend()
+	assert.True(t, errors.Is(memoryBackend.CreateTopic(ctx, "", custom.NewTopicDetail(1, 1, nil, nil)), ErrInvalidRequest))
+	assert.True(t, errors.Is(memoryBackend.CreateTopic(ctx, "TestTopicName", nil), ErrInvalidRequest))
+	assert.True(t, errors.Is(memoryBackend.CreateTopic(ctx, "TestTopicName", custom.NewTopicDetail(0, 1, nil, nil)), ErrInvalidRequest))
+	assert.True(t, errors.Is(memoryBackend.CreatePartitions(ctx, "TestTopicName", 2), ErrTopicNotFound))
+	assert.True(t, errors.Is(memoryBackend.AlterTopicConfig(ctx, "TestTopicName", nil), ErrTopicNotFound))
+}
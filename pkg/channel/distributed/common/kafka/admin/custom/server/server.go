@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+)
+
+//
+// Custom Sidecar Reference Server
+//
+// This is a reusable implementation of the eventing-kafka custom sidecar REST
+// protocol (see ../openapi.yaml) which third-party implementers can use by
+// providing their own Backend.  The MemoryBackend is included for testing.
+//
+
+// Custom Sidecar Server Definition
+type Server struct {
+	logger   *zap.Logger
+	server   *http.Server
+	backend  Backend
+	HttpHost string // The HTTP Host The Sidecar Server Listens On
+	HttpPort string // The HTTP Port The Sidecar Server Listens On
+}
+
+// Create A New Sidecar Server On The Specified Host & Port (Empty Values Default To The Custom SidecarHost / SidecarPort)
+func NewSidecarServer(logger *zap.Logger, httpHost string, httpPort string, backend Backend) *Server {
+
+	// Default The Host & Port To The Values Expected By The Custom AdminClient
+	if len(httpHost) <= 0 {
+		httpHost = custom.SidecarHost
+	}
+	if len(httpPort) <= 0 {
+		httpPort = custom.SidecarPort
+	}
+
+	// Create The Sidecar Server
+	sidecarServer := &Server{
+		logger:   logger,
+		backend:  backend,
+		HttpHost: httpHost,
+		HttpPort: httpPort,
+	}
+
+	// Initialize The HTTP Server
+	sidecarServer.server = &http.Server{Addr: net.JoinHostPort(httpHost, httpPort), Handler: sidecarServer.Handler()}
+
+	// Return The Sidecar Server
+	return sidecarServer
+}
+
+// Get The HTTP Handler Implementing The Sidecar Protocol (Useful For Embedding Or Testing)
+func (s *Server) Handler() http.Handler {
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc(custom.TopicsPath, s.handleTopics)
+	serveMux.HandleFunc(custom.TopicsPath+"/", s.handleTopic)
+	serveMux.HandleFunc(custom.ConsumerGroupsPath+"/", s.handleConsumerGroup)
+	return s.versionHandler(serveMux)
+}
+
+// Start The HTTP Server (Non-Blocking Call)
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.HttpHost, s.HttpPort))
+	if err != nil {
+		s.logger.Error("Sidecar Server HTTP Listen Returned Error", zap.Error(err))
+		return err
+	}
+
+	// Set the HttpPort field to whatever port was actually used by the system
+	// (If "0" is passed in then the Listen call will assign one arbitrarily)
+	s.HttpPort = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	go func() {
+		s.logger.Info("Starting Sidecar HTTP Server", zap.String("Host", s.HttpHost), zap.String("Port", s.HttpPort))
+		err := s.server.Serve(listener)
+		if err != nil {
+			s.logger.Info("Sidecar Server HTTP Serve Returned Error", zap.Error(err)) // Info log since it could just be normal shutdown
+		}
+	}()
+	return nil
+}
+
+// Stop The HTTP Server Listening For Requests
+func (s *Server) Stop() {
+	s.logger.Info("Stopping Sidecar HTTP Server")
+	err := s.server.Shutdown(context.TODO())
+	if err != nil {
+		s.logger.Error("Sidecar Server Failed To Shutdown HTTP Server", zap.Error(err))
+	}
+}
+
+// Wrap The Specified Handler With Validation Of The Protocol Version Header (Absent == Current Version)
+func (s *Server) versionHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		version := request.Header.Get(custom.ProtocolVersionHeader)
+		if len(version) > 0 && version != custom.ProtocolVersion {
+			s.logger.Warn("Received Request With Unsupported Protocol Version", zap.String("Version", version))
+			http.Error(responseWriter, "unsupported sidecar protocol version '"+version+"'", http.StatusBadRequest)
+			return
+		}
+		handler.ServeHTTP(responseWriter, request)
+	})
+}
+
+// HTTP Request Handler For The Topics Collection (/topics)
+func (s *Server) handleTopics(responseWriter http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodPost:
+		topicName := request.Header.Get(custom.TopicNameHeader)
+		if len(topicName) <= 0 {
+			http.Error(responseWriter, "missing '"+custom.TopicNameHeader+"' header", http.StatusBadRequest)
+			return
+		}
+		topicDetail := &custom.TopicDetail{}
+		if !s.decodeRequestBody(responseWriter, request, topicDetail) {
+			return
+		}
+		s.writeResponse(responseWriter, http.StatusCreated, nil, s.backend.CreateTopic(request.Context(), topicName, topicDetail))
+	case http.MethodGet:
+		topicDetails, err := s.backend.ListTopics(request.Context())
+		s.writeResponse(responseWriter, http.StatusOK, topicDetails, err)
+	default:
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// HTTP Request Handler For A Single Topic (/topics/<topic-name>[/partitions|/config])
+func (s *Server) handleTopic(responseWriter http.ResponseWriter, request *http.Request) {
+
+	// Parse The TopicName & Optional Sub-Path From The Request Path
+	topicName, subPath := splitResourcePath(request.URL.Path, custom.TopicsPath)
+	if len(topicName) <= 0 {
+		responseWriter.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case subPath == "" && request.Method == http.MethodGet:
+		topicDetail, err := s.backend.DescribeTopic(request.Context(), topicName)
+		s.writeResponse(responseWriter, http.StatusOK, topicDetail, err)
+	case subPath == "" && request.Method == http.MethodDelete:
+		s.writeResponse(responseWriter, http.StatusOK, nil, s.backend.DeleteTopic(request.Context(), topicName))
+	case subPath == custom.PartitionsPath && request.Method == http.MethodPost:
+		createPartitionsRequest := &custom.CreatePartitionsRequest{}
+		if !s.decodeRequestBody(responseWriter, request, createPartitionsRequest) {
+			return
+		}
+		s.writeResponse(responseWriter, http.StatusOK, nil, s.backend.CreatePartitions(request.Context(), topicName, createPartitionsRequest.NumPartitions))
+	case subPath == custom.ConfigPath && request.Method == http.MethodPut:
+		configEntries := make(map[string]*string)
+		if !s.decodeRequestBody(responseWriter, request, &configEntries) {
+			return
+		}
+		s.writeResponse(responseWriter, http.StatusOK, nil, s.backend.AlterTopicConfig(request.Context(), topicName, configEntries))
+	case subPath == "" || subPath == custom.PartitionsPath || subPath == custom.ConfigPath:
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+	default:
+		responseWriter.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// HTTP Request Handler For A Single ConsumerGroup (/consumergroups/<group-id>[/offsets])
+func (s *Server) handleConsumerGroup(responseWriter http.ResponseWriter, request *http.Request) {
+
+	// Parse The GroupId & Optional Sub-Path From The Request Path
+	groupId, subPath := splitResourcePath(request.URL.Path, custom.ConsumerGroupsPath)
+	if len(groupId) <= 0 {
+		responseWriter.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case subPath == "" && request.Method == http.MethodDelete:
+		s.writeResponse(responseWriter, http.StatusOK, nil, s.backend.DeleteConsumerGroup(request.Context(), groupId))
+	case subPath == custom.OffsetsPath && request.Method == http.MethodGet:
+		groupOffsets, err := s.backend.ListConsumerGroupOffsets(request.Context(), groupId)
+		s.writeResponse(responseWriter, http.StatusOK, groupOffsets, err)
+	case subPath == "" || subPath == custom.OffsetsPath:
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+	default:
+		responseWriter.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Decode The JSON Request Body Into The Specified Value, Writing A 400 Response On Failure
+func (s *Server) decodeRequestBody(responseWriter http.ResponseWriter, request *http.Request, value interface{}) bool {
+	err := json.NewDecoder(request.Body).Decode(value)
+	if err != nil {
+		s.logger.Warn("Failed To Decode Request Body", zap.String("Path", request.URL.Path), zap.Error(err))
+		http.Error(responseWriter, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// Write The Backend Result As An HTTP Response (JSON Body On Success, Mapped Status Code & Text Body On Failure)
+func (s *Server) writeResponse(responseWriter http.ResponseWriter, successStatusCode int, body interface{}, err error) {
+
+	// Map Backend Errors To Their Protocol Status Codes
+	if err != nil {
+		statusCode := StatusCodeForError(err)
+		if statusCode == http.StatusInternalServerError {
+			s.logger.Error("Sidecar Backend Operation Failed", zap.Error(err))
+		}
+		http.Error(responseWriter, err.Error(), statusCode)
+		return
+	}
+
+	// Write The Successful Response With Optional JSON Body
+	if body == nil {
+		responseWriter.WriteHeader(successStatusCode)
+		return
+	}
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(successStatusCode)
+	err = json.NewEncoder(responseWriter).Encode(body)
+	if err != nil {
+		s.logger.Error("Failed To Encode Response Body", zap.Error(err))
+	}
+}
+
+// Map The Specified Backend Error To The HTTP Status Code Defined By The Sidecar Protocol
+func StatusCodeForError(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrTopicAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrTopicNotFound), errors.Is(err, ErrConsumerGroupNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNotImplemented):
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Split A Request Path Of The Form <basePath>/<name>[/<subPath>] Into The Name & SubPath (With Leading Slash)
+func splitResourcePath(path string, basePath string) (string, string) {
+	resourcePath := strings.TrimPrefix(path, basePath+"/")
+	slashIndex := strings.Index(resourcePath, "/")
+	if slashIndex < 0 {
+		return resourcePath, ""
+	}
+	return resourcePath[:slashIndex], resourcePath[slashIndex:]
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewSidecarServer() Constructor
+func TestNewSidecarServer(t *testing.T) {
+
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Verify The Defaults Match The Custom AdminClient Expectations
+	sidecarServer := NewSidecarServer(logger, "", "", NewMemoryBackend())
+	assert.NotNil(t, sidecarServer)
+	assert.Equal(t, custom.SidecarHost, sidecarServer.HttpHost)
+	assert.Equal(t, custom.SidecarPort, sidecarServer.HttpPort)
+	assert.Equal(t, custom.SidecarHost+":"+custom.SidecarPort, sidecarServer.server.Addr)
+
+	// Verify Custom Host & Port
+	sidecarServer = NewSidecarServer(logger, "127.0.0.1", "9999", NewMemoryBackend())
+	assert.Equal(t, "127.0.0.1", sidecarServer.HttpHost)
+	assert.Equal(t, "9999", sidecarServer.HttpPort)
+	assert.Equal(t, "127.0.0.1:9999", sidecarServer.server.Addr)
+}
+
+// Test The Sidecar Server Start() & Stop() Functionality
+func TestSidecarServerStartStop(t *testing.T) {
+
+	// Start A Sidecar Server On An Arbitrary Port
+	sidecarServer := NewSidecarServer(logtesting.TestLogger(t).Desugar(), "localhost", "0", NewMemoryBackend())
+	assert.Nil(t, sidecarServer.Start())
+	defer sidecarServer.Stop()
+	assert.NotEqual(t, "0", sidecarServer.HttpPort)
+
+	// Verify The Server Is Responding
+	response, err := http.Get("http://localhost:" + sidecarServer.HttpPort + custom.TopicsPath)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Nil(t, response.Body.Close())
+}
+
+// Test The Full Topic Lifecycle Against The MemoryBackend
+func TestSidecarServerTopics(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	topicDetail := custom.NewTopicDetail(4, 2, nil, map[string]*string{"retention.ms": &retentionMillis})
+
+	// Create The Test Server
+	testServer := httptest.NewServer(NewSidecarServer(logtesting.TestLogger(t).Desugar(), "", "", NewMemoryBackend()).Handler())
+	defer testServer.Close()
+
+	// Create The Topic (Twice To Verify Conflict)
+	headers := map[string]string{custom.TopicNameHeader: topicName}
+	statusCode, _ := performRequest(t, http.MethodPost, testServer.URL+custom.TopicsPath, headers, topicDetail)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	statusCode, _ = performRequest(t, http.MethodPost, testServer.URL+custom.TopicsPath, headers, topicDetail)
+	assert.Equal(t, http.StatusConflict, statusCode)
+
+	// Describe The Topic
+	statusCode, body := performRequest(t, http.MethodGet, testServer.URL+custom.TopicsPath+"/"+topicName, nil, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	describedTopicDetail := &custom.TopicDetail{}
+	assert.Nil(t, json.Unmarshal(body, describedTopicDetail))
+	assert.Equal(t, topicDetail, describedTopicDetail)
+
+	// Create Partitions & Alter Config
+	statusCode, _ = performRequest(t, http.MethodPost, testServer.URL+custom.TopicsPath+"/"+topicName+custom.PartitionsPath, nil, custom.NewCreatePartitionsRequest(8))
+	assert.Equal(t, http.StatusOK, statusCode)
+	statusCode, _ = performRequest(t, http.MethodPost, testServer.URL+custom.TopicsPath+"/"+topicName+custom.PartitionsPath, nil, custom.NewCreatePartitionsRequest(2))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	statusCode, _ = performRequest(t, http.MethodPut, testServer.URL+custom.TopicsPath+"/"+topicName+custom.ConfigPath, nil, map[string]*string{})
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	// List The Topics
+	statusCode, body = performRequest(t, http.MethodGet, testServer.URL+custom.TopicsPath, nil, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	topicDetails := make(map[string]*custom.TopicDetail)
+	assert.Nil(t, json.Unmarshal(body, &topicDetails))
+	assert.Len(t, topicDetails, 1)
+	assert.Equal(t, int32(8), topicDetails[topicName].NumPartitions)
+	assert.Empty(t, topicDetails[topicName].ConfigEntries)
+
+	// Delete The Topic (Twice To Verify Not Found)
+	statusCode, _ = performRequest(t, http.MethodDelete, testServer.URL+custom.TopicsPath+"/"+topicName, nil, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	statusCode, _ = performRequest(t, http.MethodDelete, testServer.URL+custom.TopicsPath+"/"+topicName, nil, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	statusCode, _ = performRequest(t, http.MethodGet, testServer.URL+custom.TopicsPath+"/"+topicName, nil, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+// Test The ConsumerGroup Endpoints Against The MemoryBackend
+func TestSidecarServerConsumerGroups(t *testing.T) {
+
+	// Test Data
+	groupId := "TestGroupId"
+	groupOffsets := custom.ConsumerGroupOffsets{"TestTopicName": {0: 100, 1: 200}}
+
+	// Create The Test Server With A Seeded MemoryBackend
+	memoryBackend := NewMemoryBackend()
+	memoryBackend.SetConsumerGroupOffsets(groupId, groupOffsets)
+	testServer := httptest.NewServer(NewSidecarServer(logtesting.TestLogger(t).Desugar(), "", "", memoryBackend).Handler())
+	defer testServer.Close()
+
+	// List The Offsets
+	statusCode, body := performRequest(t, http.MethodGet, testServer.URL+custom.ConsumerGroupsPath+"/"+groupId+custom.OffsetsPath, nil, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	listedGroupOffsets := make(custom.ConsumerGroupOffsets)
+	assert.Nil(t, json.Unmarshal(body, &listedGroupOffsets))
+	assert.Equal(t, groupOffsets, listedGroupOffsets)
+
+	// Delete The ConsumerGroup (Twice To Verify Not Found)
+	statusCode, _ = performRequest(t, http.MethodDelete, testServer.URL+custom.ConsumerGroupsPath+"/"+groupId, nil, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	statusCode, _ = performRequest(t, http.MethodDelete, testServer.URL+custom.ConsumerGroupsPath+"/"+groupId, nil, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	statusCode, _ = performRequest(t, http.MethodGet, testServer.URL+custom.ConsumerGroupsPath+"/"+groupId+custom.OffsetsPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+// Test The Sidecar Server's Handling Of Invalid Requests
+func TestSidecarServerInvalidRequests(t *testing.T) {
+
+	// Create The Test Server
+	testServer := httptest.NewServer(NewSidecarServer(logtesting.TestLogger(t).Desugar(), "", "", NewMemoryBackend()).Handler())
+	defer testServer.Close()
+
+	// Define The TestCases
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		body       interface{}
+		statusCode int
+	}{
+		{"Unsupported Version", http.MethodGet, custom.TopicsPath, map[string]string{custom.ProtocolVersionHeader: "v0"}, nil, http.StatusBadRequest},
+		{"Current Version", http.MethodGet, custom.TopicsPath, map[string]string{custom.ProtocolVersionHeader: custom.ProtocolVersion}, nil, http.StatusOK},
+		{"Create Missing TopicName", http.MethodPost, custom.TopicsPath, nil, custom.NewTopicDetail(1, 1, nil, nil), http.StatusBadRequest},
+		{"Create Invalid Body", http.MethodPost, custom.TopicsPath, map[string]string{custom.TopicNameHeader: "Topic"}, "invalid", http.StatusBadRequest},
+		{"Topics Method Not Allowed", http.MethodPut, custom.TopicsPath, nil, nil, http.StatusMethodNotAllowed},
+		{"Topic Method Not Allowed", http.MethodPost, custom.TopicsPath + "/Topic", nil, nil, http.StatusMethodNotAllowed},
+		{"Topic Unknown SubPath", http.MethodGet, custom.TopicsPath + "/Topic/unknown", nil, nil, http.StatusNotFound},
+		{"Topic Empty Name", http.MethodGet, custom.TopicsPath + "/", nil, nil, http.StatusNotFound},
+		{"Partitions Unknown Topic", http.MethodPost, custom.TopicsPath + "/Topic" + custom.PartitionsPath, nil, custom.NewCreatePartitionsRequest(2), http.StatusNotFound},
+		{"Config Unknown Topic", http.MethodPut, custom.TopicsPath + "/Topic" + custom.ConfigPath, nil, map[string]*string{}, http.StatusNotFound},
+		{"ConsumerGroup Method Not Allowed", http.MethodGet, custom.ConsumerGroupsPath + "/Group", nil, nil, http.StatusMethodNotAllowed},
+		{"ConsumerGroup Unknown SubPath", http.MethodGet, custom.ConsumerGroupsPath + "/Group/unknown", nil, nil, http.StatusNotFound},
+	}
+
+	// Run The TestCases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statusCode, _ := performRequest(t, test.method, testServer.URL+test.path, test.headers, test.body)
+			assert.Equal(t, test.statusCode, statusCode)
+		})
+	}
+}
+
+// Test The StatusCodeForError() Functionality
+func TestStatusCodeForError(t *testing.T) {
+	assert.Equal(t, http.StatusOK, StatusCodeForError(nil))
+	assert.Equal(t, http.StatusConflict, StatusCodeForError(fmt.Errorf("%w: wrapped", ErrTopicAlreadyExists)))
+	assert.Equal(t, http.StatusNotFound, StatusCodeForError(ErrTopicNotFound))
+	assert.Equal(t, http.StatusNotFound, StatusCodeForError(ErrConsumerGroupNotFound))
+	assert.Equal(t, http.StatusBadRequest, StatusCodeForError(ErrInvalidRequest))
+	assert.Equal(t, http.StatusNotImplemented, StatusCodeForError(ErrNotImplemented))
+	assert.Equal(t, http.StatusInternalServerError, StatusCodeForError(errors.New("unexpected")))
+}
+
+// Test The Sidecar Server With A Backend Which Does Not Implement Operations
+func TestSidecarServerNotImplemented(t *testing.T) {
+
+	// Create The Test Server
+	testServer := httptest.NewServer(NewSidecarServer(logtesting.TestLogger(t).Desugar(), "", "", &notImplementedBackend{}).Handler())
+	defer testServer.Close()
+
+	// Perform The Test
+	statusCode, body := performRequest(t, http.MethodGet, testServer.URL+custom.TopicsPath+"/Topic", nil, nil)
+
+	// Verify The Results
+	assert.Equal(t, http.StatusNotImplemented, statusCode)
+	assert.Contains(t, string(body), ErrNotImplemented.Error())
+}
+
+//
+// Utilities
+//
+
+// Perform An HTTP Request Against The Test Server & Return The StatusCode & Body
+func performRequest(t *testing.T, method string, url string, headers map[string]string, body interface{}) (int, []byte) {
+
+	// Marshal The Optional Request Body
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		assert.Nil(t, err)
+		bodyReader = bytes.NewBuffer(bodyBytes)
+	}
+
+	// Create & Perform The Request
+	request, err := http.NewRequest(method, url, bodyReader)
+	assert.Nil(t, err)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, response.Body.Close()) }()
+
+	// Read & Return The Response
+	responseBody, err := ioutil.ReadAll(response.Body)
+	assert.Nil(t, err)
+	return response.StatusCode, responseBody
+}
+
+// Test Backend Which Implements Nothing
+type notImplementedBackend struct{}
+
+func (b *notImplementedBackend) CreateTopic(context.Context, string, *custom.TopicDetail) error {
+	return ErrNotImplemented
+}
+func (b *notImplementedBackend) DeleteTopic(context.Context, string) error { return ErrNotImplemented }
+func (b *notImplementedBackend) DescribeTopic(context.Context, string) (*custom.TopicDetail, error) {
+	return nil, ErrNotImplemented
+}
+func (b *notImplementedBackend) ListTopics(context.Context) (map[string]*custom.TopicDetail, error) {
+	return nil, ErrNotImplemented
+}
+func (b *notImplementedBackend) CreatePartitions(context.Context, string, int32) error {
+	return ErrNotImplemented
+}
+func (b *notImplementedBackend) AlterTopicConfig(context.Context, string, map[string]*string) error {
+	return ErrNotImplemented
+}
+func (b *notImplementedBackend) ListConsumerGroupOffsets(context.Context, string) (custom.ConsumerGroupOffsets, error) {
+	return nil, ErrNotImplemented
+}
+func (b *notImplementedBackend) DeleteConsumerGroup(context.Context, string) error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,39 @@
+package strimzi
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+//
+// Strimzi KafkaTopic Custom Resource Constants
+//
+// These describe the subset of the Strimzi KafkaTopic CRD which is used by the "strimzi"
+// AdminClient Type.  See https://strimzi.io/docs/operators/latest/using.html#type-KafkaTopic-reference
+//
+const (
+	KafkaTopicGroup    = "kafka.strimzi.io" // The API Group of the Strimzi KafkaTopic CRD.
+	KafkaTopicVersion  = "v1beta1"          // The API Version of the Strimzi KafkaTopic CRD.
+	KafkaTopicKind     = "KafkaTopic"       // The Kind of the Strimzi KafkaTopic CRD.
+	KafkaTopicResource = "kafkatopics"      // The plural Resource name of the Strimzi KafkaTopic CRD.
+
+	ClusterLabel = "strimzi.io/cluster" // The Label identifying the Strimzi Kafka cluster whose Topic Operator should manage the KafkaTopic.
+
+	SpecTopicName  = "topicName"  // The KafkaTopic spec field containing the actual Kafka Topic name.
+	SpecPartitions = "partitions" // The KafkaTopic spec field containing the number of partitions.
+	SpecReplicas   = "replicas"   // The KafkaTopic spec field containing the replication factor.
+	SpecConfig     = "config"     // The KafkaTopic spec field containing the Topic configuration.
+
+	ConditionReady = "Ready" // The KafkaTopic status condition type indicating the Topic Operator has reconciled the Topic.
+
+	DefaultReadyTimeout = 30 * time.Second // How long to wait for a KafkaTopic to become Ready if not otherwise configured.
+	ReadyPollInterval   = 1 * time.Second  // How often to check a KafkaTopic's Ready condition.
+)
+
+// The GroupVersionResource Of The Strimzi KafkaTopic CRD
+var KafkaTopicGVR = schema.GroupVersionResource{
+	Group:    KafkaTopicGroup,
+	Version:  KafkaTopicVersion,
+	Resource: KafkaTopicResource,
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -11,12 +12,46 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
 
-// Utility Function For Getting All (Limit 100) The Kafka Secrets In A K8S Namespace
+// Context Key For The Name Of The Kafka Secret To Which AdminClients Are Scoped
+type kafkaSecretNameKey struct{}
+
+// Scope The AdminClients Created With The Returned Context To The Single Named Kafka Secret (Cluster)
+func WithKafkaSecretName(ctx context.Context, kafkaSecretName string) context.Context {
+	return context.WithValue(ctx, kafkaSecretNameKey{}, kafkaSecretName)
+}
+
+// Get The Name Of The Kafka Secret To Which The Context Is Scoped (Empty String If Not Scoped)
+func KafkaSecretNameFromContext(ctx context.Context) string {
+	kafkaSecretName, _ := ctx.Value(kafkaSecretNameKey{}).(string)
+	return kafkaSecretName
+}
+
+// Utility Function For Getting All (Limit 100) The Kafka Secrets In A K8S Namespace (Only The Named One If The Context Is Scoped)
 func GetKafkaSecrets(ctx context.Context, k8sClient kubernetes.Interface, k8sNamespace string) (*corev1.SecretList, error) {
-	return k8sClient.CoreV1().Secrets(k8sNamespace).List(ctx, metav1.ListOptions{
+
+	// List The Labelled Kafka Secrets
+	kafkaSecrets, err := k8sClient.CoreV1().Secrets(k8sNamespace).List(ctx, metav1.ListOptions{
 		LabelSelector: constants.KafkaSecretLabel + "=" + "true",
 		Limit:         constants.MaxEventHubNamespaces,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter Out All But The Named Kafka Secret If The Context Is Scoped To One
+	kafkaSecretName := KafkaSecretNameFromContext(ctx)
+	if len(kafkaSecretName) > 0 {
+		filteredKafkaSecrets := make([]corev1.Secret, 0, 1)
+		for _, kafkaSecret := range kafkaSecrets.Items {
+			if kafkaSecret.Name == kafkaSecretName {
+				filteredKafkaSecrets = append(filteredKafkaSecrets, kafkaSecret)
+			}
+		}
+		kafkaSecrets.Items = filteredKafkaSecrets
+	}
+
+	// Return The Kafka Secrets
+	return kafkaSecrets, nil
 }
 
 // Utility Function For Validating Kafka Secret
@@ -84,3 +119,8 @@ func NewTopicError(kError sarama.KError, message string) *sarama.TopicError {
 		ErrMsg: &message,
 	}
 }
+
+// Utility Function For Creating A TopicError Indicating The Operation Is Not Supported By The AdminClient Type
+func NewUnsupportedOperationTopicError(operation string) *sarama.TopicError {
+	return NewTopicError(sarama.ErrUnsupportedVersion, fmt.Sprintf("topic operation '%s' not supported by AdminClient type", operation))
+}
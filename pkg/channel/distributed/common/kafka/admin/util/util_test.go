@@ -41,6 +41,29 @@ func TestGetKafkaSecrets(t *testing.T) {
 	assert.Len(t, kafkaSecretList.Items, 2)
 	assert.Contains(t, kafkaSecretList.Items, *kafkaSecret1)
 	assert.Contains(t, kafkaSecretList.Items, *kafkaSecret2)
+
+	// Perform The Test Scoped To A Single Kafka Secret
+	kafkaSecretList, err = GetKafkaSecrets(WithKafkaSecretName(context.Background(), kafkaSecretName2), k8sClient, k8sNamespace1)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, kafkaSecretList)
+	assert.Len(t, kafkaSecretList.Items, 1)
+	assert.Contains(t, kafkaSecretList.Items, *kafkaSecret2)
+
+	// Perform The Test Scoped To An Unknown Kafka Secret
+	kafkaSecretList, err = GetKafkaSecrets(WithKafkaSecretName(context.Background(), kafkaSecretName3), k8sClient, k8sNamespace1)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, kafkaSecretList)
+	assert.Len(t, kafkaSecretList.Items, 0)
+}
+
+// Test The WithKafkaSecretName() & KafkaSecretNameFromContext() Functionality
+func TestKafkaSecretNameContext(t *testing.T) {
+	assert.Equal(t, "", KafkaSecretNameFromContext(context.Background()))
+	assert.Equal(t, "TestKafkaSecretName", KafkaSecretNameFromContext(WithKafkaSecretName(context.Background(), "TestKafkaSecretName")))
 }
 
 // Test The ValidateKafkaSecret() Functionality
@@ -163,6 +186,18 @@ func TestNewTopicError(t *testing.T) {
 	assert.Equal(t, errMsg, *topicError.ErrMsg)
 }
 
+// Test The NewUnsupportedOperationTopicError() Functionality
+func TestNewUnsupportedOperationTopicError(t *testing.T) {
+
+	// Perform The Test
+	topicError := NewUnsupportedOperationTopicError("describe")
+
+	// Verify The Results
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, topicError.Err)
+	assert.Equal(t, "topic operation 'describe' not supported by AdminClient type", *topicError.ErrMsg)
+}
+
 //
 // Utilities
 //
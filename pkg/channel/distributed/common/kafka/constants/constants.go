@@ -35,6 +35,18 @@ const (
 
 	// KafkaChannel Constants
 	KafkaChannelServiceNameSuffix = "kn-channel" // Specific Value For Use With Knative e2e Tests!
+
+	// Kafka Cluster Selection
+	KafkaSecretAnnotation = "eventing-kafka.knative.dev/kafka-secret-name" // Name of the labelled Kafka Secret (cluster) backing the KafkaChannel
+
+	// Retry Topic Constants
+	RetryTopicsAnnotation     = "eventing-kafka.knative.dev/retry-topics"      // Number of retry tiers (0 disables retry topics)
+	RetryTopicDelayAnnotation = "eventing-kafka.knative.dev/retry-topic-delay" // Delay of the first retry tier (doubles each tier)
+	RetryTopicInfix           = ".retry."
+	MaxRetryTopics            = 5
+
+	// ConsumerGroup Constants
+	ConsumerGroupIdPrefix = "kafka." // Prefixes The Subscriber UID In The Name Of The Subscriber's ConsumerGroup
 )
 
 // Non-Constant Constants ;)
@@ -1,13 +1,14 @@
 package sarama
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
-	"regexp"
 
 	"github.com/Shopify/sarama"
 	"github.com/ghodss/yaml"
@@ -17,13 +18,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/system"
 )
 
-// Regular Expression To Find All Certificates In Net.TLS.Config.RootPEMs Field
-var regexRootPEMs = regexp.MustCompile(`(?s)\s*RootPEMs:.*-----END CERTIFICATE-----`)
+// The Custom Fields Of The Sarama Config Settings Which Are Not Decoded Into The sarama.Config Directly
+const (
+	saramaVersionField  = "Version"
+	saramaRootPEMsField = "RootPEMs"
+)
+
+// The Placeholder ClientID & SASL Credentials Used To Validate The Sarama Config Settings
+const (
+	saramaValidationClientId = "eventing-kafka-validation"
+	saramaValidationUsername = "eventing-kafka-validation-user"
+	saramaValidationPassword = "eventing-kafka-validation-password"
+)
 
 // Utility Function For Enabling Sarama Logging (Debugging)
 func EnableSaramaLogging() {
@@ -48,58 +58,46 @@ func UpdateSaramaConfig(config *sarama.Config, clientId string, username string,
 }
 
 //
-// Extract (Parse & Remove) Top Level Kafka Version From Specified Sarama Confirm YAML String
+// Extract (Parse & Remove) Top Level Kafka Version From Specified Sarama Config Settings
 //
 // The Sarama.Config struct contains a top-level 'Version' field of type sarama.KafkaVersion.
 // This type contains a package scoped [4]uint field called 'version' which we cannot unmarshal
 // the yaml into.  Therefore, we instead support the user providing a 'Version' string of the
-// format "major.minor.patch" (e.g. "2.3.0") which we will "extract" out of the YAML string
-// and return as an actual sarama.KafkaVersion.  In the case where the user has NOT specified
-// a Version string we will return a default version.
+// format "major.minor.patch" (e.g. "2.3.0") which we will "extract" out of the settings and
+// return as an actual sarama.KafkaVersion.  In the case where the user has NOT specified a
+// Version string we will return the default version.
 //
-func extractKafkaVersion(saramaConfigYamlString string) (string, sarama.KafkaVersion, error) {
+func extractKafkaVersion(settings map[string]interface{}) (sarama.KafkaVersion, error) {
 
-	// Define Inline Struct To Marshall The Top Level Sarama Config "Kafka" Version Into
-	type saramaConfigShell struct {
-		Version string
-	}
-
-	// Unmarshal The Sarama Config Into The Shell
-	shell := &saramaConfigShell{}
-	err := yaml.Unmarshal([]byte(saramaConfigYamlString), shell)
-	if err != nil {
-		return saramaConfigYamlString, constants.ConfigKafkaVersionDefault, err
+	// Remove The Version From The Settings
+	versionValue, ok := settings[saramaVersionField]
+	if !ok {
+		return constants.ConfigKafkaVersionDefault, nil
 	}
+	delete(settings, saramaVersionField)
 
-	// Return Default Kafka Version If Not Specified
-	if len(shell.Version) <= 0 {
-		return saramaConfigYamlString, constants.ConfigKafkaVersionDefault, nil
+	// Verify The Version Is A "major.minor.patch" String (An Unquoted 2.3 Is A Number In YAML)
+	versionString, ok := versionValue.(string)
+	if !ok {
+		return constants.ConfigKafkaVersionDefault, fmt.Errorf("Version must be a \"major.minor.patch\" string, got %v", versionValue)
 	}
 
 	// Attempt To Parse The Version String Into A Sarama.KafkaVersion
-	kafkaVersion, err := sarama.ParseKafkaVersion(shell.Version)
+	kafkaVersion, err := sarama.ParseKafkaVersion(versionString)
 	if err != nil {
-		return saramaConfigYamlString, constants.ConfigKafkaVersionDefault, err
-	}
-
-	// Remove The Version From The Sarama Config YAML String
-	regex, err := regexp.Compile("\\s*Version:\\s*" + shell.Version + "\\s*")
-	if err != nil {
-		return saramaConfigYamlString, constants.ConfigKafkaVersionDefault, err
-	} else {
-		updatedSaramaConfigYamlBytes := regex.ReplaceAll([]byte(saramaConfigYamlString), []byte{})
-		return string(updatedSaramaConfigYamlBytes), kafkaVersion, nil
+		return constants.ConfigKafkaVersionDefault, fmt.Errorf("invalid Version %q: %v", versionString, err)
 	}
+	return kafkaVersion, nil
 }
 
-/* Extract (Parse & Remove) TLS.Config Level RootPEMs From Specified Sarama Confirm YAML String
+/* Extract (Parse & Remove) TLS.Config Level RootPEMs From Specified Sarama Config Settings
 
 The Sarama.Config struct contains Net.TLS.Config which is a *tls.Config which cannot be parsed.
 due to it being from another package and containing lots of func()s.  We do need the ability
 however to provide Root Certificates in order to avoid having to disable verification via the
 InsecureSkipVerify field.  Therefore, we support a custom 'RootPEMs' field which is an array
 of strings containing the PEM file content.  This function will "extract" that content out
-of the YAML string and return as a populated *x509.CertPool which can be assigned to the
+of the settings and return as a populated *x509.CertPool which can be assigned to the
 Sarama.Config.Net.TLS.Config.RootCAs field.  In the case where the user has NOT specified
 any PEM files we will return nil.
 
@@ -113,6 +111,7 @@ metadata:
   name: config-eventing-kafka
   namespace: knative-eventing
 data:
+  version: v1
   sarama: |
     Admin:
       Timeout: 10000000000
@@ -121,7 +120,7 @@ data:
       TLS:
         Enable: true
         Config:
-          RootCaPems:
+          RootPEMs:
           - |-
             -----BEGIN CERTIFICATE-----
             MIIGBDCCA+ygAwIBAgIJAKi1aEV58cQ1MA0GCSqGSIb3DQEBCwUAMIGOMQswCQYD
@@ -136,48 +135,122 @@ data:
 prevent trailing linefeed. The indentation of the PEM content is also important
 and must be aligned as shown.
 */
-func extractRootCerts(saramaConfigYamlString string) (string, *x509.CertPool, error) {
-
-	// Define Inline Struct To Marshall The TLS Config 'RootPEMs' Into
-	type tlsConfigShell struct {
-		Net struct {
-			TLS struct {
-				Config struct {
-					RootPEMs []string
-				}
-			}
-		}
+func extractRootCerts(settings map[string]interface{}) (*x509.CertPool, error) {
+
+	// Navigate To The TLS Config Settings (Exit Early If There Are None)
+	netSettings, _ := settings["Net"].(map[string]interface{})
+	tlsSettings, _ := netSettings["TLS"].(map[string]interface{})
+	tlsConfigSettings, _ := tlsSettings["Config"].(map[string]interface{})
+	rootPEMsValue, ok := tlsConfigSettings[saramaRootPEMsField]
+	if !ok {
+		return nil, nil
 	}
 
-	// Unmarshal The TLS Config Into The Shell
-	shell := &tlsConfigShell{}
-	err := yaml.Unmarshal([]byte(saramaConfigYamlString), shell)
-	if err != nil {
-		return saramaConfigYamlString, nil, err
-	}
+	// Remove The RootPEMs From The Settings
+	delete(tlsConfigSettings, saramaRootPEMsField)
 
-	// Convenience Variable For The RootPEMs
-	rootPEMs := shell.Net.TLS.Config.RootPEMs
+	// Verify The RootPEMs Are A List Of Strings
+	rootPEMs, ok := rootPEMsValue.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Net.TLS.Config.RootPEMs must be a list of PEM strings")
+	}
 
 	// Exit Early If No RootCert PEMs
-	if rootPEMs == nil || len(rootPEMs) < 1 {
-		return saramaConfigYamlString, nil, nil
+	if len(rootPEMs) < 1 {
+		return nil, nil
 	}
 
 	// Create A New CertPool To Contain The Root PEMs
 	certPool := x509.NewCertPool()
 
 	// Populate The CertPool With The PEMs
-	for _, rootPEM := range rootPEMs {
-		ok := certPool.AppendCertsFromPEM([]byte(rootPEM))
-		if !ok {
-			return saramaConfigYamlString, nil, fmt.Errorf("failed to parse root certificate PEM: %s", rootPEM)
+	for _, rootPEMValue := range rootPEMs {
+		rootPEM, ok := rootPEMValue.(string)
+		if !ok || !certPool.AppendCertsFromPEM([]byte(rootPEM)) {
+			return nil, fmt.Errorf("failed to parse root certificate PEM: %v", rootPEMValue)
 		}
 	}
 
-	// Remove The RootPEMs From The Sarama YAML String (Multi-Line / Greedy To Collect All PEMs)
-	updatedSaramaConfigYamlBytes := regexRootPEMs.ReplaceAll([]byte(saramaConfigYamlString), []byte{})
-	return string(updatedSaramaConfigYamlBytes), certPool, nil
+	// Return The Populated CertPool
+	return certPool, nil
+}
+
+//
+// Parse The Sarama Config Settings YAML String Into The Specified Sarama.Config
+//
+// The settings are decoded strictly, such that unknown (e.g. misspelled) fields and values of
+// the wrong type (e.g. a duration which is not a number of nanoseconds) are reported as errors
+// rather than being silently ignored.  The custom Version & RootPEMs fields are extracted first
+// since they cannot be decoded into the sarama.Config directly.
+//
+func parseSaramaSettings(config *sarama.Config, saramaSettingsYamlString string) error {
+
+	// Convert The YAML To JSON And Decode It Into Generic Settings (Preserving The Precision Of Numbers)
+	saramaSettingsJsonBytes, err := yaml.YAMLToJSON([]byte(saramaSettingsYamlString))
+	if err != nil {
+		return fmt.Errorf("invalid YAML: %v", err)
+	}
+	settings := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(saramaSettingsJsonBytes))
+	decoder.UseNumber()
+	if err = decoder.Decode(&settings); err != nil {
+		return fmt.Errorf("expected a YAML object of sarama.Config fields: %v", err)
+	}
+
+	// Extract (Remove) The KafkaVersion From The Settings
+	kafkaVersion, err := extractKafkaVersion(settings)
+	if err != nil {
+		return err
+	}
+
+	// Extract (Remove) Any TLS.Config RootCAs From The Settings
+	certPool, err := extractRootCerts(settings)
+	if err != nil {
+		return err
+	}
+
+	// Strictly Decode The Remaining Settings Into The Provided Sarama.Config Object
+	remainingSettingsJsonBytes, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	decoder = json.NewDecoder(bytes.NewReader(remainingSettingsJsonBytes))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(config); err != nil {
+		return err
+	}
+
+	// Override The Custom Parsed KafkaVersion
+	config.Version = kafkaVersion
+
+	// Override Any Custom Parsed TLS.Config.RootCAs
+	if certPool != nil && len(certPool.Subjects()) > 0 {
+		config.Net.TLS.Config = &tls.Config{RootCAs: certPool}
+	}
+
+	// Return Success
+	return nil
+}
+
+// Strictly Unmarshal The Specified YAML String Into The Specified Object, Rejecting Unknown Fields
+func unmarshalStrict(yamlString string, obj interface{}) error {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(obj)
+}
+
+// Verify The Schema Version Of The Specified ConfigMap Is Supported (An Unspecified Version Is Treated As v1)
+func verifySettingsVersion(configMap *corev1.ConfigMap) error {
+	switch version := configMap.Data[commonconfig.VersionConfigKey]; version {
+	case "", commonconfig.SettingsVersionV1:
+		return nil
+	default:
+		return fmt.Errorf("unsupported %s version %q, the supported version is %q", commonconfig.SettingsConfigMapName, version, commonconfig.SettingsVersionV1)
+	}
 }
 
 // ConfigEqual is a convenience function to determine if two given sarama.Config structs are identical aside
@@ -235,33 +308,15 @@ func MergeSaramaSettings(config *sarama.Config, configMap *corev1.ConfigMap) (*s
 		UpdateSaramaConfig(config, config.ClientID, "", "")
 	}
 
-	// Merge The ConfigMap Settings Into The Provided Config
-	saramaSettingsYamlString := configMap.Data[testing.SaramaSettingsConfigKey]
-
-	// Extract (Remove) The KafkaVersion From The Sarama Config YAML
-	saramaSettingsYamlString, kafkaVersion, err := extractKafkaVersion(saramaSettingsYamlString)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract KafkaVersion from Sarama Config YAML: err=%s : config=%+v", err, saramaSettingsYamlString)
-	}
-
-	// Extract (Remove) Any TLS.Config RootCAs & Set In Sarama.Config
-	saramaSettingsYamlString, certPool, err := extractRootCerts(saramaSettingsYamlString)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract RootPEMs from Sarama Config YAML: err=%s : config=%+v", err, saramaSettingsYamlString)
-	}
-
-	// Unmarshall The Sarama Config Yaml Into The Provided Sarama.Config Object
-	err = yaml.Unmarshal([]byte(saramaSettingsYamlString), &config)
-	if err != nil {
-		return nil, fmt.Errorf("ConfigMap's sarama value could not be converted to a Sarama.Config struct: %s : %v", err, saramaSettingsYamlString)
+	// Verify The ConfigMap Schema Version
+	if err := verifySettingsVersion(configMap); err != nil {
+		return nil, err
 	}
 
-	// Override The Custom Parsed KafkaVersion
-	config.Version = kafkaVersion
-
-	// Override Any Custom Parsed TLS.Config.RootCAs
-	if certPool != nil && len(certPool.Subjects()) > 0 {
-		config.Net.TLS.Config = &tls.Config{RootCAs: certPool}
+	// Merge The ConfigMap Settings Into The Provided Config (The JSON Decoding Of "null" Leaves It Unchanged)
+	saramaSettingsYamlString := configMap.Data[commonconfig.SaramaSettingsConfigKey]
+	if err := parseSaramaSettings(config, saramaSettingsYamlString); err != nil {
+		return nil, fmt.Errorf("ConfigMap's sarama value could not be converted to a Sarama.Config struct: %v", err)
 	}
 
 	// Return Success
@@ -286,15 +341,72 @@ func LoadSettings(ctx context.Context) (*sarama.Config, *commonconfig.EventingKa
 	}
 
 	// Unmarshal The Eventing-Kafka ConfigMap YAML Into A EventingKafkaSettings Struct
-	eventingKafkaConfigString := configMap.Data[commonconfig.EventingKafkaSettingsConfigKey]
-	eventingKafkaConfig := &commonconfig.EventingKafkaConfig{}
-	err = yaml.Unmarshal([]byte(eventingKafkaConfigString), &eventingKafkaConfig)
+	eventingKafkaConfig, err := parseEventingKafkaSettings(configMap)
 	if err != nil {
-		return nil, nil, fmt.Errorf("ConfigMap's eventing-kafka value could not be converted to an EventingKafkaConfig struct: %s : %v", err, eventingKafkaConfigString)
+		return nil, nil, err
 	}
 
 	// Merge The Sarama Settings In The ConfigMap Into A New Base Sarama Config
 	saramaConfig, err := MergeSaramaSettings(nil, configMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return saramaConfig, eventingKafkaConfig, nil
+}
+
+// Strictly Unmarshal The Eventing-Kafka Settings Of The Specified ConfigMap Into A New EventingKafkaConfig Struct
+func parseEventingKafkaSettings(configMap *corev1.ConfigMap) (*commonconfig.EventingKafkaConfig, error) {
+
+	// Verify The ConfigMap Schema Version
+	if err := verifySettingsVersion(configMap); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal The Eventing-Kafka YAML, Rejecting Unknown (e.g. Misspelled) Fields
+	eventingKafkaConfig := &commonconfig.EventingKafkaConfig{}
+	err := unmarshalStrict(configMap.Data[commonconfig.EventingKafkaSettingsConfigKey], eventingKafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ConfigMap's eventing-kafka value could not be converted to an EventingKafkaConfig struct: %v", err)
+	}
+	return eventingKafkaConfig, nil
+}
+
+//
+// Validate The Sarama & EventingKafka Settings Of The Specified ConfigMap
+//
+// This performs the same strict parsing as LoadSettings() and MergeSaramaSettings() so that invalid
+// edits to the ConfigMap can be rejected (e.g. by a validating webhook) before the controller, receiver
+// and dispatcher pick them up.  The resulting sarama.Config is also validated by Sarama itself, after
+// filling in the ClientID and SASL credentials which are only provided at runtime (from the Kafka Secret),
+// so that invalid values (e.g. a zero or negative duration) are reported as well.  The EventingKafkaConfig
+// is returned to allow for further (component specific) verification.
+//
+func ValidateSettings(configMap *corev1.ConfigMap) (*commonconfig.EventingKafkaConfig, error) {
+
+	// Validate The ConfigMap Data
+	if configMap.Data == nil {
+		return nil, fmt.Errorf("attempted to validate empty configmap")
+	}
+
+	// Strictly Parse The Eventing-Kafka Settings
+	eventingKafkaConfig, err := parseEventingKafkaSettings(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strictly Parse The Sarama Settings Into A New Base Sarama Config
+	saramaConfig, err := MergeSaramaSettings(nil, configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill In The Runtime Provided Settings & Validate The Resulting Sarama Config
+	UpdateSaramaConfig(saramaConfig, saramaValidationClientId, saramaValidationUsername, saramaValidationPassword)
+	if err = saramaConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("ConfigMap's sarama value is not a valid Sarama.Config: %v", err)
+	}
 
-	return saramaConfig, eventingKafkaConfig, err
+	// Return The Validated EventingKafkaConfig
+	return eventingKafkaConfig, nil
 }
@@ -3,19 +3,22 @@ package sarama
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"os"
 	"regexp"
 	"strconv"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 	injectionclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/system"
@@ -395,33 +398,136 @@ func getTestSaramaContext(t *testing.T, saramaConfig string, eventingKafkaConfig
 	return ctx
 }
 
-// Test The ExtractRoots() Functionality
+// Test The ExtractRootCerts() Functionality
 func TestExtractRootCerts(t *testing.T) {
 
-	// The Sarama Config YAML String To Test
-	beforeSaramaConfigYaml := EKDefaultSaramaConfigWithRootCert
+	// The Sarama Config Settings To Test
+	settings := map[string]interface{}{}
+	assert.Nil(t, yaml.Unmarshal([]byte(EKDefaultSaramaConfigWithRootCert), &settings))
 
 	// Perform The Test (Extract The RootCert)
-	afterSaramaConfigYaml, certPool, err := extractRootCerts(beforeSaramaConfigYaml)
+	certPool, err := extractRootCerts(settings)
 
 	// Verify The RootCert Was Extracted Successfully & Returned In CertPool
-	assert.NotNil(t, afterSaramaConfigYaml)
-	assert.NotEqual(t, beforeSaramaConfigYaml, afterSaramaConfigYaml)
-	assert.False(t, strings.Contains(afterSaramaConfigYaml, "RootPEMs"))
-	assert.False(t, strings.Contains(afterSaramaConfigYaml, "-----BEGIN CERTIFICATE-----"))
-	assert.False(t, strings.Contains(afterSaramaConfigYaml, "-----END CERTIFICATE-----"))
-	assert.NotNil(t, certPool)
 	assert.Nil(t, err)
-	subjects := certPool.Subjects()
-	assert.NotNil(t, subjects)
-	assert.Len(t, subjects, 1)
-
-	// Attempt To Extract Again (Now That There Arent' Any RootPEMs)
-	finalSaramaConfigYaml, certPool, err := extractRootCerts(afterSaramaConfigYaml)
+	assert.NotNil(t, certPool)
+	assert.Len(t, certPool.Subjects(), 1)
+	tlsConfigSettings := settings["Net"].(map[string]interface{})["TLS"].(map[string]interface{})["Config"].(map[string]interface{})
+	assert.NotContains(t, tlsConfigSettings, "RootPEMs")
 
-	// Verify The YAML String Is Unchanged And CertPool Is Nil
-	assert.NotNil(t, finalSaramaConfigYaml)
-	assert.Equal(t, afterSaramaConfigYaml, finalSaramaConfigYaml)
+	// Attempt To Extract Again (Now That There Aren't Any RootPEMs) & Verify The CertPool Is Nil
+	certPool, err = extractRootCerts(settings)
 	assert.Nil(t, certPool)
 	assert.Nil(t, err)
+
+	// Verify RootPEMs Which Are Not A List Of Strings Are Rejected
+	tlsConfigSettings["RootPEMs"] = "-----BEGIN CERTIFICATE-----"
+	certPool, err = extractRootCerts(settings)
+	assert.Nil(t, certPool)
+	assert.NotNil(t, err)
+}
+
+// Test The ExtractKafkaVersion() Functionality
+func TestExtractKafkaVersion(t *testing.T) {
+
+	// Define The Test Cases
+	tests := []struct {
+		name        string
+		settings    map[string]interface{}
+		wantVersion sarama.KafkaVersion
+		wantErr     bool
+	}{
+		{name: "Unspecified Version", settings: map[string]interface{}{}, wantVersion: constants.ConfigKafkaVersionDefault},
+		{name: "Valid Version", settings: map[string]interface{}{"Version": "2.3.0"}, wantVersion: sarama.V2_3_0_0},
+		{name: "Invalid Version", settings: map[string]interface{}{"Version": "INVALID"}, wantErr: true},
+		{name: "Numeric Version", settings: map[string]interface{}{"Version": json.Number("2.3")}, wantErr: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := extractKafkaVersion(test.settings)
+			assert.Equal(t, test.wantErr, err != nil)
+			if !test.wantErr {
+				assert.Equal(t, test.wantVersion, version)
+			}
+			assert.NotContains(t, test.settings, "Version")
+		})
+	}
+}
+
+// Test That The Sarama & EventingKafka Settings Are Decoded Strictly
+func TestStrictSettings(t *testing.T) {
+
+	// Define The Test Cases
+	tests := []struct {
+		name          string
+		saramaConfig  string
+		ekConfig      string
+		version       string
+		wantErrSubstr string
+	}{
+		{name: "Valid v1 Settings", saramaConfig: commontesting.OldSaramaConfig, ekConfig: EKDefaultConfigYaml, version: commonconfig.SettingsVersionV1},
+		{name: "Valid Unversioned Settings", saramaConfig: commontesting.OldSaramaConfig, ekConfig: EKDefaultConfigYaml},
+		{name: "Unsupported Version", saramaConfig: commontesting.OldSaramaConfig, ekConfig: EKDefaultConfigYaml, version: "v2", wantErrSubstr: `unsupported config-eventing-kafka version "v2"`},
+		{name: "Misspelled Sarama Field", saramaConfig: "Admin:\n  Timout: 10000000000\n", ekConfig: EKDefaultConfigYaml, wantErrSubstr: `unknown field "Timout"`},
+		{name: "Duration String", saramaConfig: "Admin:\n  Timeout: 10s\n", ekConfig: EKDefaultConfigYaml, wantErrSubstr: "Admin.Timeout"},
+		{name: "Misspelled EventingKafka Field", saramaConfig: commontesting.OldSaramaConfig, ekConfig: "dispatcher:\n  replica: 1\n", wantErrSubstr: `unknown field "replica"`},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := getTestSaramaContext(t, test.saramaConfig, test.ekConfig)
+			configMap, err := injectionclient.Get(ctx).CoreV1().ConfigMaps(commonconstants.KnativeEventingNamespace).Get(ctx, commonconfig.SettingsConfigMapName, metav1.GetOptions{})
+			assert.Nil(t, err)
+			if test.version != "" {
+				configMap.Data[commonconfig.VersionConfigKey] = test.version
+				_, err = injectionclient.Get(ctx).CoreV1().ConfigMaps(commonconstants.KnativeEventingNamespace).Update(ctx, configMap, metav1.UpdateOptions{})
+				assert.Nil(t, err)
+			}
+			saramaConfig, eventingKafkaConfig, err := LoadSettings(ctx)
+			if test.wantErrSubstr == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, saramaConfig)
+				assert.NotNil(t, eventingKafkaConfig)
+			} else {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrSubstr)
+			}
+		})
+	}
+}
+
+// Test The ValidateSettings() Functionality
+func TestValidateSettings(t *testing.T) {
+
+	// Define The Test Cases
+	tests := []struct {
+		name         string
+		saramaConfig string
+		ekConfig     string
+		nilData      bool
+		wantErr      bool
+	}{
+		{name: "Valid Settings", saramaConfig: EKDefaultSaramaConfigWithRootCert, ekConfig: EKDefaultConfigYaml},
+		{name: "Empty ConfigMap", nilData: true, wantErr: true},
+		{name: "Misspelled Field", saramaConfig: "Net:\n  KeepAlive: 30000000000\n  SASL:\n    Enabled: true\n", ekConfig: EKDefaultConfigYaml, wantErr: true},
+		{name: "Negative Duration", saramaConfig: "Admin:\n  Timeout: -1\n", ekConfig: EKDefaultConfigYaml, wantErr: true},
+		{name: "Idempotent Producer Without WaitForAll", saramaConfig: "Version: 2.0.0\nNet:\n  MaxOpenRequests: 1\nProducer:\n  Idempotent: true\n  RequiredAcks: 1\n", ekConfig: EKDefaultConfigYaml, wantErr: true},
+		{name: "Invalid EventingKafka YAML", saramaConfig: EKDefaultSaramaConfig, ekConfig: "\tinvalidYaml", wantErr: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configMap := commontesting.GetTestSaramaConfigMap(test.saramaConfig, test.ekConfig)
+			if test.nilData {
+				configMap.Data = nil
+			}
+			eventingKafkaConfig, err := ValidateSettings(configMap)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantErr, eventingKafkaConfig == nil)
+		})
+	}
 }
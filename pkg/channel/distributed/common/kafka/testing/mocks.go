@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/mock"
 )
 
 //
@@ -47,3 +48,125 @@ func (m *MockConsumerGroup) Close() error {
 	m.Closed = true
 	return nil
 }
+
+//
+// Mock Sarama Kafka ClusterAdmin
+//
+
+// Verify The Mock Sarama ClusterAdmin Implements The Interface
+var _ sarama.ClusterAdmin = &MockClusterAdmin{}
+
+// The Mock Sarama ClusterAdmin
+type MockClusterAdmin struct {
+	mock.Mock
+}
+
+func (m *MockClusterAdmin) DescribeLogDirs(brokers []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	args := m.Called(topic, detail)
+	return args.Get(0).(*sarama.TopicError)
+}
+
+func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	args := m.Called()
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.(map[string]sarama.TopicDetail), args.Error(1)
+	}
+}
+
+func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
+	args := m.Called(topics)
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.([]*sarama.TopicMetadata), args.Error(1)
+	}
+}
+
+func (m *MockClusterAdmin) DeleteTopic(topic string) error {
+	args := m.Called(topic)
+	return args.Get(0).(*sarama.TopicError)
+}
+
+func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	args := m.Called(topic, count)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) ListPartitionReassignments(topics string, partitions []int32) (topicStatus map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, err error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	args := m.Called(resource)
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.([]sarama.ConfigEntry), args.Error(1)
+	}
+}
+
+func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
+	args := m.Called(resourceType, name, entries)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) ListAcls(filter sarama.AclFilter) ([]sarama.ResourceAcls, error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) DeleteACL(filter sarama.AclFilter, validateOnly bool) ([]sarama.MatchingAcl, error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	args := m.Called(group, topicPartitions)
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.(*sarama.OffsetFetchResponse), args.Error(1)
+	}
+}
+
+func (m *MockClusterAdmin) DeleteConsumerGroup(group string) error {
+	args := m.Called(group)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error) {
+	panic("implement me")
+}
+
+func (m *MockClusterAdmin) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
@@ -21,3 +22,38 @@ func AppendKafkaChannelServiceNameSuffix(channelName string) string {
 func TrimKafkaChannelServiceNameSuffix(serviceName string) string {
 	return strings.TrimSuffix(serviceName, "-"+constants.KafkaChannelServiceNameSuffix)
 }
+
+// Get The Formatted Retry Topic Name For The Specified Tier (1 Based) Of The Specified Topic
+func RetryTopicName(topicName string, tier int) string {
+	return fmt.Sprintf("%s%s%d", topicName, constants.RetryTopicInfix, tier)
+}
+
+// Parse The Base Topic Name & Tier From The Specified Topic Name (Tier 0 If Not A Retry Topic)
+func ParseRetryTopicName(topicName string) (string, int) {
+	index := strings.LastIndex(topicName, constants.RetryTopicInfix)
+	if index < 0 {
+		return topicName, 0
+	}
+	tier, err := strconv.Atoi(topicName[index+len(constants.RetryTopicInfix):])
+	if err != nil || tier <= 0 {
+		return topicName, 0
+	}
+	return topicName[:index], tier
+}
+
+// Get The Name Of The ConsumerGroup Of The Subscriber With The Specified UID
+func ConsumerGroupId(subscriberUid string) string {
+	return constants.ConsumerGroupIdPrefix + subscriberUid
+}
+
+// Get The Number Of Retry Topic Tiers From The Specified KafkaChannel Annotations (Invalid Values Disable Retry Topics)
+func RetryTopicCount(annotations map[string]string) int {
+	retryTopics, err := strconv.Atoi(annotations[constants.RetryTopicsAnnotation])
+	if err != nil || retryTopics <= 0 {
+		return 0
+	}
+	if retryTopics > constants.MaxRetryTopics {
+		return constants.MaxRetryTopics
+	}
+	return retryTopics
+}
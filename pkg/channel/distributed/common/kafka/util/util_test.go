@@ -51,3 +51,38 @@ func TestTrimKafkaChannelServiceNameSuffix(t *testing.T) {
 	expectedResult := channelName
 	assert.Equal(t, expectedResult, actualResult)
 }
+
+// Test The RetryTopicName() & ParseRetryTopicName() Functionality
+func TestRetryTopicName(t *testing.T) {
+
+	// Test Data
+	topicName := "TestNamespace.TestName"
+
+	// Perform The Test & Verify The Round Trip
+	retryTopicName := RetryTopicName(topicName, 2)
+	assert.Equal(t, topicName+".retry.2", retryTopicName)
+	baseTopicName, tier := ParseRetryTopicName(retryTopicName)
+	assert.Equal(t, topicName, baseTopicName)
+	assert.Equal(t, 2, tier)
+
+	// Verify Non Retry Topics Are Tier 0
+	for _, nonRetryTopicName := range []string{topicName, topicName + ".retry.", topicName + ".retry.x", topicName + ".retry.0"} {
+		baseTopicName, tier = ParseRetryTopicName(nonRetryTopicName)
+		assert.Equal(t, nonRetryTopicName, baseTopicName)
+		assert.Equal(t, 0, tier)
+	}
+}
+
+// Test The ConsumerGroupId() Functionality
+func TestConsumerGroupId(t *testing.T) {
+	assert.Equal(t, "kafka.TestSubscriberUid", ConsumerGroupId("TestSubscriberUid"))
+}
+
+// Test The RetryTopicCount() Functionality
+func TestRetryTopicCount(t *testing.T) {
+	assert.Equal(t, 0, RetryTopicCount(nil))
+	assert.Equal(t, 0, RetryTopicCount(map[string]string{constants.RetryTopicsAnnotation: "invalid"}))
+	assert.Equal(t, 0, RetryTopicCount(map[string]string{constants.RetryTopicsAnnotation: "-1"}))
+	assert.Equal(t, 3, RetryTopicCount(map[string]string{constants.RetryTopicsAnnotation: "3"}))
+	assert.Equal(t, constants.MaxRetryTopics, RetryTopicCount(map[string]string{constants.RetryTopicsAnnotation: "100"}))
+}
@@ -0,0 +1,232 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+
+	// Delivery Metric Labels
+	LabelNamespace       = "namespace_name"
+	LabelChannel         = "channel_name"
+	LabelSubscription    = "subscription_uid"
+	LabelResponseCode    = "response_code"
+	LabelResult          = "result"
+	LabelPartition       = "partition"
+	ResultDelivered      = "delivered"
+	ResultFailed         = "failed"
+	ResponseCodeNoResult = "-1" // Used when no HTTP response was received (connection failure, etc.)
+)
+
+var (
+	// Counter For The Number Of HTTP Dispatch Attempts By Response Code
+	dispatchedMessageCount = stats.Int64(
+		"dispatched_msg_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Dispatched Message Count",
+		stats.UnitDimensionless,
+	)
+
+	// Distribution Of The Time Taken To Deliver A Message (Including All Retries & DeadLetterSink)
+	dispatchLatency = stats.Float64(
+		"dispatch_latencies",
+		"Dispatch Latency",
+		stats.UnitMilliseconds,
+	)
+
+	// Counter For The Number Of HTTP Dispatch Retries
+	retryCount = stats.Int64(
+		"dispatch_retry_count",
+		"Dispatch Retry Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Messages Delivered To The DeadLetterSink
+	deadLetteredMessageCount = stats.Int64(
+		"dead_lettered_msg_count",
+		"Dead Lettered Message Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Messages Excluded By A Subscription's Filter (Committed Without Dispatch)
+	filteredMessageCount = stats.Int64(
+		"filtered_msg_count",
+		"Filtered Message Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Failed Attempts To Delete The ConsumerGroup Of A Removed Subscription
+	consumerGroupCleanupFailureCount = stats.Int64(
+		"consumer_group_cleanup_failure_count",
+		"ConsumerGroup Cleanup Failure Count",
+		stats.UnitDimensionless,
+	)
+
+	// Gauge For The Number Of Messages In A Partition Not Yet Consumed By A Subscription
+	consumerLag = stats.Int64(
+		"consumer_lag",
+		"Consumer Lag",
+		stats.UnitDimensionless,
+	)
+
+	// Create the tag keys that will be used to add tags to our measurements.
+	namespaceKey    = tag.MustNewKey(LabelNamespace)
+	channelKey      = tag.MustNewKey(LabelChannel)
+	subscriptionKey = tag.MustNewKey(LabelSubscription)
+	responseCodeKey = tag.MustNewKey(LabelResponseCode)
+	resultKey       = tag.MustNewKey(LabelResult)
+	partitionKey    = tag.MustNewKey(LabelPartition)
+
+	// Common Tag Keys Of All Delivery Metrics
+	deliveryTagKeys = []tag.Key{namespaceKey, channelKey, subscriptionKey}
+)
+
+// Register the OpenCensus Delivery View Structures
+func init() {
+	err := view.Register(
+		&view.View{
+			Description: dispatchedMessageCount.Description(),
+			Measure:     dispatchedMessageCount,
+			Aggregation: view.Count(),
+			TagKeys:     append(deliveryTagKeys, responseCodeKey),
+		},
+		&view.View{
+			Description: dispatchLatency.Description(),
+			Measure:     dispatchLatency,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 100000)...), // 1ms - 100s
+			TagKeys:     append(deliveryTagKeys, resultKey),
+		},
+		&view.View{
+			Description: retryCount.Description(),
+			Measure:     retryCount,
+			Aggregation: view.Count(),
+			TagKeys:     deliveryTagKeys,
+		},
+		&view.View{
+			Description: deadLetteredMessageCount.Description(),
+			Measure:     deadLetteredMessageCount,
+			Aggregation: view.Count(),
+			TagKeys:     deliveryTagKeys,
+		},
+		&view.View{
+			Description: filteredMessageCount.Description(),
+			Measure:     filteredMessageCount,
+			Aggregation: view.Count(),
+			TagKeys:     deliveryTagKeys,
+		},
+		&view.View{
+			Description: consumerGroupCleanupFailureCount.Description(),
+			Measure:     consumerGroupCleanupFailureCount,
+			Aggregation: view.Count(),
+			TagKeys:     deliveryTagKeys,
+		},
+		&view.View{
+			Description: consumerLag.Description(),
+			Measure:     consumerLag,
+			Aggregation: view.LastValue(),
+			TagKeys:     append(deliveryTagKeys, partitionKey),
+		},
+	)
+	if err != nil {
+		log.Printf("failed to register opencensus delivery views, %v", err)
+	}
+}
+
+// Wrapper Around Knative Metrics Recording To Facilitate Unit Testing
+var recordWrapper = metrics.Record
+
+// DeliveryReporter defines the interface for sending dispatcher delivery metrics.
+type DeliveryReporter interface {
+	ReportDispatch(tags DeliveryTags, responseCode int)
+	ReportDispatchLatency(tags DeliveryTags, delivered bool, latency time.Duration)
+	ReportRetry(tags DeliveryTags)
+	ReportDeadLetter(tags DeliveryTags)
+	ReportFiltered(tags DeliveryTags)
+	ReportConsumerGroupCleanupFailure(tags DeliveryTags)
+	ReportConsumerLag(tags DeliveryTags, partition int32, lag int64)
+}
+
+// The Tags Identifying A Single Subscription Of A KafkaChannel
+type DeliveryTags struct {
+	Namespace       string
+	Channel         string
+	SubscriptionUID string
+}
+
+// Verify DeliveryStatsReporter Implements DeliveryReporter Interface
+var _ DeliveryReporter = &DeliveryStatsReporter{}
+
+// Define DeliveryReporter Structure
+type DeliveryStatsReporter struct {
+	logger *zap.Logger
+}
+
+// DeliveryReporter Constructor
+func NewDeliveryReporter(logger *zap.Logger) DeliveryReporter {
+	return &DeliveryStatsReporter{logger: logger}
+}
+
+// Report A Single HTTP Dispatch Attempt With The Specified Response Code (Negative If No Response)
+func (r *DeliveryStatsReporter) ReportDispatch(tags DeliveryTags, responseCode int) {
+	responseCodeString := ResponseCodeNoResult
+	if responseCode >= 0 {
+		responseCodeString = strconv.Itoa(responseCode)
+	}
+	r.record(tags, dispatchedMessageCount.M(1), tag.Insert(responseCodeKey, responseCodeString))
+}
+
+// Report The Time Taken To Deliver (Or Fail To Deliver) A Message
+func (r *DeliveryStatsReporter) ReportDispatchLatency(tags DeliveryTags, delivered bool, latency time.Duration) {
+	result := ResultFailed
+	if delivered {
+		result = ResultDelivered
+	}
+	r.record(tags, dispatchLatency.M(float64(latency)/float64(time.Millisecond)), tag.Insert(resultKey, result))
+}
+
+// Report A Single HTTP Dispatch Retry
+func (r *DeliveryStatsReporter) ReportRetry(tags DeliveryTags) {
+	r.record(tags, retryCount.M(1))
+}
+
+// Report A Message Delivered To The DeadLetterSink
+func (r *DeliveryStatsReporter) ReportDeadLetter(tags DeliveryTags) {
+	r.record(tags, deadLetteredMessageCount.M(1))
+}
+
+// Report A Message Excluded By The Subscription's Filter
+func (r *DeliveryStatsReporter) ReportFiltered(tags DeliveryTags) {
+	r.record(tags, filteredMessageCount.M(1))
+}
+
+// Report A Failed Attempt To Delete The ConsumerGroup Of A Removed Subscription
+func (r *DeliveryStatsReporter) ReportConsumerGroupCleanupFailure(tags DeliveryTags) {
+	r.record(tags, consumerGroupCleanupFailureCount.M(1))
+}
+
+// Report The Consumer Lag Of The Specified Partition
+func (r *DeliveryStatsReporter) ReportConsumerLag(tags DeliveryTags, partition int32, lag int64) {
+	r.record(tags, consumerLag.M(lag), tag.Insert(partitionKey, strconv.Itoa(int(partition))))
+}
+
+// Record The Specified Measurement With The DeliveryTags & Any Additional Tag Mutators
+func (r *DeliveryStatsReporter) record(tags DeliveryTags, measurement stats.Measurement, mutators ...tag.Mutator) {
+	mutators = append(mutators,
+		tag.Insert(namespaceKey, tags.Namespace),
+		tag.Insert(channelKey, tags.Channel),
+		tag.Insert(subscriptionKey, tags.SubscriptionUID))
+	ctx, err := tag.New(context.Background(), mutators...)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tags For Delivery Metric", zap.Any("Tags", tags), zap.Error(err))
+		return
+	}
+	recordWrapper(ctx, measurement)
+}
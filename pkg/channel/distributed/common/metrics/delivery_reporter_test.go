@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The DeliveryStatsReporter's Report Functionality
+func TestDeliveryStatsReporter(t *testing.T) {
+
+	// Stub The Record Wrapper To Record Directly Via OpenCensus (And Restore Post-Test)
+	recordWrapperPlaceholder := recordWrapper
+	recordWrapper = func(ctx context.Context, measurement stats.Measurement, _ ...stats.Options) {
+		stats.Record(ctx, measurement)
+	}
+	defer func() { recordWrapper = recordWrapperPlaceholder }()
+
+	// Test Data
+	tags := DeliveryTags{Namespace: "TestNamespace", Channel: "TestChannel", SubscriptionUID: "TestSubscriptionUID"}
+	deliveryReporter := NewDeliveryReporter(logtesting.TestLogger(t).Desugar())
+
+	// Perform The Test
+	deliveryReporter.ReportDispatch(tags, 500)
+	deliveryReporter.ReportDispatch(tags, 500)
+	deliveryReporter.ReportDispatch(tags, -1)
+	deliveryReporter.ReportDispatchLatency(tags, true, 25*time.Millisecond)
+	deliveryReporter.ReportRetry(tags)
+	deliveryReporter.ReportDeadLetter(tags)
+	deliveryReporter.ReportFiltered(tags)
+	deliveryReporter.ReportConsumerGroupCleanupFailure(tags)
+	deliveryReporter.ReportConsumerLag(tags, 3, 17)
+
+	// Verify The Results
+	verifyCount(t, dispatchedMessageCount.Name(), 2, tags, tag.Tag{Key: responseCodeKey, Value: "500"})
+	verifyCount(t, dispatchedMessageCount.Name(), 1, tags, tag.Tag{Key: responseCodeKey, Value: ResponseCodeNoResult})
+	verifyCount(t, retryCount.Name(), 1, tags)
+	verifyCount(t, deadLetteredMessageCount.Name(), 1, tags)
+	verifyCount(t, filteredMessageCount.Name(), 1, tags)
+	verifyCount(t, consumerGroupCleanupFailureCount.Name(), 1, tags)
+	latencyRow := findRow(t, dispatchLatency.Name(), tags, tag.Tag{Key: resultKey, Value: ResultDelivered})
+	assert.Equal(t, int64(1), latencyRow.Data.(*view.DistributionData).Count)
+	assert.Equal(t, float64(25), latencyRow.Data.(*view.DistributionData).Mean)
+	lagRow := findRow(t, consumerLag.Name(), tags, tag.Tag{Key: partitionKey, Value: "3"})
+	assert.Equal(t, float64(17), lagRow.Data.(*view.LastValueData).Value)
+}
+
+// Verify The Count Of The Specified View Row
+func verifyCount(t *testing.T, viewName string, expected int64, tags DeliveryTags, additionalTags ...tag.Tag) {
+	row := findRow(t, viewName, tags, additionalTags...)
+	assert.Equal(t, expected, row.Data.(*view.CountData).Value)
+}
+
+// Find The View Row With The Specified DeliveryTags & Additional Tags
+func findRow(t *testing.T, viewName string, tags DeliveryTags, additionalTags ...tag.Tag) *view.Row {
+	expectedTags := append([]tag.Tag{
+		{Key: namespaceKey, Value: tags.Namespace},
+		{Key: channelKey, Value: tags.Channel},
+		{Key: subscriptionKey, Value: tags.SubscriptionUID},
+	}, additionalTags...)
+	rows, err := view.RetrieveData(viewName)
+	assert.Nil(t, err)
+	for _, row := range rows {
+		if containsTags(row.Tags, expectedTags) {
+			return row
+		}
+	}
+	assert.FailNow(t, "View Row Not Found", "View %s, Tags %v", viewName, expectedTags)
+	return nil
+}
+
+// Determine Whether All The Expected Tags Are Present
+func containsTags(tags []tag.Tag, expectedTags []tag.Tag) bool {
+	for _, expectedTag := range expectedTags {
+		found := false
+		for _, tag := range tags {
+			if tag == expectedTag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
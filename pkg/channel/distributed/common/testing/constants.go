@@ -13,9 +13,7 @@ const (
 	SaramaSettingsConfigKey        = "sarama"
 	EventingKafkaSettingsConfigKey = "eventing-kafka"
 
-	DispatcherReplicas     = "3"
-	DispatcherRetryInitial = "5000"
-	DispatcherRetry        = "500000"
+	DispatcherReplicas = "3"
 
 	// These constants are used here to make sure that the CreateConsumerGroup() call doesn't have problems,
 	// but they aren't non-testing defaults since most settings are now in 200-eventing-kafka-configmap.yaml
@@ -34,9 +32,6 @@ dispatcher:
   memoryLimit: 128Mi
   memoryRequest: 50Mi
   replicas: ` + DispatcherReplicas + `
-  retryInitialIntervalMillis: ` + DispatcherRetryInitial + `
-  retryTimeMillis: ` + DispatcherRetry + `
-  retryExponentialBackoff: true
 `
 
 	OldSaramaConfig = `
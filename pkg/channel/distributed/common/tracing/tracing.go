@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+)
+
+// W3C Trace Context Kafka Header Keys
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// The W3C Trace Context Format Used To (De)Serialize SpanContexts
+var format = &tracecontext.HTTPFormat{}
+
+// Get The Kafka RecordHeaders Containing The W3C Trace Context Of The Specified SpanContext
+func SerializeTrace(spanContext trace.SpanContext) []sarama.RecordHeader {
+	traceParent, traceState := format.SpanContextToHeaders(spanContext)
+	headers := []sarama.RecordHeader{{Key: []byte(TraceParentHeader), Value: []byte(traceParent)}}
+	if traceState != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(TraceStateHeader), Value: []byte(traceState)})
+	}
+	return headers
+}
+
+// Append The W3C Trace Context Of The Span In The Specified Context (If Any) To The ProducerMessage's Headers
+func InjectTrace(ctx context.Context, producerMessage *sarama.ProducerMessage) {
+	if span := trace.FromContext(ctx); span != nil {
+		producerMessage.Headers = append(producerMessage.Headers, SerializeTrace(span.SpanContext())...)
+	}
+}
+
+// Parse The W3C Trace Context From The Specified Kafka RecordHeaders
+func ParseSpanContext(headers []*sarama.RecordHeader) (trace.SpanContext, bool) {
+	var traceParent, traceState string
+	for _, header := range headers {
+		if header == nil {
+			continue
+		}
+		switch string(header.Key) {
+		case TraceParentHeader:
+			traceParent = string(header.Value)
+		case TraceStateHeader:
+			traceState = string(header.Value)
+		}
+	}
+	if traceParent == "" {
+		return trace.SpanContext{}, false
+	}
+	return format.SpanContextFromHeaders(traceParent, traceState)
+}
+
+//
+// Start A New Span For The Specified ConsumerMessage
+//
+// The span continues the trace from the ConsumerMessage's W3C Trace Context headers (as written
+// by the receiver) if present and valid, otherwise a new trace is started.  The span is named
+// "kafkachannel-<topic>" to match the consolidated KafkaChannel implementation.
+//
+func StartTraceFromMessage(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (context.Context, *trace.Span) {
+	spanName := "kafkachannel-" + consumerMessage.Topic
+	spanContext, ok := ParseSpanContext(consumerMessage.Headers)
+	if !ok {
+		return trace.StartSpan(ctx, spanName)
+	}
+	return trace.StartSpanWithRemoteParent(ctx, spanName, spanContext)
+}
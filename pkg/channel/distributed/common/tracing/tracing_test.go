@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/tracestate"
+)
+
+// Test Data
+var (
+	testTraceId        = trace.TraceID{75, 249, 47, 53, 119, 179, 77, 166, 163, 206, 146, 157, 14, 14, 71, 54}
+	testSpanId         = trace.SpanID{0, 240, 103, 170, 11, 169, 2, 183}
+	testTraceState, _  = tracestate.New(nil, tracestate.Entry{Key: "foo", Value: "bar"})
+	testSpanContext    = trace.SpanContext{TraceID: testTraceId, SpanID: testSpanId, TraceOptions: 1, Tracestate: testTraceState}
+	testTopic          = "TestTopic"
+	testSpanName       = "kafkachannel-" + testTopic
+	testHeaderKey      = "TestHeaderKey"
+	testHeaderValue    = "TestHeaderValue"
+	testProducerHeader = sarama.RecordHeader{Key: []byte(testHeaderKey), Value: []byte(testHeaderValue)}
+)
+
+// Test The SerializeTrace() & ParseSpanContext() Round Trip Functionality
+func TestSerializeTraceRoundTrip(t *testing.T) {
+
+	// Test With & Without A TraceState
+	for _, spanContext := range []trace.SpanContext{testSpanContext, {TraceID: testTraceId, SpanID: testSpanId, TraceOptions: 1}} {
+
+		// Perform The Test
+		headers := SerializeTrace(spanContext)
+
+		// Verify The Results
+		if spanContext.Tracestate != nil {
+			assert.Len(t, headers, 2)
+		} else {
+			assert.Len(t, headers, 1)
+		}
+		parsedSpanContext, ok := ParseSpanContext(toConsumerHeaders(headers))
+		assert.True(t, ok)
+		assert.Equal(t, spanContext, parsedSpanContext)
+	}
+}
+
+// Test The ParseSpanContext() Functionality With Missing & Invalid Headers
+func TestParseSpanContextInvalid(t *testing.T) {
+
+	// Missing TraceParent Header
+	_, ok := ParseSpanContext([]*sarama.RecordHeader{&testProducerHeader, nil})
+	assert.False(t, ok)
+
+	// Invalid TraceParent Header
+	_, ok = ParseSpanContext([]*sarama.RecordHeader{{Key: []byte(TraceParentHeader), Value: []byte("invalid")}})
+	assert.False(t, ok)
+}
+
+// Test The InjectTrace() Functionality
+func TestInjectTrace(t *testing.T) {
+
+	// Context Without A Span Should Not Add Headers
+	producerMessage := &sarama.ProducerMessage{Headers: []sarama.RecordHeader{testProducerHeader}}
+	InjectTrace(context.TODO(), producerMessage)
+	assert.Equal(t, []sarama.RecordHeader{testProducerHeader}, producerMessage.Headers)
+
+	// Context With A Span Should Append The Trace Context Headers
+	ctx, span := trace.StartSpan(context.TODO(), "TestSpan")
+	defer span.End()
+	InjectTrace(ctx, producerMessage)
+	assert.Equal(t, testProducerHeader, producerMessage.Headers[0])
+	spanContext, ok := ParseSpanContext(toConsumerHeaders(producerMessage.Headers))
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID, spanContext.TraceID)
+	assert.Equal(t, span.SpanContext().SpanID, spanContext.SpanID)
+}
+
+// Test The StartTraceFromMessage() Functionality
+func TestStartTraceFromMessage(t *testing.T) {
+
+	// Message With Trace Context Should Continue The Trace
+	consumerMessage := &sarama.ConsumerMessage{Topic: testTopic, Headers: toConsumerHeaders(SerializeTrace(testSpanContext))}
+	ctx, span := StartTraceFromMessage(context.TODO(), consumerMessage)
+	assert.NotNil(t, ctx)
+	assert.Equal(t, span, trace.FromContext(ctx))
+	assert.Equal(t, testTraceId, span.SpanContext().TraceID)
+	assert.NotEqual(t, testSpanId, span.SpanContext().SpanID)
+	span.End()
+
+	// Message Without Trace Context Should Start A New Trace
+	consumerMessage = &sarama.ConsumerMessage{Topic: testTopic}
+	ctx, span = StartTraceFromMessage(context.TODO(), consumerMessage)
+	assert.NotNil(t, ctx)
+	assert.Equal(t, span, trace.FromContext(ctx))
+	assert.NotEqual(t, testTraceId, span.SpanContext().TraceID)
+	span.End()
+}
+
+// Utility Function For Converting ProducerMessage Headers Into ConsumerMessage Headers
+func toConsumerHeaders(headers []sarama.RecordHeader) []*sarama.RecordHeader {
+	consumerHeaders := make([]*sarama.RecordHeader, len(headers))
+	for i := range headers {
+		consumerHeaders[i] = &headers[i]
+	}
+	return consumerHeaders
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
@@ -23,12 +25,37 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 	// Verify & Lowercase The Kafka AdminType
 	lowercaseKafkaAdminType := strings.ToLower(configuration.Kafka.AdminType)
 	switch lowercaseKafkaAdminType {
-	case constants.KafkaAdminTypeValueKafka, constants.KafkaAdminTypeValueAzure, constants.KafkaAdminTypeValueCustom:
+	case constants.KafkaAdminTypeValueKafka, constants.KafkaAdminTypeValueAzure, constants.KafkaAdminTypeValueCustom, constants.KafkaAdminTypeValueStrimzi:
 		configuration.Kafka.AdminType = lowercaseKafkaAdminType
 	default:
 		return ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: " + configuration.Kafka.AdminType)
 	}
 
+	// Verify The Strimzi Settings Required By The Strimzi AdminType
+	if configuration.Kafka.AdminType == constants.KafkaAdminTypeValueStrimzi {
+		switch {
+		case configuration.Kafka.Strimzi.ClusterName == "":
+			return ControllerConfigurationError("Kafka.Strimzi.ClusterName must be specified for the strimzi admin type")
+		case configuration.Kafka.Strimzi.Namespace == "":
+			return ControllerConfigurationError("Kafka.Strimzi.Namespace must be specified for the strimzi admin type")
+		}
+	}
+
+	// Verify The Optional Default Topic Deletion Policy
+	switch kafkav1beta1.TopicDeletionPolicy(configuration.Kafka.Topic.DefaultDeletionPolicy) {
+	case "", kafkav1beta1.TopicDeletionPolicyDelete, kafkav1beta1.TopicDeletionPolicyRetain:
+	default:
+		return ControllerConfigurationError("Kafka.Topic.DefaultDeletionPolicy must be either Delete or Retain")
+	}
+
+	// Verify The Optional Autoscaling & PodDisruptionBudget Settings Of The Channel & Dispatcher Deployments
+	if err := verifyScaling("Channel", &configuration.Channel.EKKubernetesConfig); err != nil {
+		return err
+	}
+	if err := verifyScaling("Dispatcher", &configuration.Dispatcher.EKKubernetesConfig); err != nil {
+		return err
+	}
+
 	// Verify mandatory configuration settings
 	switch {
 	case configuration.Kafka.Topic.DefaultNumPartitions < 1:
@@ -60,3 +87,34 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 	}
 	return nil // no problems found
 }
+
+// verifyScaling returns an error if the optional autoscaling or PodDisruptionBudget settings of the specified
+// Deployment configuration are inconsistent (autoscaling is only enabled when MaxReplicas is set).
+func verifyScaling(name string, kubernetesConfig *config.EKKubernetesConfig) error {
+
+	// Verify The Autoscaling Settings
+	autoscaling := kubernetesConfig.Autoscaling
+	if autoscaling.MaxReplicas != 0 || autoscaling.MinReplicas != 0 || autoscaling.TargetCpuUtilizationPercentage != 0 || autoscaling.CustomMetricName != "" {
+		switch {
+		case autoscaling.MinReplicas < 1:
+			return ControllerConfigurationError(name + ".Autoscaling.MinReplicas must be > 0")
+		case autoscaling.MaxReplicas < autoscaling.MinReplicas:
+			return ControllerConfigurationError(name + ".Autoscaling.MaxReplicas must be >= MinReplicas")
+		case autoscaling.TargetCpuUtilizationPercentage < 0:
+			return ControllerConfigurationError(name + ".Autoscaling.TargetCpuUtilizationPercentage must be > 0")
+		case autoscaling.TargetCpuUtilizationPercentage == 0 && autoscaling.CustomMetricName == "":
+			return ControllerConfigurationError(name + ".Autoscaling requires a TargetCpuUtilizationPercentage and / or a CustomMetricName")
+		case autoscaling.CustomMetricName != "" && autoscaling.CustomMetricTargetAverageValue.Sign() <= 0:
+			return ControllerConfigurationError(name + ".Autoscaling.CustomMetricTargetAverageValue must be > 0")
+		}
+	}
+
+	// Verify The PodDisruptionBudget Settings
+	if minAvailable := kubernetesConfig.PodDisruptionBudget.MinAvailable; minAvailable != nil {
+		value, err := intstr.GetValueFromIntOrPercent(minAvailable, 100, true)
+		if err != nil || value < 0 {
+			return ControllerConfigurationError(name + ".PodDisruptionBudget.MinAvailable must be a non-negative number or percentage")
+		}
+	}
+	return nil
+}
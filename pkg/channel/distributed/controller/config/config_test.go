@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 )
 
@@ -12,6 +13,9 @@ import (
 const (
 	kafkaAdminType = "custom"
 
+	strimziClusterName = "TestStrimziCluster"
+	strimziNamespace   = "TestStrimziNamespace"
+
 	defaultNumPartitions     = 7
 	defaultReplicationFactor = 2
 	defaultRetentionMillis   = 13579
@@ -37,7 +41,10 @@ type TestCase struct {
 	kafkaTopicDefaultNumPartitions     int32
 	kafkaTopicDefaultReplicationFactor int16
 	kafkaTopicDefaultRetentionMillis   int64
+	kafkaTopicDefaultDeletionPolicy    string
 	kafkaAdminType                     string
+	strimziClusterName                 string
+	strimziNamespace                   string
 	dispatcherCpuLimit                 resource.Quantity
 	dispatcherCpuRequest               resource.Quantity
 	dispatcherMemoryLimit              resource.Quantity
@@ -48,6 +55,8 @@ type TestCase struct {
 	channelMemoryLimit                 resource.Quantity
 	channelMemoryRequest               resource.Quantity
 	channelReplicas                    int
+	channelAutoscaling                 config.EKAutoscalingConfig
+	dispatcherPodDisruptionBudget      config.EKPodDisruptionBudgetConfig
 
 	expectedError error
 }
@@ -98,6 +107,15 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultDeletionPolicy")
+	testCase.kafkaTopicDefaultDeletionPolicy = "Retain"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultDeletionPolicy")
+	testCase.kafkaTopicDefaultDeletionPolicy = "Archive"
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultDeletionPolicy must be either Delete or Retain")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Dispatcher.CpuLimit")
 	testCase.dispatcherCpuLimit = resource.Quantity{}
 	testCase.expectedError = ControllerConfigurationError("Dispatcher.CpuLimit must be nonzero")
@@ -153,6 +171,63 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: invalidadmintype")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Kafka.Strimzi")
+	testCase.kafkaAdminType = "strimzi"
+	testCase.strimziClusterName = strimziClusterName
+	testCase.strimziNamespace = strimziNamespace
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Strimzi.ClusterName")
+	testCase.kafkaAdminType = "strimzi"
+	testCase.strimziNamespace = strimziNamespace
+	testCase.expectedError = ControllerConfigurationError("Kafka.Strimzi.ClusterName must be specified for the strimzi admin type")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Strimzi.Namespace")
+	testCase.kafkaAdminType = "strimzi"
+	testCase.strimziClusterName = strimziClusterName
+	testCase.expectedError = ControllerConfigurationError("Kafka.Strimzi.Namespace must be specified for the strimzi admin type")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Channel.Autoscaling (CPU)")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MinReplicas: 1, MaxReplicas: 5, TargetCpuUtilizationPercentage: 80}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Channel.Autoscaling (Custom Metric)")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MinReplicas: 2, MaxReplicas: 2, CustomMetricName: "requests_per_second", CustomMetricTargetAverageValue: resource.MustParse("100")}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Channel.Autoscaling.MinReplicas")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MaxReplicas: 5, TargetCpuUtilizationPercentage: 80}
+	testCase.expectedError = ControllerConfigurationError("Channel.Autoscaling.MinReplicas must be > 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Channel.Autoscaling.MaxReplicas")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MinReplicas: 3, MaxReplicas: 2, TargetCpuUtilizationPercentage: 80}
+	testCase.expectedError = ControllerConfigurationError("Channel.Autoscaling.MaxReplicas must be >= MinReplicas")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Channel.Autoscaling Target")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MinReplicas: 1, MaxReplicas: 5}
+	testCase.expectedError = ControllerConfigurationError("Channel.Autoscaling requires a TargetCpuUtilizationPercentage and / or a CustomMetricName")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Channel.Autoscaling.CustomMetricTargetAverageValue")
+	testCase.channelAutoscaling = config.EKAutoscalingConfig{MinReplicas: 1, MaxReplicas: 5, CustomMetricName: "requests_per_second"}
+	testCase.expectedError = ControllerConfigurationError("Channel.Autoscaling.CustomMetricTargetAverageValue must be > 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher.PodDisruptionBudget.MinAvailable")
+	minAvailablePercent := intstr.FromString("50%")
+	testCase.dispatcherPodDisruptionBudget = config.EKPodDisruptionBudgetConfig{MinAvailable: &minAvailablePercent}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.PodDisruptionBudget.MinAvailable")
+	minAvailableInvalid := intstr.FromString("half")
+	testCase.dispatcherPodDisruptionBudget = config.EKPodDisruptionBudgetConfig{MinAvailable: &minAvailableInvalid}
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.PodDisruptionBudget.MinAvailable must be a non-negative number or percentage")
+	testCases = append(testCases, testCase)
+
 	// Loop Over All The TestCases
 	for _, testCase := range testCases {
 
@@ -160,7 +235,10 @@ func TestVerifyConfiguration(t *testing.T) {
 		testConfig.Kafka.Topic.DefaultNumPartitions = testCase.kafkaTopicDefaultNumPartitions
 		testConfig.Kafka.Topic.DefaultReplicationFactor = testCase.kafkaTopicDefaultReplicationFactor
 		testConfig.Kafka.Topic.DefaultRetentionMillis = testCase.kafkaTopicDefaultRetentionMillis
+		testConfig.Kafka.Topic.DefaultDeletionPolicy = testCase.kafkaTopicDefaultDeletionPolicy
 		testConfig.Kafka.AdminType = testCase.kafkaAdminType
+		testConfig.Kafka.Strimzi.ClusterName = testCase.strimziClusterName
+		testConfig.Kafka.Strimzi.Namespace = testCase.strimziNamespace
 		testConfig.Dispatcher.CpuLimit = testCase.dispatcherCpuLimit
 		testConfig.Dispatcher.CpuRequest = testCase.dispatcherCpuRequest
 		testConfig.Dispatcher.MemoryLimit = testCase.dispatcherMemoryLimit
@@ -171,6 +249,8 @@ func TestVerifyConfiguration(t *testing.T) {
 		testConfig.Channel.MemoryLimit = testCase.channelMemoryLimit
 		testConfig.Channel.MemoryRequest = testCase.channelMemoryRequest
 		testConfig.Channel.Replicas = testCase.channelReplicas
+		testConfig.Channel.Autoscaling = testCase.channelAutoscaling
+		testConfig.Dispatcher.PodDisruptionBudget = testCase.dispatcherPodDisruptionBudget
 
 		// Perform The Test
 		err := VerifyConfiguration(testConfig)
@@ -181,6 +261,7 @@ func TestVerifyConfiguration(t *testing.T) {
 			assert.Equal(t, testCase.kafkaTopicDefaultNumPartitions, testConfig.Kafka.Topic.DefaultNumPartitions)
 			assert.Equal(t, testCase.kafkaTopicDefaultReplicationFactor, testConfig.Kafka.Topic.DefaultReplicationFactor)
 			assert.Equal(t, testCase.kafkaTopicDefaultRetentionMillis, testConfig.Kafka.Topic.DefaultRetentionMillis)
+			assert.Equal(t, testCase.kafkaTopicDefaultDeletionPolicy, testConfig.Kafka.Topic.DefaultDeletionPolicy)
 			assert.Equal(t, testCase.kafkaAdminType, testConfig.Kafka.AdminType)
 			assert.Equal(t, testCase.dispatcherCpuLimit, testConfig.Dispatcher.CpuLimit)
 			assert.Equal(t, testCase.dispatcherCpuRequest, testConfig.Dispatcher.CpuRequest)
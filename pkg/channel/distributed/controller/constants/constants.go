@@ -1,11 +1,14 @@
 package constants
 
+import "time"
+
 const (
 
 	// Kafka Admin Type Types
-	KafkaAdminTypeValueKafka  = "kafka"
-	KafkaAdminTypeValueAzure  = "azure"
-	KafkaAdminTypeValueCustom = "custom"
+	KafkaAdminTypeValueKafka   = "kafka"
+	KafkaAdminTypeValueAzure   = "azure"
+	KafkaAdminTypeValueCustom  = "custom"
+	KafkaAdminTypeValueStrimzi = "strimzi"
 
 	// The Controller's Component Name (Needs To Be DNS Safe!)
 	ControllerComponentName = "eventingkafka-controller"
@@ -18,11 +21,13 @@ const (
 	KafkaSecretControllerAgentName  = "kafka-secret-controller"
 
 	// CRD Kinds
-	SecretKind              = "Secret"
-	ServiceKind             = "Service"
-	DeploymentKind          = "Deployment"
-	KnativeSubscriptionKind = "Subscription"
-	KafkaChannelKind        = "KafkaChannel"
+	SecretKind                  = "Secret"
+	ServiceKind                 = "Service"
+	DeploymentKind              = "Deployment"
+	HorizontalPodAutoscalerKind = "HorizontalPodAutoscaler"
+	PodDisruptionBudgetKind     = "PodDisruptionBudget"
+	KnativeSubscriptionKind     = "Subscription"
+	KafkaChannelKind            = "KafkaChannel"
 
 	// HTTP Port
 	HttpPortName = "http"
@@ -50,13 +55,18 @@ const (
 	EventingKafkaFinalizerPrefix = "eventing-kafka/"
 
 	// Labels
-	AppLabel                    = "app"
-	KafkaChannelNameLabel       = "kafkachannel-name"
-	KafkaChannelNamespaceLabel  = "kafkachannel-namespace"
-	KafkaChannelChannelLabel    = "kafkachannel-channel"    // Channel Label - Used To Mark Deployment As Related To Channel
-	KafkaChannelDispatcherLabel = "kafkachannel-dispatcher" // Dispatcher Label - Used To Mark Deployment As Dispatcher
-	KafkaSecretLabel            = "kafkasecret"             // Secret Label - Indicates The Kafka Secret Of The KafkaChannel
-	KafkaTopicLabel             = "kafkaTopic"              // Topic Label - Indicates The Kafka Topic Of The KnativeChannel
+	AppLabel                        = "app"
+	KafkaChannelNameLabel           = "kafkachannel-name"
+	KafkaChannelNamespaceLabel      = "kafkachannel-namespace"
+	KafkaChannelChannelLabel        = "kafkachannel-channel"         // Channel Label - Used To Mark Deployment As Related To Channel
+	KafkaChannelDispatcherLabel     = "kafkachannel-dispatcher"      // Dispatcher Label - Used To Mark Deployment As Dispatcher
+	KafkaChannelDispatcherPoolLabel = "kafkachannel-dispatcher-pool" // Dispatcher Pool Label - Indicates The Dispatcher Pool Of A Pooled Dispatcher Deployment
+	KafkaSecretLabel                = "kafkasecret"                  // Secret Label - Indicates The Kafka Secret Of The KafkaChannel
+	KafkaTopicLabel                 = "kafkaTopic"                   // Topic Label - Indicates The Kafka Topic Of The KnativeChannel
+
+	// Annotations
+	KafkaSecretHashAnnotation         = "eventing-kafka.knative.dev/kafka-secret-hash"         // Pod Template Annotation - Hash Of The Kafka Secret Data (Changes Trigger A Rollout)
+	DispatcherOverridesHashAnnotation = "eventing-kafka.knative.dev/dispatcher-overrides-hash" // Deployment Annotation - Hash Of The KafkaChannel's Dispatcher Overrides (Changes Trigger An Update)
 
 	// Prometheus ServiceMonitor Selector Labels / Values
 	K8sAppChannelSelectorLabel    = "k8s-app"
@@ -77,4 +87,8 @@ const (
 	DispatcherLivenessPeriod  = 5
 	DispatcherReadinessDelay  = 10
 	DispatcherReadinessPeriod = 5
+
+	// Time After The Deletion Of A KafkaChannel During Which The Finalization Is Retried While The ConsumerGroups
+	// Of Its Subscribers Are Still In Use By The Dispatcher (After Which They Are Left In Place)
+	ConsumerGroupDeletionTimeout = 2 * time.Minute
 )
@@ -22,18 +22,31 @@ const (
 	// Channel (Kafka Producer) Reconciliation
 	ChannelServiceReconciliationFailed
 	ChannelDeploymentReconciliationFailed
+	ChannelScalingReconciliationFailed
 	ChannelStatusReconciliationFailed
 
 	// Kafka Topic Reconciliation
 	KafkaTopicReconciliationFailed
+	KafkaTopicRetained
+
+	// Kafka ConsumerGroup Deletion
+	KafkaConsumerGroupDeletionFailed
 
 	// Dispatcher (Kafka Consumer) Reconciliation
 	DispatcherServiceReconciliationFailed
 	DispatcherDeploymentReconciliationFailed
+	DispatcherScalingReconciliationFailed
+
+	// KafkaChannel Kafka Secret (Cluster) Selection
+	KafkaSecretSelectionFailed
+
+	// KafkaChannel Delivery DeadLetterSink Resolution
+	DeadLetterSinkResolutionFailed
 
 	// Kafka Secret Reconciliation
 	KafkaSecretReconciled
 	KafkaSecretFinalized
+	KafkaSecretRotated
 )
 
 // CoreV1 EventType String Value
@@ -60,18 +73,32 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "ChannelServiceReconciliationFailed"
 	case ChannelDeploymentReconciliationFailed:
 		eventTypeString = "ChannelDeploymentReconciliationFailed"
+	case ChannelScalingReconciliationFailed:
+		eventTypeString = "ChannelScalingReconciliationFailed"
 	case ChannelStatusReconciliationFailed:
 		eventTypeString = "ChannelStatusReconciliationFailed"
 	case KafkaTopicReconciliationFailed:
 		eventTypeString = "KafkaTopicReconciliationFailed"
+	case KafkaTopicRetained:
+		eventTypeString = "KafkaTopicRetained"
+	case KafkaConsumerGroupDeletionFailed:
+		eventTypeString = "KafkaConsumerGroupDeletionFailed"
 	case DispatcherServiceReconciliationFailed:
 		eventTypeString = "DispatcherServiceReconciliationFailed"
 	case DispatcherDeploymentReconciliationFailed:
 		eventTypeString = "DispatcherDeploymentReconciliationFailed"
+	case DispatcherScalingReconciliationFailed:
+		eventTypeString = "DispatcherScalingReconciliationFailed"
+	case KafkaSecretSelectionFailed:
+		eventTypeString = "KafkaSecretSelectionFailed"
+	case DeadLetterSinkResolutionFailed:
+		eventTypeString = "DeadLetterSinkResolutionFailed"
 	case KafkaSecretReconciled:
 		eventTypeString = "KafkaSecretReconciled"
 	case KafkaSecretFinalized:
 		eventTypeString = "KafkaSecretFinalized"
+	case KafkaSecretRotated:
+		eventTypeString = "KafkaSecretRotated"
 	}
 
 	// Return The EventType String Value
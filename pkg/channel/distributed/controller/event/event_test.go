@@ -15,11 +15,18 @@ func TestEventTypes(t *testing.T) {
 	performEventTypeStringTest(t, ChannelServiceReconciliationFailed, "ChannelServiceReconciliationFailed")
 	performEventTypeStringTest(t, ChannelServiceReconciliationFailed, "ChannelServiceReconciliationFailed")
 	performEventTypeStringTest(t, ChannelDeploymentReconciliationFailed, "ChannelDeploymentReconciliationFailed")
+	performEventTypeStringTest(t, ChannelScalingReconciliationFailed, "ChannelScalingReconciliationFailed")
 	performEventTypeStringTest(t, KafkaTopicReconciliationFailed, "KafkaTopicReconciliationFailed")
+	performEventTypeStringTest(t, KafkaTopicRetained, "KafkaTopicRetained")
+	performEventTypeStringTest(t, KafkaConsumerGroupDeletionFailed, "KafkaConsumerGroupDeletionFailed")
 	performEventTypeStringTest(t, DispatcherServiceReconciliationFailed, "DispatcherServiceReconciliationFailed")
 	performEventTypeStringTest(t, DispatcherDeploymentReconciliationFailed, "DispatcherDeploymentReconciliationFailed")
+	performEventTypeStringTest(t, DispatcherScalingReconciliationFailed, "DispatcherScalingReconciliationFailed")
+	performEventTypeStringTest(t, KafkaSecretSelectionFailed, "KafkaSecretSelectionFailed")
+	performEventTypeStringTest(t, DeadLetterSinkResolutionFailed, "DeadLetterSinkResolutionFailed")
 	performEventTypeStringTest(t, KafkaSecretReconciled, "KafkaSecretReconciled")
 	performEventTypeStringTest(t, KafkaSecretFinalized, "KafkaSecretFinalized")
+	performEventTypeStringTest(t, KafkaSecretRotated, "KafkaSecretRotated")
 }
 
 // Perform A Single Instance Of The CoreV1 EventType String Test
@@ -0,0 +1,69 @@
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/pkg/controller"
+)
+
+// Delete The ConsumerGroups Of The KafkaChannel's Subscribers, Returning An Error (To Retry The Finalization) Only While
+// Any Of Them Is Still In Use By A Dispatcher Within The ConsumerGroupDeletionTimeout.  Other Failures Are Reported As
+// Warning Events So That They Don't Block The Deletion Of The KafkaChannel.
+func (r *Reconciler) deleteConsumerGroups(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Track The ConsumerGroups Still In Use By A Dispatcher
+	var inUseGroupIds []string
+
+	// Attempt To Delete The ConsumerGroup Of Each Subscriber & Process Results
+	for _, subscriber := range channel.Spec.Subscribers {
+		groupId := commonkafkautil.ConsumerGroupId(string(subscriber.UID))
+		logger := r.logger.With(zap.String("GroupId", groupId))
+		err := r.adminClient.DeleteConsumerGroup(ctx, groupId)
+		switch {
+		case err == nil:
+			logger.Info("Successfully Deleted Subscriber ConsumerGroup")
+		case errors.Is(err, kafkaadmin.ErrUnsupportedOperation):
+			logger.Debug("Kafka AdminClient Does Not Support ConsumerGroup Deletion - Leaving Subscriber ConsumerGroups In Place")
+			return nil
+		case consumerGroupError(err) == sarama.ErrGroupIDNotFound:
+			logger.Info("Subscriber ConsumerGroup Not Found - No Deletion Required")
+		case consumerGroupError(err) == sarama.ErrNonEmptyGroup && channel.DeletionTimestamp != nil &&
+			time.Since(channel.DeletionTimestamp.Time) < constants.ConsumerGroupDeletionTimeout:
+			logger.Info("Subscriber ConsumerGroup Still In Use By The Dispatcher - Retrying")
+			inUseGroupIds = append(inUseGroupIds, groupId)
+		default:
+			logger.Warn("Failed To Delete Subscriber ConsumerGroup", zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaConsumerGroupDeletionFailed.String(), "Failed To Delete ConsumerGroup %q Of Subscriber: %v", groupId, err)
+		}
+	}
+
+	// Retry While Any ConsumerGroup Is Still In Use
+	if len(inUseGroupIds) > 0 {
+		return fmt.Errorf("consumer groups %v are still in use by the dispatcher", inUseGroupIds)
+	}
+	return nil
+}
+
+// Get The Kafka Error Of A Failed ConsumerGroup Deletion (The Custom AdminClient Returns It Within A TopicError)
+func consumerGroupError(err error) sarama.KError {
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) {
+		return topicError.Err
+	}
+	var kafkaError sarama.KError
+	if errors.As(err, &kafkaError) {
+		return kafkaError
+	}
+	return sarama.ErrUnknown
+}
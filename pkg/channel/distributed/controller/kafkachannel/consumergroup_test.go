@@ -0,0 +1,144 @@
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// Test The Deletion Of The ConsumerGroups Of A KafkaChannel's Subscribers
+func TestDeleteConsumerGroups(t *testing.T) {
+
+	// Define The Test Cases (The Error Is Returned For The ConsumerGroup Of The First Subscriber Only)
+	tests := []struct {
+		name             string
+		deletedAgo       time.Duration
+		err              error
+		wantGroupIds     []string
+		wantError        bool
+		wantWarningEvent bool
+	}{
+		{name: "Success", wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}},
+		{name: "Not Found", err: sarama.ErrGroupIDNotFound, wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}},
+		{name: "Not Found (TopicError)", err: adminutil.NewTopicError(sarama.ErrGroupIDNotFound, "not found"), wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}},
+		{name: "Unsupported", err: kafkaadmin.ErrUnsupportedOperation, wantGroupIds: []string{"kafka.sub-1"}},
+		{name: "In Use", err: sarama.ErrNonEmptyGroup, wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}, wantError: true},
+		{name: "In Use After Timeout", deletedAgo: 3 * time.Minute, err: sarama.ErrNonEmptyGroup, wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}, wantWarningEvent: true},
+		{name: "Failure", err: errors.New("test failure"), wantGroupIds: []string{"kafka.sub-1", "kafka.sub-2"}, wantWarningEvent: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Mock Kafka AdminClient Tracking The Deleted ConsumerGroups
+			var deletedGroupIds []string
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockDeleteConsumerGroupFunc: func(ctx context.Context, groupId string) error {
+					deletedGroupIds = append(deletedGroupIds, groupId)
+					if len(deletedGroupIds) == 1 {
+						return test.err
+					}
+					return nil
+				},
+			}
+
+			// Create A KafkaChannel With Two Subscribers, Deleted The Specified Time Ago
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer)
+			channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: "sub-1"}, {UID: "sub-2"}}
+			deletionTimestamp := metav1.NewTime(time.Now().Add(-test.deletedAgo))
+			channel.DeletionTimestamp = &deletionTimestamp
+
+			// Initialize The Reconciler & Event Recorder
+			eventRecorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), eventRecorder)
+			r := &Reconciler{
+				logger:      logtesting.TestLogger(t).Desugar(),
+				adminClient: mockAdminClient,
+			}
+
+			// Perform The Test
+			err := r.deleteConsumerGroups(ctx, channel)
+
+			// Verify The Results
+			if (err != nil) != test.wantError {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.wantGroupIds, deletedGroupIds); diff != "" {
+				t.Errorf("unexpected deleted consumer groups (-want, +got) = %v", diff)
+			}
+			if test.wantWarningEvent {
+				if len(eventRecorder.Events) != 1 {
+					t.Fatalf("expected 1 warning event but got %d", len(eventRecorder.Events))
+				}
+				if warningEvent := <-eventRecorder.Events; !strings.Contains(warningEvent, event.KafkaConsumerGroupDeletionFailed.String()) {
+					t.Errorf("unexpected warning event: %s", warningEvent)
+				}
+			} else if len(eventRecorder.Events) > 0 {
+				t.Errorf("unexpected event: %s", <-eventRecorder.Events)
+			}
+		})
+	}
+}
+
+// Test That The Finalization Of A KafkaChannel Is Retried While Its ConsumerGroups Are In Use, Even If Its Topic Is Retained
+func TestFinalizeConsumerGroupsInUse(t *testing.T) {
+
+	// Create A Mock Kafka AdminClient Whose ConsumerGroups Are In Use
+	mockAdminClient := &controllertesting.MockAdminClient{
+		MockDeleteConsumerGroupFunc: func(ctx context.Context, groupId string) error {
+			return sarama.ErrNonEmptyGroup
+		},
+	}
+
+	// Mock The Creation Of The Kafka AdminClient
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return mockAdminClient, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Initialize The Reconciler
+	config := controllertesting.NewConfig()
+	config.Kafka.Topic.DefaultDeletionPolicy = "Retain"
+	r := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		adminClientType: kafkaadmin.Kafka,
+		adminMutex:      &sync.Mutex{},
+		config:          config,
+	}
+
+	// Perform The Test
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer)
+	channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: "sub-1"}}
+	deletionTimestamp := metav1.Now()
+	channel.DeletionTimestamp = &deletionTimestamp
+	result := r.FinalizeKind(context.TODO(), channel)
+
+	// Verify The Results
+	if result == nil || errors.Is(result, pkgreconciler.NewEvent(corev1.EventTypeNormal, event.KafkaTopicRetained.String(), "")) {
+		t.Errorf("unexpected FinalizeKind() result: %v", result)
+	}
+	if !mockAdminClient.DeleteConsumerGroupCalled() {
+		t.Error("expected DeleteConsumerGroup() to be called")
+	}
+}
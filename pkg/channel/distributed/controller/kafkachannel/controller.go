@@ -21,6 +21,8 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 )
 
 // Track The Reconciler For Shutdown() Usage
@@ -58,6 +60,8 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 		kafkaAdminClientType = kafkaadmin.EventHub
 	case constants.KafkaAdminTypeValueCustom:
 		kafkaAdminClientType = kafkaadmin.Custom
+	case constants.KafkaAdminTypeValueStrimzi:
+		kafkaAdminClientType = kafkaadmin.Strimzi
 	default:
 		logger.Warn("Encountered Unexpected Kafka AdminType - Defaulting To 'kafka'", zap.String("AdminType", configuration.Kafka.AdminType))
 		kafkaAdminClientType = kafkaadmin.Kafka
@@ -90,6 +94,9 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	// Create A New KafkaChannel Controller Impl With The Reconciler
 	controllerImpl := kafkachannelreconciler.NewImpl(ctx, rec)
 
+	// Create A URIResolver For The KafkaChannels' Delivery DeadLetterSinks (Re-Enqueuing On Ref Changes)
+	rec.uriResolver = resolver.NewURIResolver(ctx, controllerImpl.EnqueueKey)
+
 	//
 	// Configure The Informers' EventHandlers
 	//
@@ -110,6 +117,10 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 		FilterFunc: controller.FilterControllerGVK(kafkachannelv1beta1.SchemeGroupVersion.WithKind(constants.KafkaChannelKind)),
 		Handler:    controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
 	})
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.LabelExistsFilterFunc(constants.KafkaChannelDispatcherPoolLabel),
+		Handler:    controller.HandleAll(rec.enqueueDispatcherPoolChannels(controllerImpl.Enqueue)),
+	})
 
 	// Return The KafkaChannel Controller Impl
 	return controllerImpl
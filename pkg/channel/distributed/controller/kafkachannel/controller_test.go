@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
@@ -15,10 +16,12 @@ import (
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	fakeKafkaClient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel/fake" // Knative Fake Informer Injection
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	"knative.dev/pkg/client/injection/kube/client/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake" // Knative Fake Informer Injection
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"    // Knative Fake Informer Injection
 	"knative.dev/pkg/injection"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
@@ -46,6 +49,10 @@ func TestNewController(t *testing.T) {
 	ctx, fakeKafkaClientset := fakeKafkaClient.With(ctx)
 	assert.NotNil(t, fakeKafkaClientset)
 
+	// Add The Fake Dynamic Client & Addressable Duck (For The DeadLetterSink URIResolver) To The Context
+	ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+	ctx = addressable.WithDuck(ctx)
+
 	// Perform The Test (Create The KafkaChannel Controller)
 	controller := NewController(ctx, nil)
 
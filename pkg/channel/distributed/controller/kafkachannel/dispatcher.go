@@ -17,6 +17,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/scaling"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -31,6 +32,14 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, channel *kafkav1be
 	// Get Channel Specific Logger
 	logger := util.ChannelLogger(r.logger, channel)
 
+	// Delete Any Dispatcher Pools No Longer Used (Not Fatal - Retried On The Next Reconciliation)
+	r.cleanupDispatcherPools(ctx)
+
+	// KafkaChannels Of A Dispatcher Pool Share The Pool's Dispatcher Instead Of A Dedicated One
+	if poolName := channel.Labels[commonconstants.DispatcherPoolLabel]; len(poolName) > 0 {
+		return r.reconcilePooledDispatcher(ctx, channel, poolName)
+	}
+
 	// Reconcile The Dispatcher's Service (For Prometheus Only)
 	serviceErr := r.reconcileDispatcherService(ctx, channel)
 	if serviceErr != nil {
@@ -49,8 +58,17 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, channel *kafkav1be
 		logger.Info("Successfully Reconciled Dispatcher Deployment")
 	}
 
+	// Reconcile The Dispatcher Deployment's HorizontalPodAutoscaler & PodDisruptionBudget
+	scalingErr := scaling.ReconcileDeploymentScaling(ctx, logger, r.kubeClientset, util.DispatcherDnsSafeName(channel), util.NewChannelOwnerReference(channel), &r.config.Dispatcher.EKKubernetesConfig)
+	if scalingErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherScalingReconciliationFailed.String(), "Failed To Reconcile Dispatcher Scaling: %v", scalingErr)
+		logger.Error("Failed To Reconcile Dispatcher Scaling", zap.Error(scalingErr))
+	} else {
+		logger.Info("Successfully Reconciled Dispatcher Scaling")
+	}
+
 	// Return Results
-	if serviceErr != nil || deploymentErr != nil {
+	if serviceErr != nil || deploymentErr != nil || scalingErr != nil {
 		return fmt.Errorf("failed to reconcile dispatcher resources")
 	} else {
 		return nil
@@ -110,6 +128,16 @@ func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel) *c
 	serviceName := util.DispatcherDnsSafeName(channel)
 
 	// Create & Return The Service Model
+	return r.newDispatcherServiceModel(serviceName, map[string]string{
+		constants.KafkaChannelDispatcherLabel:   "true",                                  // Identifies the Service as being a KafkaChannel "Dispatcher"
+		constants.KafkaChannelNameLabel:         channel.Name,                            // Identifies the Service's Owning KafkaChannel's Name
+		constants.KafkaChannelNamespaceLabel:    channel.Namespace,                       // Identifies the Service's Owning KafkaChannel's Namespace
+		constants.K8sAppDispatcherSelectorLabel: constants.K8sAppDispatcherSelectorValue, // Prometheus ServiceMonitor
+	}, util.NewChannelOwnerReference(channel))
+}
+
+// Create A Dispatcher Service Model With The Specified Name, Labels & Owner
+func (r *Reconciler) newDispatcherServiceModel(serviceName string, labels map[string]string, ownerReference metav1.OwnerReference) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -118,14 +146,9 @@ func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel) *c
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
 			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
-				constants.KafkaChannelDispatcherLabel:   "true",                                  // Identifies the Service as being a KafkaChannel "Dispatcher"
-				constants.KafkaChannelNameLabel:         channel.Name,                            // Identifies the Service's Owning KafkaChannel's Name
-				constants.KafkaChannelNamespaceLabel:    channel.Namespace,                       // Identifies the Service's Owning KafkaChannel's Namespace
-				constants.K8sAppDispatcherSelectorLabel: constants.K8sAppDispatcherSelectorValue, // Prometheus ServiceMonitor
-			},
+			Labels:    labels,
 			OwnerReferences: []metav1.OwnerReference{
-				util.NewChannelOwnerReference(channel),
+				ownerReference,
 			},
 		},
 		Spec: corev1.ServiceSpec{
@@ -159,7 +182,7 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, channel
 
 			// Then Create The New Deployment
 			r.logger.Info("Dispatcher Deployment Not Found - Creating New One")
-			deployment, err = r.newDispatcherDeployment(channel)
+			deployment, err = r.newDispatcherDeployment(ctx, channel)
 			if err != nil {
 				r.logger.Error("Failed To Create Dispatcher Deployment YAML", zap.Error(err))
 				channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Generate Dispatcher Deployment: %v", err)
@@ -183,6 +206,14 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, channel
 			return err
 		}
 	} else {
+		// Update The Dispatcher Deployment If The KafkaChannel's Dispatcher Overrides Have Changed
+		deployment, err = r.reconcileDispatcherOverrides(ctx, channel, deployment)
+		if err != nil {
+			r.logger.Error("Failed To Update Dispatcher Deployment Overrides", zap.Error(err))
+			channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Update Dispatcher Deployment: %v", err)
+			return err
+		}
+
 		// Successfully Verified Dispatcher Deployment
 		r.logger.Info("Successfully Verified Dispatcher Deployment")
 		channel.Status.PropagateDispatcherStatus(&deployment.Status)
@@ -204,14 +235,11 @@ func (r *Reconciler) getDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 }
 
 // Create Dispatcher Deployment Model For The Specified Channel
-func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+func (r *Reconciler) newDispatcherDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
 
 	// Get The Dispatcher Deployment Name For The Channel
 	deploymentName := util.DispatcherDnsSafeName(channel)
 
-	// Replicas Int Value For De-Referencing
-	replicas := int32(r.config.Dispatcher.Replicas)
-
 	// Create The Dispatcher Container Environment Variables
 	envVars, err := r.dispatcherDeploymentEnvVars(channel)
 	if err != nil {
@@ -219,8 +247,33 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 		return nil, err
 	}
 
+	// Create The Dispatcher Pod Annotations
+	podAnnotations := r.dispatcherPodAnnotations(ctx, channel)
+
 	// Create The Dispatcher's Deployment
-	deployment := &appsv1.Deployment{
+	deployment := r.newDispatcherDeploymentModel(deploymentName, map[string]string{
+		constants.AppLabel:                    deploymentName,    // Matches K8S Service Selector Key/Value
+		constants.KafkaChannelDispatcherLabel: "true",            // Identifies the Deployment as being a KafkaChannel "Dispatcher"
+		constants.KafkaChannelNameLabel:       channel.Name,      // Identifies the Deployment's Owning KafkaChannel's Name
+		constants.KafkaChannelNamespaceLabel:  channel.Namespace, // Identifies the Deployment's Owning KafkaChannel's Namespace
+	}, util.NewChannelOwnerReference(channel), podAnnotations, envVars)
+
+	// Apply The KafkaChannel's Dispatcher Overrides (If Any)
+	applyDispatcherOverrides(deployment, channel)
+	stampDispatcherOverridesHash(deployment, dispatcherOverridesHash(channel))
+
+	// Return The Dispatcher's Deployment
+	return deployment, nil
+}
+
+// Create A Dispatcher Deployment Model With The Specified Name, Labels, Owner, Pod Annotations & Env Vars
+func (r *Reconciler) newDispatcherDeploymentModel(deploymentName string, labels map[string]string, ownerReference metav1.OwnerReference, podAnnotations map[string]string, envVars []corev1.EnvVar) *appsv1.Deployment {
+
+	// Replicas Int Value For De-Referencing
+	replicas := int32(r.config.Dispatcher.Replicas)
+
+	// Create & Return The Dispatcher Deployment Model
+	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: appsv1.SchemeGroupVersion.String(),
 			Kind:       constants.DeploymentKind,
@@ -228,14 +281,9 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deploymentName,
 			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
-				constants.AppLabel:                    deploymentName,    // Matches K8S Service Selector Key/Value Below
-				constants.KafkaChannelDispatcherLabel: "true",            // Identifies the Deployment as being a KafkaChannel "Dispatcher"
-				constants.KafkaChannelNameLabel:       channel.Name,      // Identifies the Deployment's Owning KafkaChannel's Name
-				constants.KafkaChannelNamespaceLabel:  channel.Namespace, // Identifies the Deployment's Owning KafkaChannel's Namespace
-			},
+			Labels:    labels,
 			OwnerReferences: []metav1.OwnerReference{
-				util.NewChannelOwnerReference(channel),
+				ownerReference,
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -250,6 +298,7 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 					Labels: map[string]string{
 						constants.AppLabel: deploymentName, // Matched By Deployment Selector Above
 					},
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: r.environment.ServiceAccount,
@@ -279,25 +328,45 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 							Image:           r.environment.DispatcherImage,
 							Env:             envVars,
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceMemory: r.config.Dispatcher.MemoryLimit,
-									corev1.ResourceCPU:    r.config.Dispatcher.CpuLimit,
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceMemory: r.config.Dispatcher.MemoryRequest,
-									corev1.ResourceCPU:    r.config.Dispatcher.CpuRequest,
-								},
-							},
+							Resources:       r.dispatcherResources(),
 						},
 					},
 				},
 			},
 		},
 	}
+}
 
-	// Return The Dispatcher's Deployment
-	return deployment, nil
+// Create The Dispatcher Container's Resources From The Global Dispatcher Configuration
+func (r *Reconciler) dispatcherResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: r.config.Dispatcher.MemoryLimit,
+			corev1.ResourceCPU:    r.config.Dispatcher.CpuLimit,
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: r.config.Dispatcher.MemoryRequest,
+			corev1.ResourceCPU:    r.config.Dispatcher.CpuRequest,
+		},
+	}
+}
+
+// Create The Dispatcher Pod Annotations
+func (r *Reconciler) dispatcherPodAnnotations(ctx context.Context, channel *kafkav1beta1.KafkaChannel) map[string]string {
+
+	// Get The Kafka Secret Used By The Dispatcher
+	kafkaSecretName := r.adminClient.GetKafkaSecretName(util.TopicName(channel))
+	kafkaSecret, err := r.kubeClientset.CoreV1().Secrets(commonconstants.KnativeEventingNamespace).Get(ctx, kafkaSecretName, metav1.GetOptions{})
+	if err != nil {
+		// Not Fatal - The KafkaSecret Reconciler Will Stamp The Hash (Rolling The Dispatcher Once) When It Processes This KafkaChannel
+		r.logger.Warn("Failed To Get Kafka Secret - Omitting Secret Hash From Dispatcher Pods", zap.String("Secret", kafkaSecretName), zap.Error(err))
+		return nil
+	}
+
+	// Stamp The Kafka Secret Data Hash So Secret Changes Roll The Dispatcher Pods
+	return map[string]string{
+		constants.KafkaSecretHashAnnotation: util.SecretDataHash(kafkaSecret),
+	}
 }
 
 // Create The Dispatcher Container's Env Vars
@@ -307,7 +376,43 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 	topicName := util.TopicName(channel)
 
 	// Create The Dispatcher Deployment EnvVars
-	envVars := []corev1.EnvVar{
+	envVars := append(r.dispatcherBaseEnvVars(),
+		corev1.EnvVar{
+			Name:  commonenv.ChannelKeyEnvVarKey,
+			Value: util.ChannelKey(channel),
+		},
+		corev1.EnvVar{
+			Name:  commonenv.ServiceNameEnvVarKey,
+			Value: util.DispatcherDnsSafeName(channel),
+		},
+		corev1.EnvVar{
+			Name:  commonenv.KafkaTopicEnvVarKey,
+			Value: topicName,
+		},
+	)
+
+	// Get The Kafka Secret From The Kafka Admin Client
+	kafkaSecret := r.adminClient.GetKafkaSecretName(topicName)
+
+	// If The Kafka Secret Env Var Is Specified Then Append Relevant Env Vars
+	if len(kafkaSecret) <= 0 {
+
+		// Received Invalid Kafka Secret - Cannot Proceed
+		return nil, fmt.Errorf("invalid kafkaSecret for topic '%s'", topicName)
+
+	} else {
+
+		// Append The Kafka Brokers, Username & Password As Env Vars
+		envVars = append(envVars, dispatcherKafkaSecretEnvVars(kafkaSecret)...)
+	}
+
+	// Return The Channel Deployment EnvVars Array
+	return envVars, nil
+}
+
+// Create The Dispatcher Container's Env Vars Common To All Dispatchers
+func (r *Reconciler) dispatcherBaseEnvVars() []corev1.EnvVar {
+	return []corev1.EnvVar{
 		{
 			Name:  system.NamespaceEnvKey,
 			Value: commonconstants.KnativeEventingNamespace,
@@ -328,33 +433,13 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 			Name:  commonenv.HealthPortEnvVarKey,
 			Value: strconv.Itoa(constants.HealthPort),
 		},
-		{
-			Name:  commonenv.ChannelKeyEnvVarKey,
-			Value: util.ChannelKey(channel),
-		},
-		{
-			Name:  commonenv.ServiceNameEnvVarKey,
-			Value: util.DispatcherDnsSafeName(channel),
-		},
-		{
-			Name:  commonenv.KafkaTopicEnvVarKey,
-			Value: topicName,
-		},
 	}
+}
 
-	// Get The Kafka Secret From The Kafka Admin Client
-	kafkaSecret := r.adminClient.GetKafkaSecretName(topicName)
-
-	// If The Kafka Secret Env Var Is Specified Then Append Relevant Env Vars
-	if len(kafkaSecret) <= 0 {
-
-		// Received Invalid Kafka Secret - Cannot Proceed
-		return nil, fmt.Errorf("invalid kafkaSecret for topic '%s'", topicName)
-
-	} else {
-
-		// Append The Kafka Brokers As Env Var
-		envVars = append(envVars, corev1.EnvVar{
+// Create The Dispatcher Container's Env Vars Referencing The Specified Kafka Secret
+func dispatcherKafkaSecretEnvVars(kafkaSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
 			Name: commonenv.KafkaBrokerEnvVarKey,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
@@ -362,10 +447,8 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 					Key:                  constants.KafkaSecretDataKeyBrokers,
 				},
 			},
-		})
-
-		// Append The Kafka Username As Env Var
-		envVars = append(envVars, corev1.EnvVar{
+		},
+		{
 			Name: commonenv.KafkaUsernameEnvVarKey,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
@@ -373,10 +456,8 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 					Key:                  constants.KafkaSecretDataKeyUsername,
 				},
 			},
-		})
-
-		// Append The Kafka Password As Env Var
-		envVars = append(envVars, corev1.EnvVar{
+		},
+		{
 			Name: commonenv.KafkaPasswordEnvVarKey,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
@@ -384,9 +465,6 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 					Key:                  constants.KafkaSecretDataKeyPassword,
 				},
 			},
-		})
+		},
 	}
-
-	// Return The Channel Deployment EnvVars Array
-	return envVars, nil
 }
@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/eventing/pkg/apis/messaging"
+	"knative.dev/pkg/controller"
 )
 
 // Reconcile The KafkaChannel Itself - After Channel Reconciliation (Add MetaData)
@@ -30,6 +33,30 @@ func (r *Reconciler) reconcileKafkaChannel(ctx context.Context, channel *kafkav1
 	}
 }
 
+// Resolve The KafkaChannel's Delivery DeadLetterSink Into The Status (Used By Subscribers Without A Delivery)
+func (r *Reconciler) reconcileDeadLetterSink(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Clear The Status If The KafkaChannel Has No Delivery DeadLetterSink
+	if channel.Spec.Delivery == nil || channel.Spec.Delivery.DeadLetterSink == nil {
+		channel.Status.SetDeadLetterSinkURI(nil)
+		return nil
+	}
+
+	// Resolve The DeadLetterSink (Tracking Any Ref For Changes) & Handle Error Response
+	uri, err := r.uriResolver.URIFromDestinationV1(ctx, *channel.Spec.Delivery.DeadLetterSink, channel)
+	if err != nil {
+		r.logger.Error("Failed To Resolve KafkaChannel Delivery DeadLetterSink", zap.Error(err))
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DeadLetterSinkResolutionFailed.String(), "Failed To Resolve Delivery DeadLetterSink: %v", err)
+		channel.Status.SetDeadLetterSinkURI(nil)
+		return err
+	}
+
+	// Update The Status With The Resolved URI
+	r.logger.Info("Successfully Resolved KafkaChannel Delivery DeadLetterSink", zap.String("URI", uri.String()))
+	channel.Status.SetDeadLetterSinkURI(uri)
+	return nil
+}
+
 // Reconcile The KafkaChannels MetaData (Annotations, Labels, etc...)
 func (r *Reconciler) reconcileMetaData(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
 
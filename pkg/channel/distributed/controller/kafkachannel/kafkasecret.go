@@ -0,0 +1,96 @@
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Kafka Secret (Kafka Cluster) Selection
+//
+// A KafkaChannel may select the Kafka cluster backing it by naming one of the labelled Kafka Secrets in the
+// KafkaSecretAnnotation.  The Kafka AdminClient is then scoped to that Kafka Secret so that the Topic operations,
+// as well as the Kafka Secret used by the Channel & Dispatcher Deployments, are those of the selected cluster.
+//
+
+// Scope The Context (And Thus The Kafka AdminClient) To The Kafka Secret Selected By The KafkaChannel, If Any
+func withSelectedKafkaSecret(ctx context.Context, channel *kafkav1beta1.KafkaChannel) context.Context {
+	kafkaSecretName := channel.Annotations[kafkaconstants.KafkaSecretAnnotation]
+	if len(kafkaSecretName) > 0 {
+		return adminutil.WithKafkaSecretName(ctx, kafkaSecretName)
+	}
+	return ctx
+}
+
+// Scope The Context (And Thus The Kafka AdminClient) To The Kafka Secret Previously Used By The KafkaChannel, If Known
+func withReconciledKafkaSecret(ctx context.Context, channel *kafkav1beta1.KafkaChannel) context.Context {
+
+	// The Kafka Secret Label Holds The (Truncated) Name Of The Reconciled Kafka Secret, Which Is The Selected One If It Matches
+	kafkaSecretLabel := channel.Labels[constants.KafkaSecretLabel]
+	if len(kafkaSecretLabel) <= 0 || kafkaSecretLabel == commonk8s.TruncateLabelValue(channel.Annotations[kafkaconstants.KafkaSecretAnnotation]) {
+		return withSelectedKafkaSecret(ctx, channel)
+	}
+
+	// A Possibly Truncated Label Doesn't Name The Kafka Secret, So Leave The AdminClient Unscoped (Spanning All Kafka Secrets)
+	if len(kafkaSecretLabel) >= commonk8s.K8sLabelValueMaxLength {
+		return ctx
+	}
+	return adminutil.WithKafkaSecretName(ctx, kafkaSecretLabel)
+}
+
+// Verify The Kafka Secret Selected By The KafkaChannel (If Any) Is A Known Kafka Secret Which Has Not Been Changed
+func (r *Reconciler) reconcileKafkaSecretSelection(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Verify If The KafkaChannel Doesn't Select A Kafka Secret
+	kafkaSecretName := channel.Annotations[kafkaconstants.KafkaSecretAnnotation]
+	if len(kafkaSecretName) <= 0 {
+		return nil
+	}
+
+	// Verify The Selected Kafka Secret
+	err := r.verifyKafkaSecretSelection(ctx, channel, kafkaSecretName)
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaSecretSelectionFailed.String(), "Failed To Select Kafka Secret For Channel: %v", err)
+		util.ChannelLogger(r.logger, channel).Error("Invalid Kafka Secret Selection", zap.String("Secret", kafkaSecretName), zap.Error(err))
+		channel.Status.MarkConfigFailed(event.KafkaSecretSelectionFailed.String(), "Invalid Kafka Secret Selection: %v", err)
+		return err
+	}
+
+	// Return Success
+	return nil
+}
+
+// Verify The Specified Kafka Secret Is A Labelled Kafka Secret & Is The One Already In Use By The KafkaChannel (If Any)
+func (r *Reconciler) verifyKafkaSecretSelection(ctx context.Context, channel *kafkav1beta1.KafkaChannel, kafkaSecretName string) error {
+
+	// The Kafka Topic Can't Be Moved Between Kafka Clusters (The Label Holds The Truncated Name Of The Current Kafka Secret)
+	currentKafkaSecretLabel := channel.Labels[constants.KafkaSecretLabel]
+	if len(currentKafkaSecretLabel) > 0 && currentKafkaSecretLabel != commonk8s.TruncateLabelValue(kafkaSecretName) {
+		return fmt.Errorf("the Kafka Secret cannot be changed from %q to %q", currentKafkaSecretLabel, kafkaSecretName)
+	}
+
+	// The Selected Kafka Secret Must Exist & Be Labelled As A Kafka Secret
+	kafkaSecret, err := r.kubeClientset.CoreV1().Secrets(commonconstants.KnativeEventingNamespace).Get(ctx, kafkaSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unknown Kafka Secret %q: %v", kafkaSecretName, err)
+	}
+	if kafkaSecret.Labels[kafkaconstants.KafkaSecretLabel] != "true" {
+		return fmt.Errorf("secret %q is not labelled as a Kafka Secret (%s: \"true\")", kafkaSecretName, kafkaconstants.KafkaSecretLabel)
+	}
+
+	// Return Success
+	return nil
+}
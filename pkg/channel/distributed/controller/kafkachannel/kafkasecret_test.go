@@ -0,0 +1,170 @@
+package kafkachannel
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Kafka Secret Selection Scoping Of The Context
+func TestWithSelectedKafkaSecret(t *testing.T) {
+
+	// An Unannotated KafkaChannel Leaves The Context Unscoped
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithLabels)
+	if got := adminutil.KafkaSecretNameFromContext(withSelectedKafkaSecret(context.TODO(), channel)); got != "" {
+		t.Errorf("expected unscoped context but got %q", got)
+	}
+
+	// An Annotated KafkaChannel Scopes The Context To The Selected Kafka Secret (Rather Than The Reconciled One)
+	channel.Annotations = map[string]string{kafkaconstants.KafkaSecretAnnotation: "selected-kafka-secret"}
+	if got := adminutil.KafkaSecretNameFromContext(withSelectedKafkaSecret(context.TODO(), channel)); got != "selected-kafka-secret" {
+		t.Errorf("expected context scoped to selected-kafka-secret but got %q", got)
+	}
+
+	// The Reconciled Kafka Secret Takes Precedence When Finalizing
+	if got := adminutil.KafkaSecretNameFromContext(withReconciledKafkaSecret(context.TODO(), channel)); got != controllertesting.KafkaSecretName {
+		t.Errorf("expected context scoped to %s but got %q", controllertesting.KafkaSecretName, got)
+	}
+
+	// The Full Name Of A Selected Kafka Secret Whose Name Was Truncated In The Label Is Used When Finalizing
+	longKafkaSecretName := strings.Repeat("k", commonk8s.K8sLabelValueMaxLength+10)
+	channel.Annotations[kafkaconstants.KafkaSecretAnnotation] = longKafkaSecretName
+	channel.Labels[constants.KafkaSecretLabel] = commonk8s.TruncateLabelValue(longKafkaSecretName)
+	if got := adminutil.KafkaSecretNameFromContext(withReconciledKafkaSecret(context.TODO(), channel)); got != longKafkaSecretName {
+		t.Errorf("expected context scoped to %s but got %q", longKafkaSecretName, got)
+	}
+
+	// A Truncated Label Not Matching The Selection Leaves The Context Unscoped
+	channel.Annotations[kafkaconstants.KafkaSecretAnnotation] = "selected-kafka-secret"
+	if got := adminutil.KafkaSecretNameFromContext(withReconciledKafkaSecret(context.TODO(), channel)); got != "" {
+		t.Errorf("expected unscoped context but got %q", got)
+	}
+
+	// The Selected Kafka Secret Is Used When Finalizing A KafkaChannel Which Was Never Reconciled
+	channel.Labels = nil
+	if got := adminutil.KafkaSecretNameFromContext(withReconciledKafkaSecret(context.TODO(), channel)); got != "selected-kafka-secret" {
+		t.Errorf("expected context scoped to selected-kafka-secret but got %q", got)
+	}
+}
+
+// Test The Verification Of The Kafka Secret Selected By A KafkaChannel
+func TestReconcileKafkaSecretSelection(t *testing.T) {
+
+	// Test Data - A Labelled Kafka Secret & A Plain Secret
+	kafkaSecret := controllertesting.NewKafkaSecret()
+	kafkaSecret.Labels = map[string]string{kafkaconstants.KafkaSecretLabel: "true"}
+	plainSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "plain-secret", Namespace: controllertesting.KafkaSecretNamespace}}
+	longKafkaSecret := kafkaSecret.DeepCopy()
+	longKafkaSecret.Name = strings.Repeat("k", commonk8s.K8sLabelValueMaxLength+10)
+
+	// Define The Test Cases
+	tests := []struct {
+		name       string
+		selection  string
+		reconciled bool
+		wantErr    bool
+	}{
+		{name: "No Selection"},
+		{name: "Known Kafka Secret", selection: controllertesting.KafkaSecretName},
+		{name: "Known Kafka Secret Already Reconciled", selection: controllertesting.KafkaSecretName, reconciled: true},
+		{name: "Unknown Kafka Secret", selection: "unknown-secret", wantErr: true},
+		{name: "Unlabelled Secret", selection: plainSecret.Name, wantErr: true},
+		{name: "Changed Kafka Secret", selection: plainSecret.Name, reconciled: true, wantErr: true},
+		{name: "Long Kafka Secret Already Reconciled", selection: longKafkaSecret.Name, reconciled: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Initialize The Reconciler
+			r := &Reconciler{
+				logger:        logtesting.TestLogger(t).Desugar(),
+				kubeClientset: fake.NewSimpleClientset(kafkaSecret, plainSecret, longKafkaSecret),
+			}
+
+			// Create The KafkaChannel With The Selected (And Possibly Previously Reconciled) Kafka Secret
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			if len(test.selection) > 0 {
+				channel.Annotations = map[string]string{kafkaconstants.KafkaSecretAnnotation: test.selection}
+			}
+			if test.reconciled {
+				reconciledKafkaSecretName := controllertesting.KafkaSecretName
+				if test.selection == longKafkaSecret.Name {
+					reconciledKafkaSecretName = longKafkaSecret.Name
+				}
+				channel.Labels = map[string]string{constants.KafkaSecretLabel: commonk8s.TruncateLabelValue(reconciledKafkaSecretName)}
+			}
+
+			// Perform The Test
+			eventRecorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), eventRecorder)
+			err := r.reconcileKafkaSecretSelection(ctx, channel)
+
+			// Verify The Results
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			configCondition := channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady)
+			if test.wantErr {
+				if configCondition == nil || !configCondition.IsFalse() {
+					t.Errorf("expected ConfigurationReady to be False but got %v", configCondition)
+				}
+				if len(eventRecorder.Events) != 1 {
+					t.Errorf("expected 1 warning event but got %d", len(eventRecorder.Events))
+				}
+			} else if configCondition != nil && configCondition.IsFalse() {
+				t.Errorf("unexpected ConfigurationReady failure: %v", configCondition)
+			}
+		})
+	}
+}
+
+// Test That Finalization Scopes The Kafka AdminClient To The Kafka Secret The Topic Was Created With
+func TestFinalizeKindScopedToReconciledKafkaSecret(t *testing.T) {
+
+	// Mock The Creation Of The Kafka AdminClient Capturing The Kafka Secret Scope
+	var scopedKafkaSecretName string
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		scopedKafkaSecretName = adminutil.KafkaSecretNameFromContext(ctx)
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Initialize The Reconciler
+	r := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		adminClientType: kafkaadmin.Kafka,
+		adminMutex:      &sync.Mutex{},
+		config:          controllertesting.NewConfig(),
+	}
+
+	// Perform The Test
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer, controllertesting.WithLabels)
+	channel.Annotations = map[string]string{kafkaconstants.KafkaSecretAnnotation: "selected-kafka-secret"}
+	_ = r.FinalizeKind(context.TODO(), channel)
+
+	// Verify The Results
+	if scopedKafkaSecretName != controllertesting.KafkaSecretName {
+		t.Errorf("expected Kafka AdminClient scoped to %s but got %q", controllertesting.KafkaSecretName, scopedKafkaSecretName)
+	}
+}
@@ -0,0 +1,136 @@
+package kafkachannel
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+)
+
+//
+// Dispatcher Overrides
+//
+// A KafkaChannel may override the replicas, resources, node selector and tolerations of its Dispatcher Deployment
+// (spec.dispatcher), which are otherwise those of the global Dispatcher configuration in the eventing-kafka ConfigMap.
+// A hash of the overrides is stamped on the Deployment so that it is only updated when the overrides change, leaving
+// the Dispatcher Deployments of KafkaChannels without overrides untouched.
+//
+
+// Apply The KafkaChannel's Dispatcher Overrides (If Any) On Top Of The Global Configuration Of The Deployment
+func applyDispatcherOverrides(deployment *appsv1.Deployment, channel *kafkav1beta1.KafkaChannel) {
+
+	// Nothing To Apply If The KafkaChannel Has No Dispatcher Overrides
+	overrides := channel.Spec.Dispatcher
+	if overrides == nil {
+		return
+	}
+
+	// Override The Replicas
+	if overrides.Replicas != nil {
+		replicas := *overrides.Replicas
+		deployment.Spec.Replicas = &replicas
+	}
+
+	// Override The Individual Resource Limits / Requests Of The Dispatcher Container
+	if overrides.Resources != nil && len(deployment.Spec.Template.Spec.Containers) > 0 {
+		resources := &deployment.Spec.Template.Spec.Containers[0].Resources
+		resources.Limits = mergeResourceList(resources.Limits, overrides.Resources.Limits)
+		resources.Requests = mergeResourceList(resources.Requests, overrides.Resources.Requests)
+	}
+
+	// Override The Node Selector & Tolerations (No Global Configuration To Merge With)
+	if len(overrides.NodeSelector) > 0 {
+		nodeSelector := make(map[string]string, len(overrides.NodeSelector))
+		for key, value := range overrides.NodeSelector {
+			nodeSelector[key] = value
+		}
+		deployment.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+	if len(overrides.Tolerations) > 0 {
+		tolerations := make([]corev1.Toleration, len(overrides.Tolerations))
+		for i := range overrides.Tolerations {
+			overrides.Tolerations[i].DeepCopyInto(&tolerations[i])
+		}
+		deployment.Spec.Template.Spec.Tolerations = tolerations
+	}
+}
+
+// Merge The Override ResourceList On Top Of The Base ResourceList (Returns A New ResourceList)
+func mergeResourceList(base corev1.ResourceList, overrides corev1.ResourceList) corev1.ResourceList {
+	merged := make(corev1.ResourceList, len(base)+len(overrides))
+	for name, quantity := range base {
+		merged[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range overrides {
+		merged[name] = quantity.DeepCopy()
+	}
+	return merged
+}
+
+// Generate A Hash Of The KafkaChannel's Dispatcher Overrides (Empty If There Are None)
+func dispatcherOverridesHash(channel *kafkav1beta1.KafkaChannel) string {
+	if channel.Spec.Dispatcher == nil {
+		return ""
+	}
+	overridesJson, err := json.Marshal(channel.Spec.Dispatcher)
+	if err != nil {
+		return "" // Should Never Happen
+	}
+	return util.GenerateHash(string(overridesJson), 32)
+}
+
+// Stamp The Hash Of The KafkaChannel's Dispatcher Overrides (If Any) On The Deployment
+func stampDispatcherOverridesHash(deployment *appsv1.Deployment, overridesHash string) {
+	if len(overridesHash) > 0 {
+		if deployment.Annotations == nil {
+			deployment.Annotations = make(map[string]string)
+		}
+		deployment.Annotations[constants.DispatcherOverridesHashAnnotation] = overridesHash
+	} else {
+		delete(deployment.Annotations, constants.DispatcherOverridesHashAnnotation)
+	}
+}
+
+// Update The Dispatcher Deployment If The KafkaChannel's Dispatcher Overrides Have Changed Since It Was Last Updated
+func (r *Reconciler) reconcileDispatcherOverrides(ctx context.Context, channel *kafkav1beta1.KafkaChannel, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+
+	// Nothing To Do If The Deployment Already Reflects The Current Dispatcher Overrides
+	overridesHash := dispatcherOverridesHash(channel)
+	if deployment.Annotations[constants.DispatcherOverridesHashAnnotation] == overridesHash {
+		return deployment, nil
+	}
+
+	// Reset A Copy Of The Deployment (Don't Modify The Informer's Cache) To The Global Configuration
+	updatedDeployment := deployment.DeepCopy()
+	if r.config.Dispatcher.Autoscaling.MaxReplicas <= 0 {
+		replicas := int32(r.config.Dispatcher.Replicas)
+		updatedDeployment.Spec.Replicas = &replicas
+	}
+	if len(updatedDeployment.Spec.Template.Spec.Containers) > 0 {
+		updatedDeployment.Spec.Template.Spec.Containers[0].Resources = r.dispatcherResources()
+	}
+	updatedDeployment.Spec.Template.Spec.NodeSelector = nil
+	updatedDeployment.Spec.Template.Spec.Tolerations = nil
+
+	// Apply The Current Dispatcher Overrides (Replicas Are Left To The HorizontalPodAutoscaler If Autoscaling)
+	autoscaledReplicas := updatedDeployment.Spec.Replicas
+	applyDispatcherOverrides(updatedDeployment, channel)
+	if r.config.Dispatcher.Autoscaling.MaxReplicas > 0 {
+		updatedDeployment.Spec.Replicas = autoscaledReplicas
+	}
+	stampDispatcherOverridesHash(updatedDeployment, overridesHash)
+
+	// Update The Dispatcher Deployment
+	updatedDeployment, err := r.kubeClientset.AppsV1().Deployments(updatedDeployment.Namespace).Update(ctx, updatedDeployment, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	util.ChannelLogger(r.logger, channel).Info("Dispatcher Overrides Changed - Updated Dispatcher Deployment", zap.String("Deployment", deployment.Name))
+	return updatedDeployment, nil
+}
@@ -0,0 +1,164 @@
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/fake"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+)
+
+// Test The Application Of A KafkaChannel's Dispatcher Overrides On Top Of The Global Configuration
+func TestApplyDispatcherOverrides(t *testing.T) {
+
+	// A KafkaChannel Without Overrides Leaves The Deployment Unchanged
+	deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	applyDispatcherOverrides(deployment, controllertesting.NewKafkaChannel())
+	assert.Equal(t, controllertesting.NewKafkaChannelDispatcherDeployment(), deployment)
+
+	// A KafkaChannel With Overrides Replaces Only The Overridden Values
+	channel := controllertesting.NewKafkaChannel()
+	channel.Spec.Dispatcher = newTestDispatcherOverrides()
+	applyDispatcherOverrides(deployment, channel)
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Equal(t, resource.MustParse("2"), podSpec.Containers[0].Resources.Limits[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse(controllertesting.DispatcherMemoryLimit), podSpec.Containers[0].Resources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse(controllertesting.DispatcherCpuRequest), podSpec.Containers[0].Resources.Requests[corev1.ResourceCPU])
+	assert.Equal(t, map[string]string{"pool": "high-volume"}, podSpec.NodeSelector)
+	assert.Equal(t, channel.Spec.Dispatcher.Tolerations, podSpec.Tolerations)
+}
+
+// Test The Hash Of A KafkaChannel's Dispatcher Overrides
+func TestDispatcherOverridesHash(t *testing.T) {
+	channel := controllertesting.NewKafkaChannel()
+	assert.Empty(t, dispatcherOverridesHash(channel))
+
+	channel.Spec.Dispatcher = newTestDispatcherOverrides()
+	hash := dispatcherOverridesHash(channel)
+	assert.Len(t, hash, 32)
+	assert.Equal(t, hash, dispatcherOverridesHash(channel.DeepCopy()))
+
+	channel.Spec.Dispatcher.Replicas = ptr.Int32(4)
+	assert.NotEqual(t, hash, dispatcherOverridesHash(channel))
+}
+
+// Test The Update Of The Dispatcher Deployment When The KafkaChannel's Dispatcher Overrides Change
+func TestReconcileDispatcherOverrides(t *testing.T) {
+
+	// Test Data - The Dispatcher Deployment Of A KafkaChannel Whose Overrides Have Been Removed
+	overriddenChannel := controllertesting.NewKafkaChannel()
+	overriddenChannel.Spec.Dispatcher = newTestDispatcherOverrides()
+	staleDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	applyDispatcherOverrides(staleDeployment, overriddenChannel)
+	stampDispatcherOverridesHash(staleDeployment, dispatcherOverridesHash(overriddenChannel))
+
+	// Define The Test Cases
+	tests := []struct {
+		name          string
+		channel       *kafkav1beta1.KafkaChannel
+		deployment    *appsv1.Deployment
+		autoscaling   bool
+		wantUpdate    bool
+		wantReplicas  int32
+		wantOverrides bool
+	}{
+		{
+			name:         "No Overrides",
+			channel:      controllertesting.NewKafkaChannel(),
+			deployment:   controllertesting.NewKafkaChannelDispatcherDeployment(),
+			wantReplicas: controllertesting.DispatcherReplicas,
+		},
+		{
+			name:          "Unchanged Overrides",
+			channel:       overriddenChannel,
+			deployment:    staleDeployment,
+			wantReplicas:  3,
+			wantOverrides: true,
+		},
+		{
+			name:          "Added Overrides",
+			channel:       overriddenChannel,
+			deployment:    controllertesting.NewKafkaChannelDispatcherDeployment(),
+			wantUpdate:    true,
+			wantReplicas:  3,
+			wantOverrides: true,
+		},
+		{
+			name:          "Added Overrides With Autoscaling",
+			channel:       overriddenChannel,
+			deployment:    controllertesting.NewKafkaChannelDispatcherDeployment(),
+			autoscaling:   true,
+			wantUpdate:    true,
+			wantReplicas:  controllertesting.DispatcherReplicas,
+			wantOverrides: true,
+		},
+		{
+			name:         "Removed Overrides",
+			channel:      controllertesting.NewKafkaChannel(),
+			deployment:   staleDeployment,
+			wantUpdate:   true,
+			wantReplicas: controllertesting.DispatcherReplicas,
+		},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Initialize The Reconciler
+			kubeClientset := fake.NewSimpleClientset(test.deployment)
+			r := &Reconciler{
+				logger:        logtesting.TestLogger(t).Desugar(),
+				kubeClientset: kubeClientset,
+				config:        controllertesting.NewConfig(),
+			}
+			if test.autoscaling {
+				r.config.Dispatcher.Autoscaling.MinReplicas = 1
+				r.config.Dispatcher.Autoscaling.MaxReplicas = 5
+			}
+
+			// Perform The Test
+			deployment, err := r.reconcileDispatcherOverrides(context.TODO(), test.channel, test.deployment)
+
+			// Verify The Results
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantUpdate, len(kubeClientset.Actions()) > 0)
+			assert.Equal(t, test.wantReplicas, *deployment.Spec.Replicas)
+			podSpec := deployment.Spec.Template.Spec
+			if test.wantOverrides {
+				assert.Equal(t, dispatcherOverridesHash(test.channel), deployment.Annotations[constants.DispatcherOverridesHashAnnotation])
+				assert.Equal(t, resource.MustParse("2"), podSpec.Containers[0].Resources.Limits[corev1.ResourceCPU])
+				assert.Equal(t, map[string]string{"pool": "high-volume"}, podSpec.NodeSelector)
+				assert.Len(t, podSpec.Tolerations, 1)
+			} else {
+				assert.NotContains(t, deployment.Annotations, constants.DispatcherOverridesHashAnnotation)
+				assert.Equal(t, r.dispatcherResources(), podSpec.Containers[0].Resources)
+				assert.Nil(t, podSpec.NodeSelector)
+				assert.Nil(t, podSpec.Tolerations)
+			}
+		})
+	}
+}
+
+// Utility Function For Creating Test Dispatcher Overrides
+func newTestDispatcherOverrides() *kafkav1beta1.KafkaChannelDispatcher {
+	return &kafkav1beta1.KafkaChannelDispatcher{
+		Replicas: ptr.Int32(3),
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+		NodeSelector: map[string]string{"pool": "high-volume"},
+		Tolerations: []corev1.Toleration{
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "high-volume", Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+}
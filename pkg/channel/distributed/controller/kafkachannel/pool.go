@@ -0,0 +1,299 @@
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/scaling"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+)
+
+//
+// Dispatcher Pools
+//
+// KafkaChannels labelled with a dispatcher pool (eventing-kafka.knative.dev/dispatcher-pool) share a single Dispatcher
+// Deployment / Service per pool and Kafka Secret instead of having a dedicated Dispatcher.  The pooled Dispatcher
+// watches the KafkaChannels matching its pool selector and consumes the Topic of each of them.  The pool's Deployment
+// & Service are owned by the Kafka Secret, and are deleted once no KafkaChannel uses the pool any longer.
+//
+
+// Reconcile The Pooled Dispatcher Of The Specified KafkaChannel
+func (r *Reconciler) reconcilePooledDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel, poolName string) error {
+
+	// Get Channel Specific Logger
+	logger := util.ChannelLogger(r.logger, channel).With(zap.String("DispatcherPool", poolName))
+
+	// Get The Kafka Secret Owning The Dispatcher Pool's Resources
+	kafkaSecretName := r.kafkaSecretName(channel)
+	kafkaSecret, err := r.kubeClientset.CoreV1().Secrets(commonconstants.KnativeEventingNamespace).Get(ctx, kafkaSecretName, metav1.GetOptions{})
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Get Kafka Secret Of Dispatcher Pool %q: %v", poolName, err)
+		logger.Error("Failed To Get Kafka Secret Of Dispatcher Pool", zap.String("Secret", kafkaSecretName), zap.Error(err))
+		channel.Status.MarkDispatcherUnknown(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Get Kafka Secret Of Dispatcher Pool: %v", err)
+		return fmt.Errorf("failed to reconcile dispatcher resources")
+	}
+
+	// Reconcile The Dispatcher Pool's Service (For Prometheus Only)
+	serviceErr := r.reconcileDispatcherPoolService(ctx, poolName, kafkaSecret)
+	if serviceErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherServiceReconciliationFailed.String(), "Failed To Reconcile Dispatcher Pool Service: %v", serviceErr)
+		logger.Error("Failed To Reconcile Dispatcher Pool Service", zap.Error(serviceErr))
+	} else {
+		logger.Info("Successfully Reconciled Dispatcher Pool Service")
+	}
+
+	// Reconcile The Dispatcher Pool's Deployment
+	deploymentErr := r.reconcileDispatcherPoolDeployment(ctx, channel, poolName, kafkaSecret)
+	if deploymentErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Pool Deployment: %v", deploymentErr)
+		logger.Error("Failed To Reconcile Dispatcher Pool Deployment", zap.Error(deploymentErr))
+	} else {
+		logger.Info("Successfully Reconciled Dispatcher Pool Deployment")
+	}
+
+	// Reconcile The Dispatcher Pool Deployment's HorizontalPodAutoscaler & PodDisruptionBudget
+	deploymentName := util.DispatcherPoolDnsSafeName(poolName, kafkaSecret.Name)
+	scalingErr := scaling.ReconcileDeploymentScaling(ctx, logger, r.kubeClientset, deploymentName, util.NewSecretOwnerReference(kafkaSecret), &r.config.Dispatcher.EKKubernetesConfig)
+	if scalingErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherScalingReconciliationFailed.String(), "Failed To Reconcile Dispatcher Pool Scaling: %v", scalingErr)
+		logger.Error("Failed To Reconcile Dispatcher Pool Scaling", zap.Error(scalingErr))
+	} else {
+		logger.Info("Successfully Reconciled Dispatcher Pool Scaling")
+	}
+
+	// Delete The KafkaChannel's Dedicated Dispatcher (If It Was Dispatched On Its Own Before Joining The Pool)
+	dedicatedErr := r.deleteDedicatedDispatcher(ctx, channel)
+	if dedicatedErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Dedicated Dispatcher: %v", dedicatedErr)
+		logger.Error("Failed To Delete Dedicated Dispatcher", zap.Error(dedicatedErr))
+	}
+
+	// Return Results
+	if serviceErr != nil || deploymentErr != nil || scalingErr != nil || dedicatedErr != nil {
+		return fmt.Errorf("failed to reconcile dispatcher resources")
+	} else {
+		return nil
+	}
+}
+
+// Reconcile The Dispatcher Pool Service
+func (r *Reconciler) reconcileDispatcherPoolService(ctx context.Context, poolName string, kafkaSecret *corev1.Secret) error {
+
+	// Attempt To Get The Dispatcher Pool Service, Creating It If Not Found
+	serviceName := util.DispatcherPoolDnsSafeName(poolName, kafkaSecret.Name)
+	_, err := r.serviceLister.Services(commonconstants.KnativeEventingNamespace).Get(serviceName)
+	if errors.IsNotFound(err) {
+		r.logger.Info("Dispatcher Pool Service Not Found - Creating New One", zap.String("Service", serviceName))
+		service := r.newDispatcherServiceModel(serviceName, dispatcherPoolLabels(poolName, kafkaSecret, map[string]string{
+			constants.K8sAppDispatcherSelectorLabel: constants.K8sAppDispatcherSelectorValue, // Prometheus ServiceMonitor
+		}), util.NewSecretOwnerReference(kafkaSecret))
+		_, err = r.kubeClientset.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
+	}
+	return err
+}
+
+// Reconcile The Dispatcher Pool Deployment, Propagating Its Status To The Specified KafkaChannel
+func (r *Reconciler) reconcileDispatcherPoolDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel, poolName string, kafkaSecret *corev1.Secret) error {
+
+	// Attempt To Get The Dispatcher Pool Deployment
+	deploymentName := util.DispatcherPoolDnsSafeName(poolName, kafkaSecret.Name)
+	deployment, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(deploymentName)
+	if errors.IsNotFound(err) {
+
+		// Create The Dispatcher Pool Deployment If Not Found
+		r.logger.Info("Dispatcher Pool Deployment Not Found - Creating New One", zap.String("Deployment", deploymentName))
+		deployment = r.newDispatcherPoolDeployment(poolName, kafkaSecret)
+		deployment, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+		if err != nil {
+			channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Create Dispatcher Pool Deployment: %v", err)
+			return err
+		}
+	} else if err != nil {
+		channel.Status.MarkDispatcherUnknown(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Get Dispatcher Pool Deployment: %v", err)
+		return err
+	}
+
+	// The KafkaChannel Is Dispatched By The Dispatcher Pool Deployment
+	channel.Status.PropagateDispatcherStatus(&deployment.Status)
+	return nil
+}
+
+// Create The Dispatcher Pool Deployment Model For The Specified Pool & Kafka Secret
+func (r *Reconciler) newDispatcherPoolDeployment(poolName string, kafkaSecret *corev1.Secret) *appsv1.Deployment {
+
+	// Get The Dispatcher Pool Deployment Name
+	deploymentName := util.DispatcherPoolDnsSafeName(poolName, kafkaSecret.Name)
+
+	// The Pooled Dispatcher Watches The KafkaChannels Of The Pool Using The Kafka Secret
+	poolSelector := labels.SelectorFromSet(map[string]string{
+		commonconstants.DispatcherPoolLabel: poolName,
+		constants.KafkaSecretLabel:          commonk8s.TruncateLabelValue(kafkaSecret.Name),
+	})
+	envVars := append(r.dispatcherBaseEnvVars(),
+		corev1.EnvVar{
+			Name:  commonenv.ServiceNameEnvVarKey,
+			Value: deploymentName,
+		},
+		corev1.EnvVar{
+			Name:  commonenv.DispatcherPoolSelectorEnvVarKey,
+			Value: poolSelector.String(),
+		},
+	)
+	envVars = append(envVars, dispatcherKafkaSecretEnvVars(kafkaSecret.Name)...)
+
+	// Stamp The Kafka Secret Data Hash So Secret Changes Roll The Dispatcher Pool Pods
+	podAnnotations := map[string]string{
+		constants.KafkaSecretHashAnnotation: util.SecretDataHash(kafkaSecret),
+	}
+
+	// Create & Return The Dispatcher Pool Deployment Model
+	return r.newDispatcherDeploymentModel(deploymentName, dispatcherPoolLabels(poolName, kafkaSecret, map[string]string{
+		constants.AppLabel: deploymentName, // Matches K8S Service Selector Key/Value
+	}), util.NewSecretOwnerReference(kafkaSecret), podAnnotations, envVars)
+}
+
+// Create The Labels Of The Dispatcher Pool Resources (Including The Specified Additional Labels)
+func dispatcherPoolLabels(poolName string, kafkaSecret *corev1.Secret, additionalLabels map[string]string) map[string]string {
+	poolLabels := map[string]string{
+		constants.KafkaChannelDispatcherLabel:     "true",                                         // Identifies the Resource as being a KafkaChannel "Dispatcher"
+		constants.KafkaChannelDispatcherPoolLabel: poolName,                                       // Identifies the Resource's Dispatcher Pool
+		constants.KafkaSecretLabel:                commonk8s.TruncateLabelValue(kafkaSecret.Name), // Identifies the Resource's Kafka Secret
+	}
+	for key, value := range additionalLabels {
+		poolLabels[key] = value
+	}
+	return poolLabels
+}
+
+// Create An EventHandler Enqueuing The KafkaChannels Of The Dispatcher Pool Of A Deployment (To Propagate Its Status)
+func (r *Reconciler) enqueueDispatcherPoolChannels(enqueue func(obj interface{})) func(obj interface{}) {
+	return func(obj interface{}) {
+		deployment, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		poolName := deployment.GetLabels()[constants.KafkaChannelDispatcherPoolLabel]
+		channels, err := r.kafkachannelLister.List(labels.SelectorFromSet(map[string]string{commonconstants.DispatcherPoolLabel: poolName}))
+		if err != nil {
+			r.logger.Error("Failed To List KafkaChannels Of Dispatcher Pool", zap.String("DispatcherPool", poolName), zap.Error(err))
+			return
+		}
+		for _, channel := range channels {
+			enqueue(channel)
+		}
+	}
+}
+
+// Delete The Dedicated Dispatcher Deployment, Service, HorizontalPodAutoscaler & PodDisruptionBudget Of The KafkaChannel
+func (r *Reconciler) deleteDedicatedDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Delete The Dedicated Dispatcher Deployment & Service (If Any)
+	dispatcherName := util.DispatcherDnsSafeName(channel)
+	err := r.deleteDispatcherDeploymentAndService(ctx, dispatcherName)
+	if err != nil {
+		return err
+	}
+
+	// Delete The Dedicated Dispatcher's HorizontalPodAutoscaler & PodDisruptionBudget (Disabled Scaling Deletes Them)
+	return scaling.ReconcileDeploymentScaling(ctx, r.logger, r.kubeClientset, dispatcherName, util.NewChannelOwnerReference(channel), &config.EKKubernetesConfig{})
+}
+
+// Delete The Dispatcher Deployment & Service With The Specified Name (If They Exist)
+func (r *Reconciler) deleteDispatcherDeploymentAndService(ctx context.Context, name string) error {
+
+	// Delete The Dispatcher Deployment If It Exists
+	_, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(name)
+	if err == nil {
+		r.logger.Info("Deleting Dispatcher Deployment", zap.String("Deployment", name))
+		err = r.kubeClientset.AppsV1().Deployments(commonconstants.KnativeEventingNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	// Delete The Dispatcher Service If It Exists
+	_, err = r.serviceLister.Services(commonconstants.KnativeEventingNamespace).Get(name)
+	if err == nil {
+		r.logger.Info("Deleting Dispatcher Service", zap.String("Service", name))
+		err = r.kubeClientset.CoreV1().Services(commonconstants.KnativeEventingNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Delete The Dispatcher Pools No Longer Used By Any KafkaChannel (Errors Are Logged Only)
+func (r *Reconciler) cleanupDispatcherPools(ctx context.Context) {
+
+	// Create Selector With Requirement For The Dispatcher Pool Label Of The Deployments
+	requirement, err := labels.NewRequirement(constants.KafkaChannelDispatcherPoolLabel, selection.Exists, nil)
+	if err != nil {
+		r.logger.Error("Failed To Create Selector Requirement For Dispatcher Pool Label", zap.Error(err)) // Should Never Happen
+		return
+	}
+
+	// List The Dispatcher Pool Deployments
+	deployments, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).List(labels.NewSelector().Add(*requirement))
+	if err != nil {
+		r.logger.Error("Failed To List Dispatcher Pool Deployments", zap.Error(err))
+		return
+	}
+
+	// Delete Each Dispatcher Pool Deployment (And Its Service & Scaling) Which No KafkaChannel Uses
+	for _, deployment := range deployments {
+		poolName := deployment.Labels[constants.KafkaChannelDispatcherPoolLabel]
+		if r.isDispatcherPoolUsed(poolName, deployment.Labels[constants.KafkaSecretLabel]) {
+			continue
+		}
+		logger := r.logger.With(zap.String("DispatcherPool", poolName), zap.String("Deployment", deployment.Name))
+		logger.Info("Dispatcher Pool No Longer Used - Deleting")
+		err = r.deleteDispatcherDeploymentAndService(ctx, deployment.Name)
+		if err == nil {
+			if ownerReference := metav1.GetControllerOf(deployment); ownerReference != nil {
+				err = scaling.ReconcileDeploymentScaling(ctx, logger, r.kubeClientset, deployment.Name, *ownerReference, &config.EKKubernetesConfig{})
+			}
+		}
+		if err != nil {
+			logger.Error("Failed To Delete Unused Dispatcher Pool", zap.Error(err))
+		}
+	}
+}
+
+// Determine Whether Any (Non-Deleted) KafkaChannel Uses The Specified Dispatcher Pool & Kafka Secret (Label Value)
+func (r *Reconciler) isDispatcherPoolUsed(poolName string, safeSecretName string) bool {
+
+	// List The KafkaChannels Of The Dispatcher Pool (All Namespaces) - Assume Used If They Cannot Be Listed
+	channels, err := r.kafkachannelLister.List(labels.SelectorFromSet(map[string]string{commonconstants.DispatcherPoolLabel: poolName}))
+	if err != nil {
+		r.logger.Error("Failed To List KafkaChannels Of Dispatcher Pool", zap.String("DispatcherPool", poolName), zap.Error(err))
+		return true
+	}
+
+	// KafkaChannels Not Yet Labelled With Their Kafka Secret Might Still Be Using The Pool
+	for _, channel := range channels {
+		if channel != nil && channel.DeletionTimestamp == nil {
+			channelSecretName := channel.Labels[constants.KafkaSecretLabel]
+			if len(channelSecretName) == 0 || channelSecretName == safeSecretName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const testPoolName = "low-traffic"
+
+// Test The Reconciliation Of A Pooled KafkaChannel's Dispatcher
+func TestReconcilePooledDispatcher(t *testing.T) {
+
+	// Test Data - A Pooled KafkaChannel Which Previously Had A Dedicated Dispatcher
+	channel := newPooledKafkaChannel()
+	kafkaSecret := controllertesting.NewKafkaSecret()
+	dedicatedDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	dedicatedService := controllertesting.NewKafkaChannelDispatcherService()
+	r, kubeClientset := newPoolTestReconciler(t, channel, kafkaSecret, dedicatedDeployment, dedicatedService)
+	ctx := controller.WithEventRecorder(context.TODO(), record.NewFakeRecorder(10))
+
+	// Perform The Test
+	err := r.reconcileDispatcher(ctx, channel)
+
+	// Verify The Dispatcher Pool Deployment & Service Were Created For The Pool & Kafka Secret
+	assert.Nil(t, err)
+	poolName := util.DispatcherPoolDnsSafeName(testPoolName, kafkaSecret.Name)
+	deployment, err := kubeClientset.AppsV1().Deployments(commonconstants.KnativeEventingNamespace).Get(ctx, poolName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, testPoolName, deployment.Labels[constants.KafkaChannelDispatcherPoolLabel])
+	assert.Equal(t, kafkaSecret.Name, deployment.Labels[constants.KafkaSecretLabel])
+	assert.Equal(t, []metav1.OwnerReference{util.NewSecretOwnerReference(kafkaSecret)}, deployment.OwnerReferences)
+	assert.Equal(t, util.SecretDataHash(kafkaSecret), deployment.Spec.Template.Annotations[constants.KafkaSecretHashAnnotation])
+	envVars := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, envVars, corev1.EnvVar{
+		Name:  commonenv.DispatcherPoolSelectorEnvVarKey,
+		Value: commonconstants.DispatcherPoolLabel + "=" + testPoolName + "," + constants.KafkaSecretLabel + "=" + kafkaSecret.Name,
+	})
+	for _, envVar := range envVars {
+		assert.NotEqual(t, commonenv.ChannelKeyEnvVarKey, envVar.Name)
+		assert.NotEqual(t, commonenv.KafkaTopicEnvVarKey, envVar.Name)
+	}
+	service, err := kubeClientset.CoreV1().Services(commonconstants.KnativeEventingNamespace).Get(ctx, poolName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, poolName, service.Spec.Selector[constants.AppLabel])
+
+	// Verify The KafkaChannel's Dedicated Dispatcher Deployment & Service Were Deleted
+	_, err = kubeClientset.AppsV1().Deployments(commonconstants.KnativeEventingNamespace).Get(ctx, dedicatedDeployment.Name, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+	_, err = kubeClientset.CoreV1().Services(commonconstants.KnativeEventingNamespace).Get(ctx, dedicatedService.Name, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// Test The Deletion Of Dispatcher Pools No Longer Used By Any KafkaChannel
+func TestCleanupDispatcherPools(t *testing.T) {
+
+	// Test Data
+	kafkaSecret := controllertesting.NewKafkaSecret()
+	deletedChannel := newPooledKafkaChannel()
+	deletedChannel.DeletionTimestamp = &metav1.Time{}
+	unlabelledChannel := newPooledKafkaChannel()
+	delete(unlabelledChannel.Labels, constants.KafkaSecretLabel)
+	otherSecretChannel := newPooledKafkaChannel()
+	otherSecretChannel.Labels[constants.KafkaSecretLabel] = "other-secret"
+
+	// Define The Test Cases
+	tests := []struct {
+		name       string
+		channels   []runtime.Object
+		wantDelete bool
+	}{
+		{name: "Used Pool", channels: []runtime.Object{newPooledKafkaChannel()}},
+		{name: "KafkaChannel Not Yet Labelled With Kafka Secret", channels: []runtime.Object{unlabelledChannel}},
+		{name: "No KafkaChannels", wantDelete: true},
+		{name: "Deleted KafkaChannel", channels: []runtime.Object{deletedChannel}, wantDelete: true},
+		{name: "KafkaChannel Of Other Kafka Secret", channels: []runtime.Object{otherSecretChannel}, wantDelete: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Initialize The Reconciler With The Dispatcher Pool's Deployment & Service
+			r := &Reconciler{environment: controllertesting.NewEnvironment(), config: controllertesting.NewConfig()}
+			poolDeployment := r.newDispatcherPoolDeployment(testPoolName, kafkaSecret)
+			poolService := r.newDispatcherServiceModel(poolDeployment.Name, poolDeployment.Labels, util.NewSecretOwnerReference(kafkaSecret))
+			r, kubeClientset := newPoolTestReconciler(t, append(test.channels, poolDeployment, poolService)...)
+
+			// Perform The Test
+			r.cleanupDispatcherPools(context.TODO())
+
+			// Verify The Results
+			_, deploymentErr := kubeClientset.AppsV1().Deployments(commonconstants.KnativeEventingNamespace).Get(context.TODO(), poolDeployment.Name, metav1.GetOptions{})
+			_, serviceErr := kubeClientset.CoreV1().Services(commonconstants.KnativeEventingNamespace).Get(context.TODO(), poolService.Name, metav1.GetOptions{})
+			assert.Equal(t, test.wantDelete, errors.IsNotFound(deploymentErr))
+			assert.Equal(t, test.wantDelete, errors.IsNotFound(serviceErr))
+		})
+	}
+}
+
+// Utility Function For Creating A KafkaChannel Of The Test Dispatcher Pool
+func newPooledKafkaChannel() *kafkav1beta1.KafkaChannel {
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithLabels)
+	channel.Labels[commonconstants.DispatcherPoolLabel] = testPoolName
+	return channel
+}
+
+// Utility Function For Creating A Reconciler (And Its Fake Clientset) With The Specified K8S & KafkaChannel Objects
+func newPoolTestReconciler(t *testing.T, objects ...runtime.Object) (*Reconciler, *fake.Clientset) {
+	listers := controllertesting.NewListers(objects)
+	kubeClientset := fake.NewSimpleClientset(listers.GetKubeObjects()...)
+	return &Reconciler{
+		logger:             logtesting.TestLogger(t).Desugar(),
+		kubeClientset:      kubeClientset,
+		adminClient:        &controllertesting.MockAdminClient{},
+		environment:        controllertesting.NewEnvironment(),
+		config:             controllertesting.NewConfig(),
+		kafkachannelLister: listers.GetKafkaChannelLister(),
+		deploymentLister:   listers.GetDeploymentLister(),
+		serviceLister:      listers.GetServiceLister(),
+	}, kubeClientset
+}
@@ -14,6 +14,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
@@ -25,6 +26,7 @@ import (
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 )
 
 // Reconciler Implements controller.Reconciler for KafkaChannel Resources
@@ -43,6 +45,7 @@ type Reconciler struct {
 	serviceLister        corev1listers.ServiceLister
 	configObserver       func(configMap *corev1.ConfigMap)
 	adminMutex           *sync.Mutex
+	uriResolver          *resolver.URIResolver
 }
 
 var (
@@ -91,6 +94,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 	// Add The K8S ClientSet To The Reconcile Context
 	ctx = context.WithValue(ctx, kubeclient.Key{}, r.kubeClientset)
 
+	// Scope The Kafka AdminClient To The Kafka Secret (Cluster) Selected By The Channel, If Any
+	ctx = withSelectedKafkaSecret(ctx, channel)
+
 	// Don't let another goroutine clear out the admin client while we're using it in this one
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
@@ -116,14 +122,22 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 	return reconciler.NewEvent(corev1.EventTypeNormal, event.KafkaChannelReconciled.String(), "KafkaChannel Reconciled Successfully: \"%s/%s\"", channel.Namespace, channel.Name)
 }
 
-// ReconcileKind Implements The Finalizer Interface & Is Responsible For Performing The Finalization (Topic Deletion)
+// FinalizeKind Implements The Finalizer Interface & Is Responsible For Performing The Finalization (ConsumerGroup & Topic Deletion)
 func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.KafkaChannel) reconciler.Event {
 
 	r.logger.Debug("<==========  START KAFKA-CHANNEL FINALIZATION  ==========>")
 
+	// Delete The Dispatcher Pool Of The KafkaChannel If No Other KafkaChannel Uses It
+	if len(channel.Labels[commonconstants.DispatcherPoolLabel]) > 0 {
+		r.cleanupDispatcherPools(ctx)
+	}
+
 	// Add The K8S ClientSet To The Reconcile Context
 	ctx = context.WithValue(ctx, kubeclient.Key{}, r.kubeClientset)
 
+	// Scope The Kafka AdminClient To The Kafka Secret (Cluster) In Which The Channel's Topic Was Created
+	ctx = withReconciledKafkaSecret(ctx, channel)
+
 	// Don't let another goroutine clear out the admin client while we're using it in this one
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
@@ -132,11 +146,32 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 	r.SetKafkaAdminClient(ctx)
 	defer r.ClearKafkaAdminClient()
 
+	// Delete The ConsumerGroups Of The Channel's Subscribers (Orphaned Whether Or Not The Topic Is Retained)
+	err := r.deleteConsumerGroups(ctx, channel)
+	if err != nil {
+		r.logger.Info("Unable To Finalize KafkaChannel Yet", zap.Any("Channel", channel), zap.Error(err))
+		return err
+	}
+
 	// Get The Kafka Topic Name For Specified Channel
 	topicName := util.TopicName(channel)
 
-	// Delete The Kafka Topic & Handle Error Response
-	err := r.deleteTopic(ctx, topicName)
+	// Retain The Kafka Topic (And Its Retry Topics) If So Requested By The Channel Or The Default Policy
+	defaultDeletionPolicy := kafkav1beta1.TopicDeletionPolicy(r.config.Kafka.Topic.DefaultDeletionPolicy)
+	if channel.TopicDeletionPolicyOrDefault(defaultDeletionPolicy) == kafkav1beta1.TopicDeletionPolicyRetain {
+		r.logger.Info("Retaining Kafka Topic Of Deleted KafkaChannel", zap.String("Topic", topicName))
+		return reconciler.NewEvent(corev1.EventTypeNormal, event.KafkaTopicRetained.String(), "Kafka Topic %q Retained For KafkaChannel: \"%s/%s\"", topicName, channel.Namespace, channel.Name)
+	}
+
+	// Delete The Kafka Retry Topics & Topic (Preserving Adopted Topics) & Handle Error Response
+	err = r.deleteRetryTopics(ctx, topicName)
+	if err == nil {
+		if channel.AdoptedTopicName() != "" {
+			r.logger.Info("Preserving Adopted Kafka Topic", zap.String("Topic", topicName))
+		} else {
+			err = r.deleteTopic(ctx, topicName)
+		}
+	}
 	if err != nil {
 		r.logger.Error("Failed To Finalize KafkaChannel", zap.Any("Channel", channel), zap.Error(err))
 		return err
@@ -153,8 +188,14 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 	// NOTE - The sequential order of reconciliation must be "Topic" then "Channel / Dispatcher" in order for the
 	//        EventHub Cache to know the dynamically determined EventHub Namespace / Kafka Secret selected for the topic.
 
+	// Verify The Kafka Secret (Cluster) Selected By The KafkaChannel, If Any
+	err := r.reconcileKafkaSecretSelection(ctx, channel)
+	if err != nil {
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
 	// Reconcile The KafkaChannel's Kafka Topic
-	err := r.reconcileTopic(ctx, channel)
+	err = r.reconcileTopic(ctx, channel)
 	if err != nil {
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
@@ -186,6 +227,12 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
+	// Resolve The KafkaChannel's Delivery DeadLetterSink
+	err = r.reconcileDeadLetterSink(ctx, channel)
+	if err != nil {
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
 	// Return Success
 	return nil
 }
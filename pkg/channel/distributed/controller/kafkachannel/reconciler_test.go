@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
@@ -18,12 +19,14 @@ import (
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/resolver"
 )
 
 // Initialization - Add types to scheme
@@ -132,6 +135,7 @@ func TestReconcile(t *testing.T) {
 			SkipNamespaceValidation: true,
 			Key:                     controllertesting.KafkaChannelKey,
 			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
 				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions),
 			},
 			WantCreates: []runtime.Object{
@@ -170,6 +174,53 @@ func TestReconcile(t *testing.T) {
 			},
 		},
 
+		{
+			Name:                    "Complete Reconciliation Success With Delivery DeadLetterSink",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
+				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions, controllertesting.WithDelivery),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithDelivery,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+						controllertesting.WithDeadLetterSinkURI,
+					),
+				},
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				controllertesting.NewKafkaChannelLabelUpdate(
+					controllertesting.NewKafkaChannel(
+						controllertesting.WithDelivery,
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaChannelFinalizerUpdateEvent(),
+				controllertesting.NewKafkaChannelSuccessfulReconciliationEvent(),
+			},
+		},
+
 		//
 		// KafkaChannel Deletion (Finalizer)
 		//
@@ -320,6 +371,7 @@ func TestReconcile(t *testing.T) {
 			SkipNamespaceValidation: true,
 			Key:                     controllertesting.KafkaChannelKey,
 			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
 				controllertesting.NewKafkaChannel(
 					controllertesting.WithFinalizer,
 					controllertesting.WithMetaData,
@@ -344,6 +396,7 @@ func TestReconcile(t *testing.T) {
 			SkipNamespaceValidation: true,
 			Key:                     controllertesting.KafkaChannelKey,
 			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
 				controllertesting.NewKafkaChannel(
 					controllertesting.WithFinalizer,
 					controllertesting.WithMetaData,
@@ -397,6 +450,7 @@ func TestReconcile(t *testing.T) {
 	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
 	logger := logtesting.TestLogger(t)
 	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = addressable.WithDuck(ctx)
 		r := &Reconciler{
 			logger:               logging.FromContext(ctx).Desugar(),
 			kubeClientset:        kubeclient.Get(ctx),
@@ -410,6 +464,7 @@ func TestReconcile(t *testing.T) {
 			serviceLister:        listers.GetServiceLister(),
 			kafkaClientSet:       fakekafkaclient.Get(ctx),
 			adminMutex:           &sync.Mutex{},
+			uriResolver:          resolver.NewURIResolver(ctx, func(types.NamespacedName) {}),
 		}
 		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
 	}, logger.Desugar()))
@@ -9,6 +9,8 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonkafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
@@ -29,8 +31,23 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *kafkav1beta1.K
 	replicationFactor := util.ReplicationFactor(channel, r.config, r.logger)
 	retentionMillis := util.RetentionMillis(channel, r.config, r.logger)
 
-	// Create The Topic (Handles Case Where Already Exists)
-	err := r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	// Verify The Adopted Topic Or Create The Topic (Handles Case Where Already Exists)
+	var err error
+	if channel.AdoptedTopicName() != "" {
+		err = r.verifyTopic(ctx, topicName)
+	} else {
+		err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	}
+
+	// Create The Retry Topics (If Any) With The Same Configuration
+	if err == nil {
+		for tier := 1; tier <= commonkafkautil.RetryTopicCount(channel.Annotations); tier++ {
+			err = r.createTopic(ctx, commonkafkautil.RetryTopicName(topicName, tier), numPartitions, replicationFactor, retentionMillis)
+			if err != nil {
+				break
+			}
+		}
+	}
 
 	// Log Results & Return Status
 	if err != nil {
@@ -81,6 +98,33 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 	}
 }
 
+// Verify The Existence Of The Specified Kafka Topic Adopted By A Channel (Adopted Topics Are Never Created Or Deleted)
+func (r *Reconciler) verifyTopic(ctx context.Context, topicName string) error {
+
+	// Setup The Logger
+	logger := r.logger.With(zap.String("Topic", topicName))
+
+	// Attempt To Describe The Topic & Process TopicError Results (Including Success ;)
+	_, err := r.adminClient.DescribeTopic(ctx, topicName)
+	if err != nil {
+		switch err.Err {
+		case sarama.ErrNoError:
+			logger.Info("Successfully Verified Adopted Kafka Topic (ErrNoError)")
+			return nil
+		case sarama.ErrUnsupportedVersion:
+			// The AdminClient Is Unable To Describe Topics (EventHub) So The Adopted Topic Is Assumed To Exist
+			logger.Warn("Unable To Verify Adopted Kafka Topic - AdminClient Does Not Support Describing Topics")
+			return nil
+		default:
+			logger.Error("Failed To Verify Adopted Topic", zap.Any("TopicError", err))
+			return err
+		}
+	} else {
+		logger.Info("Successfully Verified Adopted Kafka Topic (Nil TopicError)")
+		return nil
+	}
+}
+
 // Delete The Specified Kafka Topic
 func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 
@@ -119,3 +163,14 @@ func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 		return nil
 	}
 }
+
+// Delete The Retry Topics Of The Specified Kafka Topic (All Possible Tiers As The Channel's Retry Topic Count May Have Been Reduced)
+func (r *Reconciler) deleteRetryTopics(ctx context.Context, topicName string) error {
+	for tier := 1; tier <= commonkafkaconstants.MaxRetryTopics; tier++ {
+		err := r.deleteTopic(ctx, commonkafkautil.RetryTopicName(topicName, tier))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
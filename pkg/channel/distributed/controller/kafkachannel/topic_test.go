@@ -2,6 +2,8 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/Shopify/sarama"
@@ -10,10 +12,14 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	commonkafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	"knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
+	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
 // Define The Topic TestCase Type
@@ -25,6 +31,8 @@ type TopicTestCase struct {
 	WantError       string
 	WantCreate      bool
 	WantDelete      bool
+	WantDeleteRetry bool
+	WantTopicNames  []string
 }
 
 //
@@ -113,6 +121,31 @@ func TestReconcileTopic(t *testing.T) {
 			MockErrorCode: sarama.ErrBrokerNotAvailable,
 			WantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
 		},
+		{
+			Name: "Create New Topic With Retry Topics",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithChannelServiceReady,
+				controllertesting.WithChannelDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+				controllertesting.WithRetryTopics,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			WantTopicNames: []string{
+				controllertesting.TopicName,
+				controllertesting.TopicName + ".retry.1",
+				controllertesting.TopicName + ".retry.2",
+			},
+		},
 		{
 			Name: "Delete Existing Topic",
 			Channel: controllertesting.NewKafkaChannel(
@@ -158,6 +191,29 @@ func TestReconcileTopic(t *testing.T) {
 			MockErrorCode: sarama.ErrBrokerNotAvailable,
 			WantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
 		},
+		{
+			Name: "Delete Retry Topics",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithChannelServiceReady,
+				controllertesting.WithChannelDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate:      false,
+			WantDelete:      true,
+			WantDeleteRetry: true,
+			MockErrorCode:   sarama.ErrUnknownTopicOrPartition,
+			WantTopicNames: []string{
+				controllertesting.TopicName + ".retry.1",
+				controllertesting.TopicName + ".retry.2",
+				controllertesting.TopicName + ".retry.3",
+				controllertesting.TopicName + ".retry.4",
+				controllertesting.TopicName + ".retry.5",
+			},
+		},
 	}
 
 	// Run All The TopicTestCases
@@ -166,6 +222,192 @@ func TestReconcileTopic(t *testing.T) {
 	}
 }
 
+// Test The Reconciliation Of An Adopted Kafka Topic (Verified Rather Than Created)
+func TestReconcileAdoptedTopic(t *testing.T) {
+
+	// Define The Adopted Topic Test Cases
+	tests := []struct {
+		name          string
+		mockErrorCode sarama.KError
+		wantError     string
+	}{
+		{name: "Existing Topic"},
+		{name: "Nonexistent Topic", mockErrorCode: sarama.ErrUnknownTopicOrPartition, wantError: sarama.ErrUnknownTopicOrPartition.Error() + " - " + controllertesting.ErrorString},
+		{name: "Unsupported Describe", mockErrorCode: sarama.ErrUnsupportedVersion},
+	}
+
+	// Run All The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewBroadcaster().NewRecorder(scheme.Scheme, corev1.EventSource{Component: constants.KafkaChannelControllerAgentName})
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Which Only Describes The Adopted Topic
+			var describedTopicNames []string
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+					t.Errorf("Unexpected CreateTopic() Call For Topic '%s'", topicName)
+					return nil
+				},
+				MockDescribeTopicFunc: func(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+					describedTopicNames = append(describedTopicNames, topicName)
+					if test.mockErrorCode != sarama.ErrNoError {
+						errMsg := controllertesting.ErrorString
+						return nil, &sarama.TopicError{Err: test.mockErrorCode, ErrMsg: &errMsg}
+					}
+					return &sarama.TopicDetail{}, nil
+				},
+			}
+
+			// Initialize The Reconciler
+			r := &Reconciler{
+				logger:      logtesting.TestLogger(t).Desugar(),
+				adminClient: mockAdminClient,
+				config:      controllertesting.NewConfig(),
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer, controllertesting.WithAdoptedTopic)
+			err := r.reconcileTopic(ctx, channel)
+
+			// Verify The Results
+			var errorString string
+			if err != nil {
+				errorString = err.Error()
+			}
+			if diff := cmp.Diff(test.wantError, errorString); diff != "" {
+				t.Errorf("unexpected error (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff([]string{controllertesting.AdoptedTopicName}, describedTopicNames); diff != "" {
+				t.Errorf("unexpected described topic names (-want, +got) = %v", diff)
+			}
+			if mockAdminClient.CreateTopicsCalled() {
+				t.Error("expected CreateTopics() not to be called")
+			}
+		})
+	}
+}
+
+// Test The Finalization Of A KafkaChannel Adopting A Topic (Retry Topics Deleted, Adopted Topic Preserved)
+func TestFinalizeAdoptedTopic(t *testing.T) {
+
+	// Create A Mock Kafka AdminClient Tracking The Deleted Topics
+	var deletedTopicNames []string
+	mockAdminClient := &controllertesting.MockAdminClient{
+		MockDeleteTopicFunc: func(ctx context.Context, topicName string) *sarama.TopicError {
+			deletedTopicNames = append(deletedTopicNames, topicName)
+			return nil
+		},
+	}
+
+	// Mock The Creation Of The Kafka AdminClient
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return mockAdminClient, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Initialize The Reconciler
+	r := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		adminClientType: kafkaadmin.Kafka,
+		adminMutex:      &sync.Mutex{},
+		config:          controllertesting.NewConfig(),
+	}
+
+	// Perform The Test
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer, controllertesting.WithAdoptedTopic)
+	result := r.FinalizeKind(context.TODO(), channel)
+
+	// Verify The Results
+	if !errors.Is(result, pkgreconciler.NewEvent(corev1.EventTypeNormal, event.KafkaChannelFinalized.String(), "")) {
+		t.Errorf("unexpected FinalizeKind() result: %v", result)
+	}
+	for _, topicName := range deletedTopicNames {
+		if topicName == controllertesting.AdoptedTopicName {
+			t.Errorf("unexpected deletion of the adopted topic '%s'", topicName)
+		}
+	}
+	if len(deletedTopicNames) != commonkafkaconstants.MaxRetryTopics {
+		t.Errorf("expected the deletion of %d retry topics, got %v", commonkafkaconstants.MaxRetryTopics, deletedTopicNames)
+	}
+}
+
+// Test The Finalization Of A KafkaChannel Under The Various Topic Deletion Policies
+func TestFinalizeTopicDeletionPolicy(t *testing.T) {
+
+	// Define The Test Cases (Channel Policy Overrides The Configured Default Policy)
+	tests := []struct {
+		name          string
+		defaultPolicy string
+		channelPolicy kafkav1beta1.TopicDeletionPolicy
+		wantRetained  bool
+	}{
+		{name: "Default Delete", wantRetained: false},
+		{name: "Channel Retain", channelPolicy: kafkav1beta1.TopicDeletionPolicyRetain, wantRetained: true},
+		{name: "Configured Retain", defaultPolicy: "Retain", wantRetained: true},
+		{name: "Channel Delete Overrides Configured Retain", defaultPolicy: "Retain", channelPolicy: kafkav1beta1.TopicDeletionPolicyDelete, wantRetained: false},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Mock Kafka AdminClient Tracking The Deleted Topics
+			var deletedTopicNames []string
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockDeleteTopicFunc: func(ctx context.Context, topicName string) *sarama.TopicError {
+					deletedTopicNames = append(deletedTopicNames, topicName)
+					return nil
+				},
+			}
+
+			// Mock The Creation Of The Kafka AdminClient
+			newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+			kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+				return mockAdminClient, nil
+			}
+			defer func() {
+				kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+			}()
+
+			// Initialize The Reconciler
+			config := controllertesting.NewConfig()
+			config.Kafka.Topic.DefaultDeletionPolicy = test.defaultPolicy
+			r := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				adminClientType: kafkaadmin.Kafka,
+				adminMutex:      &sync.Mutex{},
+				config:          config,
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer)
+			channel.Spec.TopicDeletionPolicy = test.channelPolicy
+			result := r.FinalizeKind(context.TODO(), channel)
+
+			// Verify The Results
+			wantEvent := event.KafkaChannelFinalized
+			if test.wantRetained {
+				wantEvent = event.KafkaTopicRetained
+			}
+			if !errors.Is(result, pkgreconciler.NewEvent(corev1.EventTypeNormal, wantEvent.String(), "")) {
+				t.Errorf("unexpected FinalizeKind() result: %v", result)
+			}
+			if test.wantRetained && len(deletedTopicNames) > 0 {
+				t.Errorf("unexpected deletion of retained topics %v", deletedTopicNames)
+			}
+			if !test.wantRetained && len(deletedTopicNames) != commonkafkaconstants.MaxRetryTopics+1 {
+				t.Errorf("expected the deletion of the topic and %d retry topics, got %v", commonkafkaconstants.MaxRetryTopics, deletedTopicNames)
+			}
+		})
+	}
+}
+
 // Factory For Creating A Go Test Function For The Specified TopicTestCase
 func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 	return func(t *testing.T) {
@@ -175,7 +417,8 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 		ctx := controller.WithEventRecorder(context.TODO(), recorder)
 
 		// Create A Mock Kafka AdminClient For Current TopicTestCase
-		mockAdminClient := createMockAdminClientForTestCase(t, tc)
+		var topicNames []string
+		mockAdminClient := createMockAdminClientForTestCase(t, tc, &topicNames)
 
 		// Initialize The Reconciler For The Current TopicTestCase
 		r := &Reconciler{
@@ -196,7 +439,12 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 		}
 
 		// Perform The Test (Delete) - Called By Knative FinalizeKind() Directly
-		if tc.WantDelete {
+		if tc.WantDelete && tc.WantDeleteRetry {
+			err = r.deleteRetryTopics(ctx, controllertesting.TopicName)
+			if !mockAdminClient.DeleteTopicsCalled() {
+				t.Errorf("expected DeleteTopics() called to be %t", tc.WantDelete)
+			}
+		} else if tc.WantDelete {
 			err = r.deleteTopic(ctx, controllertesting.TopicName)
 			if !mockAdminClient.DeleteTopicsCalled() {
 				t.Errorf("expected DeleteTopics() called to be %t", tc.WantCreate)
@@ -211,11 +459,18 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 		if diff := cmp.Diff(tc.WantError, errorString); diff != "" {
 			t.Errorf("unexpected error (-want, +got) = %v", diff)
 		}
+
+		// Validate TestCase Expected Topic Names (Only When Multiple Topics Are Expected)
+		if tc.WantTopicNames != nil {
+			if diff := cmp.Diff(tc.WantTopicNames, topicNames); diff != "" {
+				t.Errorf("unexpected topic names (-want, +got) = %v", diff)
+			}
+		}
 	}
 }
 
 // Create A Mock Kafka AdminClient For The Specified TopicTestCase
-func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controllertesting.MockAdminClient {
+func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase, topicNames *[]string) *controllertesting.MockAdminClient {
 
 	// Setup Desired Mock ClusterAdmin Behavior From TopicTestCase
 	return &controllertesting.MockAdminClient{
@@ -228,7 +483,8 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 			if ctx == nil {
 				t.Error("expected non nil context")
 			}
-			if topicName != controllertesting.TopicName {
+			*topicNames = append(*topicNames, topicName)
+			if tc.WantTopicNames == nil && topicName != controllertesting.TopicName {
 				t.Errorf("unexpected topic name '%s'", topicName)
 			}
 			if diff := cmp.Diff(tc.WantTopicDetail, topicDetail); diff != "" {
@@ -253,7 +509,8 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 			if ctx == nil {
 				t.Error("expected non nil context")
 			}
-			if topicName != controllertesting.TopicName {
+			*topicNames = append(*topicNames, topicName)
+			if tc.WantTopicNames == nil && topicName != controllertesting.TopicName {
 				t.Errorf("unexpected topic name '%s'", topicName)
 			}
 			errMsg := controllertesting.SuccessString
@@ -16,6 +16,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/scaling"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -46,6 +47,15 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 		logger.Info("Successfully Reconciled Channel Deployment")
 	}
 
+	// Reconcile The Channel Deployment's HorizontalPodAutoscaler & PodDisruptionBudget
+	scalingErr := scaling.ReconcileDeploymentScaling(ctx, logger, r.kubeClientset, util.ChannelDnsSafeName(secret.Name), util.NewSecretOwnerReference(secret), &r.config.Channel.EKKubernetesConfig)
+	if scalingErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeWarning, event.ChannelScalingReconciliationFailed.String(), "Failed To Reconcile Channel Scaling: %v", scalingErr)
+		logger.Error("Failed To Reconcile Channel Scaling", zap.Error(scalingErr))
+	} else {
+		logger.Info("Successfully Reconciled Channel Scaling")
+	}
+
 	// Reconcile Channel's KafkaChannel Status
 	statusErr := r.reconcileKafkaChannelStatus(ctx,
 		secret,
@@ -59,7 +69,7 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 	}
 
 	// Return Results
-	if serviceErr != nil || deploymentErr != nil || statusErr != nil {
+	if serviceErr != nil || deploymentErr != nil || scalingErr != nil || statusErr != nil {
 		return fmt.Errorf("failed to reconcile channel resources")
 	} else {
 		return nil // Success
@@ -170,7 +180,7 @@ func (r *Reconciler) newChannelService(secret *corev1.Secret) *corev1.Service {
 func (r *Reconciler) reconcileChannelDeployment(ctx context.Context, secret *corev1.Secret) error {
 
 	// Attempt To Get The KafkaChannel Deployment Associated With The Specified Channel
-	_, err := r.getChannelDeployment(secret)
+	deployment, err := r.getChannelDeployment(secret)
 	if err != nil {
 
 		// If The KafkaChannel Deployment Was Not Found - Then Create A New Deployment For The Channel
@@ -201,7 +211,14 @@ func (r *Reconciler) reconcileChannelDeployment(ctx context.Context, secret *cor
 		}
 	} else {
 
-		// Verified The Channel Service Exists
+		// Roll The Channel Deployment If The Kafka Secret Data Has Changed
+		err = r.rollDeploymentOnSecretChange(ctx, secret, deployment)
+		if err != nil {
+			r.logger.Error("Failed To Roll KafkaChannel Deployment", zap.Error(err))
+			return err
+		}
+
+		// Verified The Channel Deployment Exists
 		r.logger.Info("Successfully Verified Channel Deployment")
 		return nil
 	}
@@ -265,6 +282,9 @@ func (r *Reconciler) newChannelDeployment(secret *corev1.Secret) (*appsv1.Deploy
 					Labels: map[string]string{
 						constants.AppLabel: deploymentName, // Matched By Deployment Selector Above
 					},
+					Annotations: map[string]string{
+						constants.KafkaSecretHashAnnotation: util.SecretDataHash(secret), // Rolls The Pods When The Kafka Secret Changes
+					},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: r.environment.ServiceAccount,
@@ -30,13 +30,12 @@ func (r *Reconciler) reconcileKafkaChannelStatus(ctx context.Context,
 	logger := util.SecretLogger(r.logger, secret).With(zap.Bool("Service", serviceValid), zap.Bool("Deployment", deploymentValid))
 
 	// Create Selector With Requirement For KafkaSecret Labels With Value Of Specified Secret Name
-	selector := labels.NewSelector()
 	requirement, err := labels.NewRequirement(constants.KafkaSecretLabel, selection.Equals, []string{secret.Name})
 	if err != nil {
 		logger.Error("Failed To Create Selector Requirement For Kafka Secret Label", zap.Error(err)) // Should Never Happen
 		return err
 	}
-	selector.Add(*requirement)
+	selector := labels.NewSelector().Add(*requirement) // Only The KafkaChannels Of This Kafka Secret (Cluster)
 
 	// List The KafkaChannels Which Match The Selector (All Namespaces)
 	kafkaChannels, err := r.kafkachannelLister.List(selector)
@@ -87,6 +87,12 @@ func (r *Reconciler) reconcile(ctx context.Context, secret *corev1.Secret) error
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
+	// Roll The Dispatchers Of The Kafka Secret's KafkaChannels If The Secret Data Has Changed
+	err = r.reconcileDispatchers(ctx, secret)
+	if err != nil {
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
 	// Return Success
 	return nil
 }
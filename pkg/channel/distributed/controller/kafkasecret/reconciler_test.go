@@ -4,14 +4,18 @@ import (
 	"context"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinjection"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -28,6 +32,9 @@ func init() {
 	_ = duckv1.AddToScheme(scheme.Scheme)
 }
 
+// Test Data
+const testPoolName = "low-traffic"
+
 // Test The Reconcile Functionality
 func TestReconcile(t *testing.T) {
 
@@ -80,7 +87,7 @@ func TestReconcile(t *testing.T) {
 			Key:  controllertesting.KafkaSecretKey,
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(),
-				controllertesting.NewKafkaChannel(),
+				controllertesting.NewKafkaChannel(controllertesting.WithLabels),
 			},
 			WantCreates: []runtime.Object{
 				controllertesting.NewKafkaChannelChannelService(),
@@ -89,6 +96,7 @@ func TestReconcile(t *testing.T) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithLabels,
 						controllertesting.WithChannelServiceReady,
 						controllertesting.WithChannelDeploymentReady,
 					),
@@ -100,6 +108,23 @@ func TestReconcile(t *testing.T) {
 				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
 			},
 		},
+		{
+			Name: "Complete Reconciliation Ignores KafkaChannel Of Other Kafka Secret",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
+				controllertesting.NewKafkaChannel(withOtherKafkaSecretLabel),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelChannelService(),
+				controllertesting.NewKafkaChannelChannelDeployment(),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewKafkaSecretFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaSecretFinalizerUpdateEvent(),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
 
 		//
 		// KafkaChannel Secret Deletion (Finalizer)
@@ -111,6 +136,7 @@ func TestReconcile(t *testing.T) {
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretDeleted),
 				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
 					controllertesting.WithChannelServiceReady,
 					controllertesting.WithChannelDeploymentReady,
 				),
@@ -118,6 +144,7 @@ func TestReconcile(t *testing.T) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithLabels,
 						controllertesting.WithChannelServiceFinalized,
 						controllertesting.WithChannelDeploymentFinalized,
 					),
@@ -138,6 +165,7 @@ func TestReconcile(t *testing.T) {
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
 				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
 					controllertesting.WithChannelServiceReady,
 					controllertesting.WithChannelDeploymentReady,
 				),
@@ -154,6 +182,7 @@ func TestReconcile(t *testing.T) {
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
 				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
 					controllertesting.WithChannelServiceReady,
 					controllertesting.WithChannelDeploymentReady,
 				),
@@ -168,6 +197,7 @@ func TestReconcile(t *testing.T) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithLabels,
 						controllertesting.WithChannelServiceFailed,
 						controllertesting.WithChannelDeploymentReady,
 					),
@@ -189,6 +219,7 @@ func TestReconcile(t *testing.T) {
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
 				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
 					controllertesting.WithChannelServiceReady,
 					controllertesting.WithChannelDeploymentReady,
 				),
@@ -204,6 +235,7 @@ func TestReconcile(t *testing.T) {
 			Objects: []runtime.Object{
 				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
 				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
 					controllertesting.WithChannelServiceReady,
 					controllertesting.WithChannelDeploymentReady,
 				),
@@ -216,6 +248,7 @@ func TestReconcile(t *testing.T) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithLabels,
 						controllertesting.WithChannelServiceReady,
 						controllertesting.WithChannelDeploymentFailed,
 					),
@@ -226,6 +259,105 @@ func TestReconcile(t *testing.T) {
 				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
 			},
 		},
+
+		//
+		// Kafka Secret Rotation
+		//
+
+		{
+			Name: "Roll Channel Deployment On Kafka Secret Change",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithChannelServiceReady,
+					controllertesting.WithChannelDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelChannelService(),
+				withStaleKafkaSecretHash(controllertesting.NewKafkaChannelChannelDeployment()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.NewKafkaChannelChannelDeployment()},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, event.KafkaSecretRotated.String(), "Kafka Secret Changed - Rolling Deployment: \"%s/%s\"", commonconstants.KnativeEventingNamespace, controllertesting.ChannelDeploymentName),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name: "Roll Dispatcher Deployment On Kafka Secret Change",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithChannelServiceReady,
+					controllertesting.WithChannelDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelChannelService(),
+				controllertesting.NewKafkaChannelChannelDeployment(),
+				withStaleKafkaSecretHash(controllertesting.NewKafkaChannelDispatcherDeployment()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.NewKafkaChannelDispatcherDeployment()},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, event.KafkaSecretRotated.String(), "Kafka Secret Changed - Rolling Deployment: \"%s/%s\"", commonconstants.KnativeEventingNamespace, controllertesting.NewKafkaChannelDispatcherDeployment().Name),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name: "Roll Dispatcher Pool Deployment Once On Kafka Secret Change",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				withDispatcherPool(controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithChannelServiceReady,
+					controllertesting.WithChannelDeploymentReady,
+				)),
+				withDispatcherPool(withName(controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithChannelServiceReady,
+					controllertesting.WithChannelDeploymentReady,
+				), "other-kafkachannel")),
+				controllertesting.NewKafkaChannelChannelService(),
+				controllertesting.NewKafkaChannelChannelDeployment(),
+				withStaleKafkaSecretHash(newDispatcherPoolDeployment()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: newDispatcherPoolDeployment()},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, event.KafkaSecretRotated.String(), "Kafka Secret Changed - Rolling Deployment: \"%s/%s\"", commonconstants.KnativeEventingNamespace, newDispatcherPoolDeployment().Name),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name: "Roll Dispatcher Deployment On Kafka Secret Change Error(Update)",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithChannelServiceReady,
+					controllertesting.WithChannelDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelChannelService(),
+				controllertesting.NewKafkaChannelChannelDeployment(),
+				withStaleKafkaSecretHash(controllertesting.NewKafkaChannelDispatcherDeployment()),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("update", "deployments")},
+			WantErr:      true,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.NewKafkaChannelDispatcherDeployment()},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Roll Dispatcher Deployment %q: inducing failure for update deployments", controllertesting.NewKafkaChannelDispatcherDeployment().Name),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
 	}
 
 	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
@@ -244,3 +376,33 @@ func TestReconcile(t *testing.T) {
 		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
 	}, logger.Desugar()))
 }
+
+// Set The Kafka Secret Hash Of The Specified Deployment's Pod Template To A Prior (Pre-Rotation) Value
+func withStaleKafkaSecretHash(deployment *appsv1.Deployment) *appsv1.Deployment {
+	deployment.Spec.Template.Annotations[constants.KafkaSecretHashAnnotation] = "stale-kafka-secret-hash"
+	return deployment
+}
+
+// Set The KafkaChannel's Kafka Secret Label To A Different Kafka Secret (Cluster) Than The Test Kafka Secret
+func withOtherKafkaSecretLabel(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Labels = map[string]string{constants.KafkaSecretLabel: "other-kafka-secret"}
+}
+
+// Label The KafkaChannel As Part Of The Test Dispatcher Pool
+func withDispatcherPool(kafkachannel *kafkav1beta1.KafkaChannel) *kafkav1beta1.KafkaChannel {
+	kafkachannel.ObjectMeta.Labels[commonconstants.DispatcherPoolLabel] = testPoolName
+	return kafkachannel
+}
+
+// Set The Name Of The Specified KafkaChannel
+func withName(kafkachannel *kafkav1beta1.KafkaChannel, name string) *kafkav1beta1.KafkaChannel {
+	kafkachannel.ObjectMeta.Name = name
+	return kafkachannel
+}
+
+// Create The Dispatcher Deployment Of The Test Dispatcher Pool (Dispatcher Deployment Renamed For The Pool)
+func newDispatcherPoolDeployment() *appsv1.Deployment {
+	deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	deployment.Name = util.DispatcherPoolDnsSafeName(testPoolName, controllertesting.KafkaSecretName)
+	return deployment
+}
@@ -0,0 +1,115 @@
+package kafkasecret
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Kafka Secret Rotation - Rolls The Deployments Which Consume The Kafka Secret Via SecretKeyRef Env Vars
+//
+
+// Reconcile The Dispatcher Deployments Of All KafkaChannels Using The Specified Kafka Secret
+func (r *Reconciler) reconcileDispatchers(ctx context.Context, secret *corev1.Secret) error {
+
+	// Get Secret Specific Logger
+	logger := util.SecretLogger(r.logger, secret)
+
+	// Create Selector With Requirement For KafkaSecret Labels With Value Of Specified Secret Name
+	requirement, err := labels.NewRequirement(constants.KafkaSecretLabel, selection.Equals, []string{secret.Name})
+	if err != nil {
+		logger.Error("Failed To Create Selector Requirement For Kafka Secret Label", zap.Error(err)) // Should Never Happen
+		return err
+	}
+	selector := labels.NewSelector().Add(*requirement)
+
+	// List The KafkaChannels Which Match The Selector (All Namespaces)
+	kafkaChannels, err := r.kafkachannelLister.List(selector)
+	if err != nil {
+		logger.Error("Failed To List KafkaChannels For Kafka Secret", zap.Error(err))
+		return err
+	}
+
+	// Roll The Dispatcher Deployment Of Each KafkaChannel (Process All Regardless Of Error)
+	rolloutErrors := false
+	rolledDeployments := sets.NewString()
+	for _, kafkaChannel := range kafkaChannels {
+		if kafkaChannel != nil {
+
+			// Pooled KafkaChannels Share The Dispatcher Deployment Of Their Pool (Only Roll It Once)
+			deploymentName := util.DispatcherDnsSafeName(kafkaChannel)
+			if poolName := kafkaChannel.Labels[commonconstants.DispatcherPoolLabel]; len(poolName) > 0 {
+				deploymentName = util.DispatcherPoolDnsSafeName(poolName, secret.Name)
+			}
+			if rolledDeployments.Has(deploymentName) {
+				continue
+			}
+			rolledDeployments.Insert(deploymentName)
+
+			// Get The KafkaChannel's Dispatcher Deployment (Created By The KafkaChannel Reconciler - Skip If Not Yet Present)
+			deployment, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(deploymentName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				logger.Error("Failed To Get Dispatcher Deployment", zap.String("Deployment", deploymentName), zap.Error(err))
+				rolloutErrors = true
+				continue
+			}
+
+			// Roll The Dispatcher Deployment If The Kafka Secret Data Has Changed
+			err = r.rollDeploymentOnSecretChange(ctx, secret, deployment)
+			if err != nil {
+				controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Roll Dispatcher Deployment %q: %v", deploymentName, err)
+				logger.Error("Failed To Roll Dispatcher Deployment", zap.String("Deployment", deploymentName), zap.Error(err))
+				rolloutErrors = true
+			}
+		}
+	}
+
+	// Return Rollout Error
+	if rolloutErrors {
+		return fmt.Errorf("failed to roll one or more Dispatcher Deployments")
+	} else {
+		return nil
+	}
+}
+
+// Stamp The Kafka Secret Data Hash On The Deployment's Pod Template (Triggering A Rollout) If It Has Changed
+func (r *Reconciler) rollDeploymentOnSecretChange(ctx context.Context, secret *corev1.Secret, deployment *appsv1.Deployment) error {
+
+	// Nothing To Do If The Pod Template Already Reflects The Current Kafka Secret Data
+	secretHash := util.SecretDataHash(secret)
+	if deployment.Spec.Template.Annotations[constants.KafkaSecretHashAnnotation] == secretHash {
+		return nil
+	}
+
+	// Update A Copy Of The Deployment (Don't Modify The Informer's Cache) With The New Kafka Secret Hash
+	updatedDeployment := deployment.DeepCopy()
+	if updatedDeployment.Spec.Template.Annotations == nil {
+		updatedDeployment.Spec.Template.Annotations = make(map[string]string)
+	}
+	updatedDeployment.Spec.Template.Annotations[constants.KafkaSecretHashAnnotation] = secretHash
+	_, err := r.kubeClientset.AppsV1().Deployments(updatedDeployment.Namespace).Update(ctx, updatedDeployment, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Record The Rollout
+	r.logger.Info("Kafka Secret Changed - Rolling Deployment", zap.String("Secret", secret.Name), zap.String("Deployment", deployment.Name))
+	controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeNormal, event.KafkaSecretRotated.String(), "Kafka Secret Changed - Rolling Deployment: \"%s/%s\"", deployment.Namespace, deployment.Name)
+	return nil
+}
@@ -0,0 +1,277 @@
+package scaling
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+//
+// Deployment Scaling - The Optional HorizontalPodAutoscaler & PodDisruptionBudget Of A Channel / Dispatcher Deployment
+//
+// Both are named after, and owned by the same owner as, the Deployment they apply to.  They are created / updated
+// when enabled in the eventing-kafka ConfigMap, and deleted once the corresponding settings have been removed.
+//
+
+// Reconcile The HorizontalPodAutoscaler & PodDisruptionBudget Of The Specified Deployment
+func ReconcileDeploymentScaling(ctx context.Context,
+	logger *zap.Logger,
+	kubeClientset kubernetes.Interface,
+	deploymentName string,
+	ownerReference metav1.OwnerReference,
+	kubernetesConfig *config.EKKubernetesConfig) error {
+
+	// Get Deployment Specific Logger
+	logger = logger.With(zap.String("Deployment", deploymentName))
+
+	// Reconcile The HorizontalPodAutoscaler
+	err := reconcileHorizontalPodAutoscaler(ctx, logger, kubeClientset, deploymentName, ownerReference, &kubernetesConfig.Autoscaling)
+	if err != nil {
+		return err
+	}
+
+	// Reconcile The PodDisruptionBudget
+	return reconcilePodDisruptionBudget(ctx, logger, kubeClientset, deploymentName, ownerReference, &kubernetesConfig.PodDisruptionBudget)
+}
+
+//
+// HorizontalPodAutoscaler
+//
+
+// Reconcile The HorizontalPodAutoscaler Of The Specified Deployment
+func reconcileHorizontalPodAutoscaler(ctx context.Context,
+	logger *zap.Logger,
+	kubeClientset kubernetes.Interface,
+	deploymentName string,
+	ownerReference metav1.OwnerReference,
+	autoscalingConfig *config.EKAutoscalingConfig) error {
+
+	// Attempt To Get The Existing HorizontalPodAutoscaler
+	hpaClient := kubeClientset.AutoscalingV2beta2().HorizontalPodAutoscalers(commonconstants.KnativeEventingNamespace)
+	existingHpa, err := hpaClient.Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error("Failed To Get HorizontalPodAutoscaler", zap.Error(err))
+		return err
+	}
+	exists := err == nil
+
+	// Delete Any Owned HorizontalPodAutoscaler If Autoscaling Is Disabled
+	if autoscalingConfig.MaxReplicas <= 0 {
+		if exists && isControlledBy(existingHpa.ObjectMeta, ownerReference) {
+			err = hpaClient.Delete(ctx, deploymentName, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				logger.Error("Failed To Delete HorizontalPodAutoscaler", zap.Error(err))
+				return err
+			}
+			logger.Info("Successfully Deleted HorizontalPodAutoscaler")
+		}
+		return nil
+	}
+
+	// Create The HorizontalPodAutoscaler If It Doesn't Exist
+	hpa := newHorizontalPodAutoscaler(deploymentName, ownerReference, autoscalingConfig)
+	if !exists {
+		_, err = hpaClient.Create(ctx, hpa, metav1.CreateOptions{})
+		if err != nil {
+			logger.Error("Failed To Create HorizontalPodAutoscaler", zap.Error(err))
+			return err
+		}
+		logger.Info("Successfully Created HorizontalPodAutoscaler")
+		return nil
+	}
+
+	// Update The HorizontalPodAutoscaler If The Configuration Has Changed
+	if !equality.Semantic.DeepEqual(existingHpa.Spec, hpa.Spec) {
+		updatedHpa := existingHpa.DeepCopy()
+		updatedHpa.Spec = hpa.Spec
+		_, err = hpaClient.Update(ctx, updatedHpa, metav1.UpdateOptions{})
+		if err != nil {
+			logger.Error("Failed To Update HorizontalPodAutoscaler", zap.Error(err))
+			return err
+		}
+		logger.Info("Successfully Updated HorizontalPodAutoscaler")
+	}
+
+	// Return Success
+	return nil
+}
+
+// Create HorizontalPodAutoscaler Model For The Specified Deployment
+func newHorizontalPodAutoscaler(deploymentName string, ownerReference metav1.OwnerReference, autoscalingConfig *config.EKAutoscalingConfig) *autoscalingv2beta2.HorizontalPodAutoscaler {
+
+	// Min Replicas Int Value For De-Referencing
+	minReplicas := autoscalingConfig.MinReplicas
+
+	// Create The Metrics (Average CPU Utilization And / Or Average Custom Pod Metric Value)
+	var metrics []autoscalingv2beta2.MetricSpec
+	if autoscalingConfig.TargetCpuUtilizationPercentage > 0 {
+		targetCpuUtilizationPercentage := autoscalingConfig.TargetCpuUtilizationPercentage
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: &targetCpuUtilizationPercentage,
+				},
+			},
+		})
+	}
+	if len(autoscalingConfig.CustomMetricName) > 0 {
+		targetAverageValue := autoscalingConfig.CustomMetricTargetAverageValue.DeepCopy()
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.PodsMetricSourceType,
+			Pods: &autoscalingv2beta2.PodsMetricSource{
+				Metric: autoscalingv2beta2.MetricIdentifier{
+					Name: autoscalingConfig.CustomMetricName,
+				},
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:         autoscalingv2beta2.AverageValueMetricType,
+					AverageValue: &targetAverageValue,
+				},
+			},
+		})
+	}
+
+	// Create & Return The HorizontalPodAutoscaler Model
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2beta2.SchemeGroupVersion.String(),
+			Kind:       constants.HorizontalPodAutoscalerKind,
+		},
+		ObjectMeta: newObjectMeta(deploymentName, ownerReference),
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       constants.DeploymentKind,
+				Name:       deploymentName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscalingConfig.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+//
+// PodDisruptionBudget
+//
+
+// Reconcile The PodDisruptionBudget Of The Specified Deployment
+func reconcilePodDisruptionBudget(ctx context.Context,
+	logger *zap.Logger,
+	kubeClientset kubernetes.Interface,
+	deploymentName string,
+	ownerReference metav1.OwnerReference,
+	podDisruptionBudgetConfig *config.EKPodDisruptionBudgetConfig) error {
+
+	// Attempt To Get The Existing PodDisruptionBudget
+	pdbClient := kubeClientset.PolicyV1beta1().PodDisruptionBudgets(commonconstants.KnativeEventingNamespace)
+	existingPdb, err := pdbClient.Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error("Failed To Get PodDisruptionBudget", zap.Error(err))
+		return err
+	}
+	exists := err == nil
+
+	// Delete Any Owned PodDisruptionBudget If It Is Disabled
+	if podDisruptionBudgetConfig.MinAvailable == nil {
+		if exists && isControlledBy(existingPdb.ObjectMeta, ownerReference) {
+			err = pdbClient.Delete(ctx, deploymentName, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				logger.Error("Failed To Delete PodDisruptionBudget", zap.Error(err))
+				return err
+			}
+			logger.Info("Successfully Deleted PodDisruptionBudget")
+		}
+		return nil
+	}
+
+	// Create The PodDisruptionBudget If It Doesn't Exist
+	pdb := newPodDisruptionBudget(deploymentName, ownerReference, podDisruptionBudgetConfig)
+	if !exists {
+		_, err = pdbClient.Create(ctx, pdb, metav1.CreateOptions{})
+		if err != nil {
+			logger.Error("Failed To Create PodDisruptionBudget", zap.Error(err))
+			return err
+		}
+		logger.Info("Successfully Created PodDisruptionBudget")
+		return nil
+	}
+
+	// Update The PodDisruptionBudget If The Configuration Has Changed
+	if !equality.Semantic.DeepEqual(existingPdb.Spec, pdb.Spec) {
+		updatedPdb := existingPdb.DeepCopy()
+		updatedPdb.Spec = pdb.Spec
+		_, err = pdbClient.Update(ctx, updatedPdb, metav1.UpdateOptions{})
+		if err != nil {
+			logger.Error("Failed To Update PodDisruptionBudget", zap.Error(err))
+			return err
+		}
+		logger.Info("Successfully Updated PodDisruptionBudget")
+	}
+
+	// Return Success
+	return nil
+}
+
+// Create PodDisruptionBudget Model For The Specified Deployment
+func newPodDisruptionBudget(deploymentName string, ownerReference metav1.OwnerReference, podDisruptionBudgetConfig *config.EKPodDisruptionBudgetConfig) *policyv1beta1.PodDisruptionBudget {
+
+	// MinAvailable Value For De-Referencing
+	minAvailable := *podDisruptionBudgetConfig.MinAvailable
+
+	// Create & Return The PodDisruptionBudget Model
+	return &policyv1beta1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1beta1.SchemeGroupVersion.String(),
+			Kind:       constants.PodDisruptionBudgetKind,
+		},
+		ObjectMeta: newObjectMeta(deploymentName, ownerReference),
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.AppLabel: deploymentName, // Matches Deployment Label Key/Value
+				},
+			},
+		},
+	}
+}
+
+//
+// Utilities
+//
+
+// Create The Common ObjectMeta Of The HorizontalPodAutoscaler & PodDisruptionBudget Of The Specified Deployment
+func newObjectMeta(deploymentName string, ownerReference metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      deploymentName,
+		Namespace: commonconstants.KnativeEventingNamespace,
+		Labels: map[string]string{
+			constants.AppLabel: deploymentName,
+		},
+		OwnerReferences: []metav1.OwnerReference{
+			ownerReference,
+		},
+	}
+}
+
+// Determine Whether The Specified Object Is Controlled By The Specified Owner (Don't Delete Foreign Resources)
+func isControlledBy(objectMeta metav1.ObjectMeta, ownerReference metav1.OwnerReference) bool {
+	controllerReference := metav1.GetControllerOf(&objectMeta)
+	return controllerReference != nil &&
+		controllerReference.Kind == ownerReference.Kind &&
+		controllerReference.Name == ownerReference.Name
+}
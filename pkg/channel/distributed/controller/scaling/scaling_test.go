@@ -0,0 +1,157 @@
+package scaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	deploymentName = "test-deployment"
+	ownerName      = "test-owner"
+)
+
+// Test The Creation, Update & Deletion Of The HorizontalPodAutoscaler & PodDisruptionBudget
+func TestReconcileDeploymentScaling(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	ctx := context.TODO()
+	kubeClientset := fake.NewSimpleClientset()
+	ownerReference := newTestOwnerReference(ownerName)
+	minAvailable := intstr.FromInt(1)
+	kubernetesConfig := &config.EKKubernetesConfig{
+		Autoscaling: config.EKAutoscalingConfig{
+			MinReplicas:                    1,
+			MaxReplicas:                    5,
+			TargetCpuUtilizationPercentage: 80,
+		},
+		PodDisruptionBudget: config.EKPodDisruptionBudgetConfig{MinAvailable: &minAvailable},
+	}
+
+	// Perform The Test - Creation
+	err := ReconcileDeploymentScaling(ctx, logger, kubeClientset, deploymentName, ownerReference, kubernetesConfig)
+	assert.Nil(t, err)
+	hpa := getHorizontalPodAutoscaler(t, kubeClientset)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Equal(t, deploymentName, hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, constants.DeploymentKind, hpa.Spec.ScaleTargetRef.Kind)
+	assert.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, []metav1.OwnerReference{ownerReference}, hpa.OwnerReferences)
+	pdb := getPodDisruptionBudget(t, kubeClientset)
+	assert.Equal(t, minAvailable, *pdb.Spec.MinAvailable)
+	assert.Equal(t, map[string]string{constants.AppLabel: deploymentName}, pdb.Spec.Selector.MatchLabels)
+	assert.Equal(t, []metav1.OwnerReference{ownerReference}, pdb.OwnerReferences)
+
+	// Perform The Test - Update
+	minAvailable = intstr.FromString("50%")
+	kubernetesConfig.Autoscaling.MaxReplicas = 10
+	kubernetesConfig.Autoscaling.CustomMetricName = "requests_per_second"
+	kubernetesConfig.Autoscaling.CustomMetricTargetAverageValue = resource.MustParse("100")
+	err = ReconcileDeploymentScaling(ctx, logger, kubeClientset, deploymentName, ownerReference, kubernetesConfig)
+	assert.Nil(t, err)
+	hpa = getHorizontalPodAutoscaler(t, kubeClientset)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 2)
+	assert.Equal(t, autoscalingv2beta2.PodsMetricSourceType, hpa.Spec.Metrics[1].Type)
+	assert.Equal(t, "requests_per_second", hpa.Spec.Metrics[1].Pods.Metric.Name)
+	assert.Equal(t, "100", hpa.Spec.Metrics[1].Pods.Target.AverageValue.String())
+	pdb = getPodDisruptionBudget(t, kubeClientset)
+	assert.Equal(t, minAvailable, *pdb.Spec.MinAvailable)
+
+	// Perform The Test - Deletion
+	err = ReconcileDeploymentScaling(ctx, logger, kubeClientset, deploymentName, ownerReference, &config.EKKubernetesConfig{})
+	assert.Nil(t, err)
+	_, err = kubeClientset.AutoscalingV2beta2().HorizontalPodAutoscalers(commonconstants.KnativeEventingNamespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+	_, err = kubeClientset.PolicyV1beta1().PodDisruptionBudgets(commonconstants.KnativeEventingNamespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// Test That Disabled Scaling Doesn't Delete A HorizontalPodAutoscaler Or PodDisruptionBudget Owned By Someone Else
+func TestReconcileDeploymentScalingPreservesForeignResources(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	ctx := context.TODO()
+	minAvailable := intstr.FromInt(1)
+	foreignObjectMeta := newObjectMeta(deploymentName, newTestOwnerReference("foreign-owner"))
+	kubeClientset := fake.NewSimpleClientset(
+		&autoscalingv2beta2.HorizontalPodAutoscaler{ObjectMeta: foreignObjectMeta},
+		&policyv1beta1.PodDisruptionBudget{ObjectMeta: foreignObjectMeta, Spec: policyv1beta1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable}},
+	)
+
+	// Perform The Test
+	err := ReconcileDeploymentScaling(ctx, logger, kubeClientset, deploymentName, newTestOwnerReference(ownerName), &config.EKKubernetesConfig{})
+
+	// Verify The Results
+	assert.Nil(t, err)
+	getHorizontalPodAutoscaler(t, kubeClientset)
+	getPodDisruptionBudget(t, kubeClientset)
+}
+
+// Test The HorizontalPodAutoscaler Model With Only A Custom Metric
+func TestNewHorizontalPodAutoscalerCustomMetric(t *testing.T) {
+
+	// Test Data
+	autoscalingConfig := &config.EKAutoscalingConfig{
+		MinReplicas:                    2,
+		MaxReplicas:                    4,
+		CustomMetricName:               "requests_per_second",
+		CustomMetricTargetAverageValue: resource.MustParse("250m"),
+	}
+
+	// Perform The Test
+	hpa := newHorizontalPodAutoscaler(deploymentName, newTestOwnerReference(ownerName), autoscalingConfig)
+
+	// Verify The Results
+	assert.Equal(t, deploymentName, hpa.Name)
+	assert.Equal(t, commonconstants.KnativeEventingNamespace, hpa.Namespace)
+	assert.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, autoscalingv2beta2.PodsMetricSourceType, hpa.Spec.Metrics[0].Type)
+	assert.Equal(t, autoscalingv2beta2.AverageValueMetricType, hpa.Spec.Metrics[0].Pods.Target.Type)
+	assert.Equal(t, "250m", hpa.Spec.Metrics[0].Pods.Target.AverageValue.String())
+}
+
+// Utility Function For Creating A Controller OwnerReference
+func newTestOwnerReference(name string) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       constants.SecretKind,
+		Name:       name,
+		Controller: &controller,
+	}
+}
+
+// Utility Function For Getting The HorizontalPodAutoscaler From The Fake Clientset
+func getHorizontalPodAutoscaler(t *testing.T, kubeClientset *fake.Clientset) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa, err := kubeClientset.AutoscalingV2beta2().HorizontalPodAutoscalers(commonconstants.KnativeEventingNamespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.NotNil(t, hpa)
+	return hpa
+}
+
+// Utility Function For Getting The PodDisruptionBudget From The Fake Clientset
+func getPodDisruptionBudget(t *testing.T, kubeClientset *fake.Clientset) *policyv1beta1.PodDisruptionBudget {
+	pdb, err := kubeClientset.PolicyV1beta1().PodDisruptionBudgets(commonconstants.KnativeEventingNamespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.NotNil(t, pdb)
+	return pdb
+}
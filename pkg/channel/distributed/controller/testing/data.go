@@ -23,9 +23,12 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/messaging"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/network"
 	reconcilertesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
 )
@@ -62,6 +65,8 @@ const (
 	ChannelDeploymentName = KafkaSecretName + "-b9176d5f-channel" // Truncated MD5 Hash Of KafkaSecretName
 	ChannelServiceName    = ChannelDeploymentName
 	TopicName             = KafkaChannelNamespace + "." + KafkaChannelName
+	AdoptedTopicName      = "existing-topic"
+	DeadLetterSinkName    = "dead-letter-sink"
 
 	KafkaSecretDataValueBrokers  = "TestKafkaSecretDataBrokers"
 	KafkaSecretDataValueUsername = "TestKafkaSecretDataUsername"
@@ -100,9 +105,6 @@ dispatcher:
   memoryLimit: 128Mi
   memoryRequest: 50Mi
   replicas: 1
-  retryInitialIntervalMillis: 500
-  retryTimeMillis: 300000
-  retryExponentialBackoff: true
 kafka:
   topic:
     defaultNumPartitions: 4
@@ -337,6 +339,37 @@ func WithAnnotations(kafkachannel *kafkav1beta1.KafkaChannel) {
 	}
 }
 
+// Set The KafkaChannel's Retry Topics Annotation (Two Tiers)
+func WithRetryTopics(kafkachannel *kafkav1beta1.KafkaChannel) {
+	if kafkachannel.ObjectMeta.Annotations == nil {
+		kafkachannel.ObjectMeta.Annotations = make(map[string]string)
+	}
+	kafkachannel.ObjectMeta.Annotations[kafkaconstants.RetryTopicsAnnotation] = "2"
+}
+
+// Set The KafkaChannel's Adopted (Existing) Topic
+func WithAdoptedTopic(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.Topic = &kafkav1beta1.KafkaChannelTopic{Name: AdoptedTopicName, Adopt: true}
+}
+
+// Set The KafkaChannel's Delivery (DeadLetterSink Referencing A Kubernetes Service)
+func WithDelivery(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.Delivery = &eventingduck.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{
+			Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Namespace: KafkaChannelNamespace, Name: DeadLetterSinkName},
+		},
+	}
+}
+
+// Set The KafkaChannel's Resolved Delivery DeadLetterSink URI
+func WithDeadLetterSinkURI(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.SetDeadLetterSinkURI(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(DeadLetterSinkName, KafkaChannelNamespace),
+		Path:   "/",
+	})
+}
+
 // Set The KafkaChannel's Labels
 func WithLabels(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.ObjectMeta.Labels = map[string]string{
@@ -506,6 +539,9 @@ func NewKafkaChannelChannelDeployment() *appsv1.Deployment {
 					Labels: map[string]string{
 						"app": ChannelDeploymentName,
 					},
+					Annotations: map[string]string{
+						constants.KafkaSecretHashAnnotation: util.SecretDataHash(NewKafkaSecret()),
+					},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccount,
@@ -693,6 +729,9 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 					Labels: map[string]string{
 						"app": dispatcherName,
 					},
+					Annotations: map[string]string{
+						constants.KafkaSecretHashAnnotation: util.SecretDataHash(NewKafkaSecret()),
+					},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccount,
@@ -16,11 +16,18 @@ var _ kafkaadmin.AdminClientInterface = &MockAdminClient{}
 
 // Mock Kafka AdminClient Implementation
 type MockAdminClient struct {
-	closeCalled         bool
-	createTopicsCalled  bool
-	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
-	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
+	closeCalled                      bool
+	createTopicsCalled               bool
+	deleteTopicsCalled               bool
+	deleteConsumerGroupCalled        bool
+	MockCreateTopicFunc              func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockDeleteTopicFunc              func(context.Context, string) *sarama.TopicError
+	MockDescribeTopicFunc            func(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError)
+	MockListTopicsFunc               func(context.Context) (map[string]sarama.TopicDetail, error)
+	MockCreatePartitionsFunc         func(context.Context, string, int32) *sarama.TopicError
+	MockAlterTopicConfigFunc         func(context.Context, string, map[string]*string) *sarama.TopicError
+	MockListConsumerGroupOffsetsFunc func(context.Context, string, map[string][]int32) (map[string]map[int32]int64, error)
+	MockDeleteConsumerGroupFunc      func(context.Context, string) error
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
@@ -53,6 +60,60 @@ func (m *MockAdminClient) DeleteTopicsCalled() bool {
 	return m.deleteTopicsCalled
 }
 
+// Mock Kafka AdminClient DescribeTopic() Function - Calls Custom DescribeTopic() If Specified, Otherwise Returns Empty TopicDetail
+func (m *MockAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+	if m.MockDescribeTopicFunc != nil {
+		return m.MockDescribeTopicFunc(ctx, topicName)
+	}
+	return &sarama.TopicDetail{}, nil
+}
+
+// Mock Kafka AdminClient ListTopics() Function - Calls Custom ListTopics() If Specified, Otherwise Returns Empty Map
+func (m *MockAdminClient) ListTopics(ctx context.Context) (map[string]sarama.TopicDetail, error) {
+	if m.MockListTopicsFunc != nil {
+		return m.MockListTopicsFunc(ctx)
+	}
+	return map[string]sarama.TopicDetail{}, nil
+}
+
+// Mock Kafka AdminClient CreatePartitions() Function - Calls Custom CreatePartitions() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) CreatePartitions(ctx context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+	if m.MockCreatePartitionsFunc != nil {
+		return m.MockCreatePartitionsFunc(ctx, topicName, numPartitions)
+	}
+	return nil
+}
+
+// Mock Kafka AdminClient AlterTopicConfig() Function - Calls Custom AlterTopicConfig() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if m.MockAlterTopicConfigFunc != nil {
+		return m.MockAlterTopicConfigFunc(ctx, topicName, configEntries)
+	}
+	return nil
+}
+
+// Mock Kafka AdminClient ListConsumerGroupOffsets() Function - Calls Custom ListConsumerGroupOffsets() If Specified, Otherwise Returns Empty Map
+func (m *MockAdminClient) ListConsumerGroupOffsets(ctx context.Context, groupId string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	if m.MockListConsumerGroupOffsetsFunc != nil {
+		return m.MockListConsumerGroupOffsetsFunc(ctx, groupId, topicPartitions)
+	}
+	return map[string]map[int32]int64{}, nil
+}
+
+// Mock Kafka AdminClient DeleteConsumerGroup() Function - Calls Custom DeleteConsumerGroup() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) DeleteConsumerGroup(ctx context.Context, groupId string) error {
+	m.deleteConsumerGroupCalled = true
+	if m.MockDeleteConsumerGroupFunc != nil {
+		return m.MockDeleteConsumerGroupFunc(ctx, groupId)
+	}
+	return nil
+}
+
+// Check On Calls To DeleteConsumerGroup()
+func (m *MockAdminClient) DeleteConsumerGroupCalled() bool {
+	return m.deleteConsumerGroupCalled
+}
+
 // Mock Kafka AdminClient Close Function - NoOp
 func (m *MockAdminClient) Close() error {
 	m.closeCalled = true
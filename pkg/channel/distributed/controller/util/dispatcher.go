@@ -18,3 +18,16 @@ func DispatcherDnsSafeName(channel *kafkav1beta1.KafkaChannel) string {
 	hash := GenerateHash(channel.Name+channel.Namespace, 8)
 	return fmt.Sprintf("%s-%s-%s-dispatcher", safeChannelName, safeChannelNamespace, hash)
 }
+
+// Create A DNS Safe Name For The Dispatcher Pool Deployment / Service Of The Specified Pool & Kafka Secret
+func DispatcherPoolDnsSafeName(poolName string, kafkaSecretName string) string {
+
+	// In order for the resulting name to be a valid DNS component is 63 characters.  We are appending 13 characters to
+	// separate the components and to indicate this is a Dispatcher, and adding 8 hash characters, which further reduces
+	// the available length to 42.
+	// We will allocate 21 characters to the pool and 21 to the kafka secret.
+	safePoolName := GenerateValidDnsName(poolName, 21, true, false)
+	safeSecretName := GenerateValidDnsName(kafkaSecretName, 21, false, false)
+	hash := GenerateHash(poolName+"/"+kafkaSecretName, 8)
+	return fmt.Sprintf("%s-%s-%s-dispatcher", safePoolName, safeSecretName, hash)
+}
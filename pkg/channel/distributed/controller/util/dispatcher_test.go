@@ -140,3 +140,18 @@ func TestDispatcherDnsSafeName_LongNamesDifferent(t *testing.T) {
 		assert.NotEqual(t, actualResult1, actualResult2)
 	}
 }
+
+// Test The DispatcherPoolDnsSafeName() Functionality
+func TestDispatcherPoolDnsSafeName(t *testing.T) {
+
+	// Short Names Are Used As-Is
+	assert.Equal(t, fmt.Sprintf("low-traffic-kafka-secret-%s-dispatcher", GenerateHash("low-traffic/kafka-secret", 8)),
+		DispatcherPoolDnsSafeName("low-traffic", "kafka-secret"))
+
+	// Long Names Are Truncated To A Valid DNS Name & Still Differ
+	longName1 := DispatcherPoolDnsSafeName("kubernetes-maximum-length-of-pool-name-is-sixty-three-chars-1", "kubernetes-maximum-length-of-secret-name-is-sixty-three-chars")
+	longName2 := DispatcherPoolDnsSafeName("kubernetes-maximum-length-of-pool-name-is-sixty-three-chars-2", "kubernetes-maximum-length-of-secret-name-is-sixty-three-chars")
+	assert.LessOrEqual(t, len(longName1), 63)
+	assert.Equal(t, longName1, GenerateValidDnsName(longName1, 63, true, true))
+	assert.NotEqual(t, longName1, longName2)
+}
@@ -1,6 +1,10 @@
 package util
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,3 +37,26 @@ func NewSecretOwnerReference(secret *corev1.Secret) metav1.OwnerReference {
 		Controller:         &controller,
 	}
 }
+
+// Generate A Stable Hash Of The Specified K8S Secret's Data (Key Order Independent)
+func SecretDataHash(secret *corev1.Secret) string {
+
+	// Sort The Data Keys So The Hash Doesn't Depend On Map Iteration Order
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Hash Each Key / Value Pair (Null Separated To Avoid Ambiguous Concatenations)
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[key])
+		hash.Write([]byte{0})
+	}
+
+	// Return The Hex Encoded Hash
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
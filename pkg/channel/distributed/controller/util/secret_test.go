@@ -48,3 +48,36 @@ func TestNewSecretOwnerReference(t *testing.T) {
 	assert.True(t, *controllerRef.BlockOwnerDeletion)
 	assert.True(t, *controllerRef.Controller)
 }
+
+// Test The SecretDataHash() Functionality
+func TestSecretDataHash(t *testing.T) {
+
+	// Test Data
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			constants.KafkaSecretDataKeyBrokers:  []byte("TestBrokers"),
+			constants.KafkaSecretDataKeyUsername: []byte("TestUsername"),
+			constants.KafkaSecretDataKeyPassword: []byte("TestPassword"),
+		},
+	}
+
+	// Perform The Test
+	hash := SecretDataHash(secret)
+
+	// Validate Results
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, SecretDataHash(secret.DeepCopy()))
+
+	// Verify A Data Change Produces A Different Hash
+	rotatedSecret := secret.DeepCopy()
+	rotatedSecret.Data[constants.KafkaSecretDataKeyPassword] = []byte("RotatedPassword")
+	assert.NotEqual(t, hash, SecretDataHash(rotatedSecret))
+
+	// Verify Moving Bytes Between Keys & Values Produces A Different Hash
+	assert.NotEqual(t,
+		SecretDataHash(&corev1.Secret{Data: map[string][]byte{"ab": []byte("c")}}),
+		SecretDataHash(&corev1.Secret{Data: map[string][]byte{"a": []byte("bc")}}))
+
+	// Verify An Empty Secret Is Hashed
+	assert.Len(t, SecretDataHash(&corev1.Secret{}), 64)
+}
@@ -5,7 +5,10 @@ import (
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 )
 
-// Get The TopicName For Specified KafkaChannel (ChannelNamespace.ChannelName)
+// Get The TopicName For Specified KafkaChannel (The Adopted Topic, Otherwise ChannelNamespace.ChannelName)
 func TopicName(channel *kafkav1beta1.KafkaChannel) string {
+	if adoptedTopicName := channel.AdoptedTopicName(); adoptedTopicName != "" {
+		return adoptedTopicName
+	}
 	return commonkafkautil.TopicName(channel.Namespace, channel.Name)
 }
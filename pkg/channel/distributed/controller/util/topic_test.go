@@ -29,3 +29,18 @@ func TestTopicName(t *testing.T) {
 	expectedTopicName := channelNamespace + "." + channelName
 	assert.Equal(t, expectedTopicName, actualTopicName)
 }
+
+// Test The TopicName() Functionality For A KafkaChannel Adopting An Existing Topic
+func TestTopicNameAdopted(t *testing.T) {
+
+	// The KafkaChannel To Test
+	channel := &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "TestChannelName", Namespace: "TestChannelNamespace"},
+		Spec: kafkav1beta1.KafkaChannelSpec{
+			Topic: &kafkav1beta1.KafkaChannelTopic{Name: "existing-topic", Adopt: true},
+		},
+	}
+
+	// Perform The Test & Verify The Results
+	assert.Equal(t, "existing-topic", TopicName(channel))
+}
@@ -1,9 +1,30 @@
 package constants
 
+import "time"
+
 // Global Constants
 const (
 	// Default Values If Optional config-eventing-kafka ConfigMap Defaults Not Specified
 	DefaultEventRetryInitialIntervalMillis = 500    // 0.5 seconds
 	DefaultEventRetryTimeMillisMax         = 300000 // 5 minutes
 	DefaultExponentialBackoff              = true   // Enabled
+
+	// KafkaChannel Annotations For Subscriber Delivery Options (Optionally Suffixed With "." + Subscriber UID)
+	DeliveryOrderingAnnotation    = "eventing-kafka.knative.dev/delivery-ordering"    // "ordered" (default) or "unordered"
+	DeliveryConcurrencyAnnotation = "eventing-kafka.knative.dev/delivery-concurrency" // Max in-flight messages per partition when "unordered"
+	FailurePolicyAnnotation       = "eventing-kafka.knative.dev/failure-policy"       // "commit" (default), "pause-partition" or "block-with-backoff"
+
+	// Default Subscriber Delivery Options
+	DefaultDeliveryConcurrency = 10
+
+	// Default Delay Before Redelivery From The First Retry Topic (Doubled For Each Subsequent Tier)
+	DefaultRetryTopicDelay = 10 * time.Second
+
+	// Kafka Message Headers Of Events Produced To A Retry Topic
+	RetrySubscriberHeader = "eventing-kafka-retry-subscriber" // The UID of the subscriber the event is being retried for
+	RetryNotBeforeHeader  = "eventing-kafka-retry-not-before" // The unix time (millis) before which the event must not be redelivered
+
+	// Backoff Between Re-Delivery Attempts Of A Failed Message With The "block-with-backoff" FailurePolicy
+	FailureBackoffInitial = 1 * time.Second
+	FailureBackoffMax     = 1 * time.Minute
 )
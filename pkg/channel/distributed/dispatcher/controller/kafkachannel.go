@@ -4,17 +4,22 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
@@ -40,6 +45,7 @@ type Reconciler struct {
 	logger               *zap.Logger
 	channelKey           string
 	dispatcher           dispatcher.Dispatcher
+	dispatcherPool       *dispatcher.DispatcherPool // Only Set When Dispatching A Pool Of KafkaChannels
 	kafkachannelInformer cache.SharedIndexInformer
 	kafkachannelLister   listers.KafkaChannelLister
 	impl                 *controller.Impl
@@ -71,18 +77,62 @@ func NewController(
 	}
 	reconciler.impl = controller.NewImpl(reconciler, reconciler.logger.Sugar(), ReconcilerName)
 
-	reconciler.logger.Info("Setting Up Event Handlers")
+	// Re-Reconcile The KafkaChannel Whenever Its Subscribers' Stuck Partitions Change (To Update Their Status)
+	if namespace, name, err := cache.SplitMetaNamespaceKey(channelKey); err != nil {
+		logger.Error("Invalid KafkaChannel Key - Subscriber Status Will Not Reflect Stuck Partitions", zap.String("ChannelKey", channelKey), zap.Error(err))
+	} else {
+		channelName := types.NamespacedName{Namespace: namespace, Name: name}
+		dispatcher.SetStatusChangedFunc(func() { reconciler.impl.EnqueueKey(channelName) })
+	}
+
+	return reconciler.registerEventHandlers(kafkachannelInformer, kubeClient, stopChannel)
+}
+
+// NewPoolController initializes the controller of a dispatcher pool, which dispatches all the KafkaChannels
+// of the specified KafkaChannel informer (expected to be filtered by the dispatcher pool's label selector).
+func NewPoolController(
+	logger *zap.Logger,
+	dispatcherPool *dispatcher.DispatcherPool,
+	kafkachannelInformer informers.KafkaChannelInformer,
+	kubeClient kubernetes.Interface,
+	kafkaClientSet versioned.Interface,
+	stopChannel <-chan struct{},
+) *controller.Impl {
+
+	reconciler := &Reconciler{
+		logger:               logger,
+		dispatcherPool:       dispatcherPool,
+		kafkachannelInformer: kafkachannelInformer.Informer(),
+		kafkachannelLister:   kafkachannelInformer.Lister(),
+		kafkaClientSet:       kafkaClientSet,
+	}
+	reconciler.impl = controller.NewImpl(reconciler, reconciler.logger.Sugar(), ReconcilerName)
+
+	// Re-Reconcile A KafkaChannel Whenever Its Subscribers' Stuck Partitions Change (To Update Their Status)
+	dispatcherPool.SetStatusChangedFunc(func(channelKey string) {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(channelKey); err == nil {
+			reconciler.impl.EnqueueKey(types.NamespacedName{Namespace: namespace, Name: name})
+		}
+	})
+
+	return reconciler.registerEventHandlers(kafkachannelInformer, kubeClient, stopChannel)
+}
+
+// Register The Event Handlers & Event Recorder Of The Reconciler's Controller
+func (r *Reconciler) registerEventHandlers(kafkachannelInformer informers.KafkaChannelInformer, kubeClient kubernetes.Interface, stopChannel <-chan struct{}) *controller.Impl {
+
+	r.logger.Info("Setting Up Event Handlers")
 
 	// Watch for kafka channels.
-	kafkachannelInformer.Informer().AddEventHandler(controller.HandleAll(reconciler.impl.Enqueue))
-	logger.Debug("Creating event broadcaster")
+	kafkachannelInformer.Informer().AddEventHandler(controller.HandleAll(r.impl.Enqueue))
+	r.logger.Debug("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
 	watches := []watch.Interface{
-		eventBroadcaster.StartLogging(logger.Sugar().Named("event-broadcaster").Infof),
+		eventBroadcaster.StartLogging(r.logger.Sugar().Named("event-broadcaster").Infof),
 		eventBroadcaster.StartRecordingToSink(
 			&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")}),
 	}
-	reconciler.recorder = eventBroadcaster.NewRecorder(
+	r.recorder = eventBroadcaster.NewRecorder(
 		scheme.Scheme, corev1.EventSource{Component: ReconcilerName})
 	go func() {
 		<-stopChannel
@@ -91,7 +141,7 @@ func NewController(
 		}
 	}()
 
-	return reconciler.impl
+	return r.impl
 }
 
 func (r Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -110,6 +160,9 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			r.logger.Warn("KafkaChannel No Longer Exists", zap.String("namespace", namespace), zap.String("name", name))
+			if r.dispatcherPool != nil {
+				r.dispatcherPool.Remove(key) // Deleted Or No Longer Part Of The Dispatcher Pool
+			}
 			return nil
 		}
 		r.logger.Error("Error Retrieving KafkaChannel", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name))
@@ -117,7 +170,18 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	// Only Reconcile KafkaChannel Associated With This Dispatcher
-	if r.channelKey != key {
+	if r.dispatcherPool == nil && r.channelKey != key {
+		return nil
+	}
+
+	// Close The ConsumerGroups Of A KafkaChannel Being Deleted So That The Controller Can Delete Them When Finalizing It
+	if !original.DeletionTimestamp.IsZero() {
+		r.logger.Info("KafkaChannel Is Being Deleted - Closing Its ConsumerGroups", zap.String("key", key))
+		if r.dispatcherPool != nil {
+			r.dispatcherPool.Remove(key)
+		} else {
+			r.dispatcher.UpdateSubscriptions(nil, nil)
+		}
 		return nil
 	}
 
@@ -128,7 +192,7 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 	// Don't modify the informers copy
 	channel := original.DeepCopy()
 
-	reconcileError := r.reconcile(channel)
+	reconcileError := r.reconcile(channel, r.channelDispatcher(key, channel))
 	if reconcileError != nil {
 		r.logger.Error("Error Reconciling KafkaChannel", zap.Error(reconcileError))
 		r.recorder.Eventf(channel, corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: %v", reconcileError)
@@ -150,24 +214,39 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 	return nil
 }
 
-// Reconcile The Specified KafkaChannel
-func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
+// Get The Dispatcher Of The Specified KafkaChannel (From The Dispatcher Pool If Dispatching A Pool Of KafkaChannels)
+func (r Reconciler) channelDispatcher(key string, channel *kafkav1beta1.KafkaChannel) dispatcher.Dispatcher {
+	if r.dispatcherPool == nil {
+		return r.dispatcher
+	}
+	topic := channel.AdoptedTopicName()
+	if topic == "" {
+		topic = commonkafkautil.TopicName(channel.Namespace, channel.Name)
+	}
+	return r.dispatcherPool.Dispatcher(key, topic)
+}
+
+// Reconcile The Specified KafkaChannel With The Specified Dispatcher
+func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel, channelDispatcher dispatcher.Dispatcher) error {
 
 	// The KafkaChannel's Subscribers
 	var subscribers []eventingduck.SubscriberSpec
 
-	// Clone The Subscribers If They Exist - Otherwise Create Empty Array
-	if channel.Spec.Subscribers != nil {
-		subscribers = channel.Spec.Subscribers
-	} else {
-		subscribers = make([]eventingduck.SubscriberSpec, 0)
+	// Clone The Subscribers, Defaulting Their Delivery To The KafkaChannel's Delivery (Empty Array If None)
+	subscribers = make([]eventingduck.SubscriberSpec, len(channel.Spec.Subscribers))
+	for i, subscriber := range channel.Spec.Subscribers {
+		subscriber.Delivery = channel.SubscriberDelivery(subscriber)
+		subscribers[i] = subscriber
 	}
 
+	// Get The Subscribers' Delivery Options From The KafkaChannel Annotations
+	subscriberOptions := dispatcher.NewSubscriberOptionsMap(r.logger, channel.Annotations, subscribers)
+
 	// Update The ConsumerGroups To Align With Current KafkaChannel Subscribers
-	failedSubscriptions := r.dispatcher.UpdateSubscriptions(subscribers)
+	failedSubscriptions := channelDispatcher.UpdateSubscriptions(subscribers, subscriberOptions)
 
-	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status
-	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, failedSubscriptions)
+	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status & Stuck Partitions
+	channel.Status.SubscribableStatus = r.createSubscribableStatus(subscribers, failedSubscriptions, channelDispatcher.StuckPartitions(), channelDispatcher.StartPositions())
 
 	// Log Failed Subscriptions & Return Error
 	if len(failedSubscriptions) > 0 {
@@ -179,8 +258,8 @@ func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
 	return nil
 }
 
-// Create The SubscribableStatus Block Based On The Updated Subscriptions
-func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error) eventingduck.SubscribableStatus {
+// Create The SubscribableStatus Block Based On The Updated Subscriptions, Their Stuck Partitions & Start Positions
+func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error, stuckPartitions map[types.UID]map[int32]error, startPositions map[types.UID]string) eventingduck.SubscribableStatus {
 
 	subscriberStatus := make([]eventingduck.SubscriberStatus, 0)
 
@@ -193,6 +272,11 @@ func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.Subscri
 		if err, ok := failedSubscriptions[subscriber]; ok {
 			status.Ready = corev1.ConditionFalse
 			status.Message = err.Error()
+		} else if partitions, ok := stuckPartitions[subscriber.UID]; ok && len(partitions) > 0 {
+			status.Ready = corev1.ConditionFalse
+			status.Message = stuckPartitionsMessage(partitions)
+		} else if startedFrom, ok := startPositions[subscriber.UID]; ok {
+			status.Message = startedFrom
 		}
 		subscriberStatus = append(subscriberStatus, status)
 	}
@@ -202,6 +286,20 @@ func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.Subscri
 	}
 }
 
+// Format The Subscriber Status Message For The Specified Stuck Partitions (Sorted For A Stable Status)
+func stuckPartitionsMessage(stuckPartitions map[int32]error) string {
+	partitions := make([]int, 0, len(stuckPartitions))
+	for partition := range stuckPartitions {
+		partitions = append(partitions, int(partition))
+	}
+	sort.Ints(partitions)
+	partitionStrings := make([]string, len(partitions))
+	for i, partition := range partitions {
+		partitionStrings[i] = strconv.Itoa(partition)
+	}
+	return fmt.Sprintf("delivery stuck on partition(s) %s: %v", strings.Join(partitionStrings, ", "), stuckPartitions[int32(partitions[0])])
+}
+
 func (r *Reconciler) updateStatus(ctx context.Context, desired *kafkav1beta1.KafkaChannel) (*kafkav1beta1.KafkaChannel, error) {
 	kc, err := r.kafkachannelLister.KafkaChannels(desired.Namespace).Get(desired.Name)
 	if err != nil {
@@ -1,23 +1,30 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
 	reconciletesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/controller"
 	kncontroller "knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -26,8 +33,12 @@ import (
 )
 
 const (
-	testNS = "test-namespace"
-	kcName = "test-kc"
+	testNS         = "test-namespace"
+	kcName         = "test-kc"
+	stuckSubUID    = types.UID("stuck")
+	stuckMessage   = "delivery stuck on partition(s) 0, 3: test delivery failure"
+	startedSubUID  = types.UID("started")
+	startedMessage = "started from earliest at partition:offset 0:0"
 )
 
 func init() {
@@ -58,6 +69,104 @@ func TestNewController(t *testing.T) {
 	close(stopChan)
 }
 
+// Test The NewPoolController() Functionality
+func TestNewPoolController(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	dispatcherPool := dispatcher.NewDispatcherPool(dispatcher.DispatcherConfig{Logger: logger})
+	fakeKafkaChannelClientSet := fakeclientset.NewSimpleClientset()
+	fakeK8sClientSet := fake.NewSimpleClientset()
+	kafkaInformerFactory := externalversions.NewSharedInformerFactory(fakeKafkaChannelClientSet, kncontroller.DefaultResyncPeriod)
+	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
+	stopChan := make(chan struct{})
+
+	// Perform The Test
+	c := NewPoolController(logger, dispatcherPool, kafkaChannelInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan)
+
+	// Verify Results
+	assert.NotNil(t, c)
+
+	// Close The StopChannel
+	close(stopChan)
+}
+
+// Test The Reconciliation Of The KafkaChannels Of A Dispatcher Pool
+func TestReconcileDispatcherPool(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	kcKey := testNS + "/" + kcName
+	channel := reconciletesting.NewKafkaChannel(kcName, testNS,
+		reconciletesting.WithInitKafkaChannelConditions,
+		reconciletesting.WithKafkaChannelAddress("http://foobar"),
+		reconciletesting.WithKafkaChannelReady)
+	deletedKey := testNS + "/deleted"
+	deletedChannel := reconciletesting.NewKafkaChannel("deleted", testNS, reconciletesting.WithKafkaChannelReady, reconciletesting.WithKafkaChannelDeleted)
+	listers := reconciletesting.NewListers([]runtime.Object{channel, deletedChannel})
+	dispatcherPool := dispatcher.NewDispatcherPool(dispatcher.DispatcherConfig{Logger: logger})
+	r := Reconciler{
+		logger:             logger,
+		dispatcherPool:     dispatcherPool,
+		kafkachannelLister: listers.GetKafkaChannelLister(),
+		recorder:           record.NewFakeRecorder(10),
+		kafkaClientSet:     fakeclientset.NewSimpleClientset(channel),
+	}
+
+	// Perform The Test - Any KafkaChannel Is Reconciled With Its Dispatcher From The Pool
+	err := r.Reconcile(context.TODO(), kcKey)
+	assert.Nil(t, err)
+	channelDispatcher := dispatcherPool.Dispatcher(kcKey, commonkafkautil.TopicName(testNS, kcName))
+	err = r.Reconcile(context.TODO(), kcKey)
+	assert.Nil(t, err)
+	assert.Same(t, channelDispatcher, dispatcherPool.Dispatcher(kcKey, commonkafkautil.TopicName(testNS, kcName)))
+
+	// Perform The Test - A KafkaChannel No Longer Found Is Removed From The Pool
+	err = r.Reconcile(context.TODO(), "foo/not-found")
+	assert.Nil(t, err)
+	notFoundDispatcher := dispatcherPool.Dispatcher("foo/not-found", "foo.not-found")
+	err = r.Reconcile(context.TODO(), "foo/not-found")
+	assert.Nil(t, err)
+	assert.NotSame(t, notFoundDispatcher, dispatcherPool.Dispatcher("foo/not-found", "foo.not-found"))
+
+	// Perform The Test - A KafkaChannel Being Deleted Is Removed From The Pool (Closing Its ConsumerGroups)
+	deletedDispatcher := dispatcherPool.Dispatcher(deletedKey, commonkafkautil.TopicName(testNS, "deleted"))
+	err = r.Reconcile(context.TODO(), deletedKey)
+	assert.Nil(t, err)
+	assert.NotSame(t, deletedDispatcher, dispatcherPool.Dispatcher(deletedKey, commonkafkautil.TopicName(testNS, "deleted")))
+}
+
+// Test That The Subscribers Of A KafkaChannel Being Deleted Are Removed From Its Dedicated Dispatcher
+func TestReconcileDeletedKafkaChannel(t *testing.T) {
+
+	// Test Data
+	kcKey := testNS + "/" + kcName
+	channel := reconciletesting.NewKafkaChannel(kcName, testNS,
+		reconciletesting.WithKafkaChannelReady,
+		reconciletesting.WithSubscriber("1", "http://foobar"),
+		reconciletesting.WithKafkaChannelDeleted)
+	listers := reconciletesting.NewListers([]runtime.Object{channel})
+
+	// Create A Reconciler With A Mock Dispatcher Recording The Subscribers
+	updatedSubscribers := channel.Spec.Subscribers
+	mockDispatcher := NewMockDispatcher(t)
+	mockDispatcher.updatedSubscribers = &updatedSubscribers
+	r := Reconciler{
+		logger:             logtesting.TestLogger(t).Desugar(),
+		channelKey:         kcKey,
+		kafkachannelLister: listers.GetKafkaChannelLister(),
+		dispatcher:         mockDispatcher,
+		recorder:           record.NewFakeRecorder(10),
+	}
+
+	// Perform The Test
+	err := r.Reconcile(context.TODO(), kcKey)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Empty(t, updatedSubscribers)
+}
+
 // Test KafkaChannel Controller Reconciliation
 func TestAllCases(t *testing.T) {
 	kcKey := testNS + "/" + kcName
@@ -139,6 +248,59 @@ func TestAllCases(t *testing.T) {
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
 		},
+		{
+			Name: "channel ready, subscriber stuck",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(stuckSubUID, "http://foobar2"),
+					reconciletesting.WithSubscriberReady("1"),
+					reconciletesting.WithSubscriberReady(stuckSubUID)),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(stuckSubUID, "http://foobar2"),
+					reconciletesting.WithSubscriberReady("1"),
+					reconciletesting.WithSubscriberStuck(stuckSubUID, stuckMessage),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber started from start position",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber(startedSubUID, "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithSubscriber(startedSubUID, "http://foobar"),
+					reconciletesting.WithSubscriberStarted(startedSubUID, startedMessage),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
 	}
 
 	table.Test(t, reconciletesting.MakeFactory(func(listers *reconciletesting.Listers, kafkaClient versioned.Interface, eventRecorder record.EventRecorder) controller.Reconciler {
@@ -157,6 +319,46 @@ func TestAllCases(t *testing.T) {
 	time.Sleep(1 * time.Second)
 }
 
+// Test That Subscribers Without A Delivery Spec Use The KafkaChannel's Delivery Spec
+func TestReconcileChannelDelivery(t *testing.T) {
+
+	// Create A KafkaChannel With A Delivery Spec & Resolved DeadLetterSink, One Subscriber Having Its Own Delivery Spec
+	channelDeadLetterSinkURI := apis.HTTP("channel-dls.example.com")
+	subscriberDelivery := &eventingduck.DeliverySpec{Retry: pointer.Int32Ptr(1)}
+	channel := reconciletesting.NewKafkaChannel(kcName, testNS,
+		reconciletesting.WithSubscriber("1", "http://foobar"),
+		reconciletesting.WithSubscriber("2", "http://foobar2"))
+	channel.Spec.Subscribers[1].Delivery = subscriberDelivery
+	channel.Spec.Delivery = &eventingduck.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "channel-dls"}},
+		Retry:          pointer.Int32Ptr(5),
+	}
+	channel.Status.DeadLetterSinkURI = channelDeadLetterSinkURI
+
+	// Create A Reconciler With A Mock Dispatcher Recording The Subscribers
+	var updatedSubscribers []eventingduck.SubscriberSpec
+	mockDispatcher := NewMockDispatcher(t)
+	mockDispatcher.updatedSubscribers = &updatedSubscribers
+	r := Reconciler{
+		logger:     logtesting.TestLogger(t).Desugar(),
+		dispatcher: mockDispatcher,
+	}
+
+	// Perform The Test
+	err := r.reconcile(channel, mockDispatcher)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Len(t, updatedSubscribers, 2)
+	assert.Equal(t, &eventingduck.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{URI: channelDeadLetterSinkURI},
+		Retry:          pointer.Int32Ptr(5),
+	}, updatedSubscribers[0].Delivery)
+	assert.Equal(t, subscriberDelivery, updatedSubscribers[1].Delivery)
+	assert.Nil(t, channel.Spec.Subscribers[0].Delivery)
+	assert.Len(t, channel.Status.Subscribers, 2)
+}
+
 //
 // Mock Dispatcher Implementation
 //
@@ -166,21 +368,38 @@ var _ dispatcher.Dispatcher = &MockDispatcher{}
 
 // Define The Mock Dispatcher
 type MockDispatcher struct {
-	t *testing.T
+	t                  *testing.T
+	stuckPartitions    map[types.UID]map[int32]error
+	updatedSubscribers *[]eventingduck.SubscriberSpec // Optional - Records The Subscribers Of UpdateSubscriptions()
 }
 
-// Mock Dispatcher Constructor
+// Mock Dispatcher Constructor (The "stuck" Subscriber Is Always Stuck & The "started" Subscriber Has A Start Position)
 func NewMockDispatcher(t *testing.T) MockDispatcher {
-	return MockDispatcher{t: t}
+	stuckErr := errors.New("test delivery failure")
+	return MockDispatcher{t: t, stuckPartitions: map[types.UID]map[int32]error{stuckSubUID: {3: stuckErr, 0: stuckErr}}}
 }
 
 func (m MockDispatcher) Shutdown() {
 }
 
-func (m MockDispatcher) UpdateSubscriptions(_ []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error {
+func (m MockDispatcher) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec, _ map[types.UID]dispatcher.SubscriberOptions) map[eventingduck.SubscriberSpec]error {
+	if m.updatedSubscribers != nil {
+		*m.updatedSubscribers = subscriberSpecs
+	}
 	return nil
 }
 
 func (m MockDispatcher) ConfigChanged(*corev1.ConfigMap) dispatcher.Dispatcher {
 	return nil
 }
+
+func (m MockDispatcher) SetStatusChangedFunc(_ func()) {
+}
+
+func (m MockDispatcher) StuckPartitions() map[types.UID]map[int32]error {
+	return m.stuckPartitions
+}
+
+func (m MockDispatcher) StartPositions() map[types.UID]string {
+	return map[types.UID]string{startedSubUID: startedMessage}
+}
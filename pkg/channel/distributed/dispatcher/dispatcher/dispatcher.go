@@ -2,60 +2,79 @@ package dispatcher
 
 import (
 	"context"
-	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	commonkafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 )
 
 // Define A Dispatcher Config Struct To Hold Configuration
 type DispatcherConfig struct {
-	Logger          *zap.Logger
-	ClientId        string
-	Brokers         []string
-	Topic           string
-	Username        string
-	Password        string
-	ChannelKey      string
-	StatsReporter   metrics.StatsReporter
-	SaramaConfig    *sarama.Config
-	SubscriberSpecs []eventingduck.SubscriberSpec
+	Logger           *zap.Logger
+	ClientId         string
+	Brokers          []string
+	Topic            string
+	Username         string
+	Password         string
+	ChannelKey       string
+	StatsReporter    metrics.StatsReporter
+	DeliveryReporter metrics.DeliveryReporter
+	SaramaConfig     *sarama.Config
+	SubscriberSpecs  []eventingduck.SubscriberSpec
+	StatusChanged    func()                       // Optional - Called When The Subscribers' Stuck Partitions Change
+	GroupCleaner     *commonconsumer.GroupCleaner // Optional - Deletes The ConsumerGroups Of Removed Subscribers
 }
 
 // Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup
 type SubscriberWrapper struct {
 	eventingduck.SubscriberSpec
+	Options       SubscriberOptions
 	GroupId       string
 	ConsumerGroup sarama.ConsumerGroup
 	StopChan      chan struct{}
+	Handler       *Handler
+	StartedFrom   string // Description Of The Start Position Offsets Committed For A New ConsumerGroup (If Any)
 }
 
 // SubscriberWrapper Constructor
-func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string, consumerGroup sarama.ConsumerGroup) *SubscriberWrapper {
-	return &SubscriberWrapper{subscriberSpec, groupId, consumerGroup, make(chan struct{})}
+func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, options SubscriberOptions, groupId string, consumerGroup sarama.ConsumerGroup) *SubscriberWrapper {
+	return &SubscriberWrapper{subscriberSpec, options, groupId, consumerGroup, make(chan struct{}), nil, ""}
 }
 
 //  Dispatcher Interface
 type Dispatcher interface {
 	ConfigChanged(*v1.ConfigMap) Dispatcher
 	Shutdown()
-	UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error
+	UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec, subscriberOptions map[types.UID]SubscriberOptions) map[eventingduck.SubscriberSpec]error
+	SetStatusChangedFunc(statusChanged func())
+	StuckPartitions() map[types.UID]map[int32]error
+	StartPositions() map[types.UID]string
 }
 
 // Define A DispatcherImpl Struct With Configuration & ConsumerGroup State
 type DispatcherImpl struct {
 	DispatcherConfig
 	subscribers        map[types.UID]*SubscriberWrapper
+	subscriberOptions  map[types.UID]SubscriberOptions
 	consumerUpdateLock sync.Mutex
+	statusChangedLock  sync.RWMutex // Guards The StatusChanged Function Which Running Handlers Call Through The Dispatcher
 	messageDispatcher  channel.MessageDispatcher
+	syncProducer       sarama.SyncProducer // Lazily Created When A Subscriber Uses Retry Topics Or A Dead Letter Topic
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -75,17 +94,91 @@ func NewDispatcher(dispatcherConfig DispatcherConfig) Dispatcher {
 	return dispatcher
 }
 
-// Shutdown The Dispatcher
+// GroupCleaner Constructor - Shared By Successive Dispatchers So That ConfigChanged Does Not Lose Pending Deletions
+func NewGroupCleaner(dispatcherConfig DispatcherConfig, gracePeriod time.Duration) *commonconsumer.GroupCleaner {
+	return commonconsumer.NewGroupCleaner(dispatcherConfig.Logger.Sugar(), dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig, gracePeriod, func(groupId string, err error) {
+		if dispatcherConfig.DeliveryReporter != nil {
+			uid := types.UID(strings.TrimPrefix(groupId, commonkafkaconstants.ConsumerGroupIdPrefix))
+			dispatcherConfig.DeliveryReporter.ReportConsumerGroupCleanupFailure(newDeliveryTags(dispatcherConfig.ChannelKey, uid))
+		}
+	})
+}
+
+// Shutdown The Dispatcher (The ConsumerGroups Of Its Current Subscribers Are Deleted By The Controller When The KafkaChannel Is Finalized)
 func (d *DispatcherImpl) Shutdown() {
 
 	// Close ConsumerGroups Of All Subscriptions
 	for _, subscriber := range d.subscribers {
 		d.closeConsumerGroup(subscriber)
 	}
+
+	// Close The SyncProducer (If Any)
+	if d.syncProducer != nil {
+		err := d.syncProducer.Close()
+		if err != nil {
+			d.Logger.Error("Failed To Close SyncProducer", zap.Error(err))
+		}
+		d.syncProducer = nil
+	}
+}
+
+// Set The Function To Be Called When The Subscribers' Stuck Partitions Change (Carried Over By ConfigChanged)
+func (d *DispatcherImpl) SetStatusChangedFunc(statusChanged func()) {
+	d.statusChangedLock.Lock()
+	defer d.statusChangedLock.Unlock()
+	d.StatusChanged = statusChanged
+}
+
+// Get The Current StatusChanged Function (If Any)
+func (d *DispatcherImpl) statusChangedFunc() func() {
+	d.statusChangedLock.RLock()
+	defer d.statusChangedLock.RUnlock()
+	return d.StatusChanged
+}
+
+//
+// Call The Current StatusChanged Function (If Any)
+//
+// Handlers are given this function, rather than a copy of the StatusChanged function, so that a function
+// set later via SetStatusChangedFunc() reaches the already running handlers as well.  A separate lock is
+// used because handlers call it while the consumerUpdateLock may be held waiting for them to stop.
+//
+func (d *DispatcherImpl) notifyStatusChanged() {
+	if statusChanged := d.statusChangedFunc(); statusChanged != nil {
+		statusChanged()
+	}
+}
+
+// Get The Currently Stuck Partitions (& Their Delivery Errors) Of Each Subscriber With Any
+func (d *DispatcherImpl) StuckPartitions() map[types.UID]map[int32]error {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	stuckPartitions := make(map[types.UID]map[int32]error)
+	for uid, subscriber := range d.subscribers {
+		if subscriber.Handler != nil {
+			if partitions := subscriber.Handler.StuckPartitions(); len(partitions) > 0 {
+				stuckPartitions[uid] = partitions
+			}
+		}
+	}
+	return stuckPartitions
+}
+
+// Get The Description Of The Start Position Offsets Committed For Each Subscriber's New ConsumerGroup
+func (d *DispatcherImpl) StartPositions() map[types.UID]string {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	startPositions := make(map[types.UID]string)
+	for uid, subscriber := range d.subscribers {
+		if subscriber.StartedFrom != "" {
+			startPositions[uid] = subscriber.StartedFrom
+		}
+	}
+	return startPositions
 }
 
-// Update The Dispatcher's Subscriptions To Align With New State
-func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error {
+// Update The Dispatcher's Subscriptions (& Their Optional Delivery Options) To Align With New State
+func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec, subscriberOptions map[types.UID]SubscriberOptions) map[eventingduck.SubscriberSpec]error {
 
 	if d.SaramaConfig == nil {
 		d.Logger.Error("Dispatcher has no config!")
@@ -103,15 +196,50 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 	// Loop Over All All The Specified Subscribers
 	for _, subscriberSpec := range subscriberSpecs {
 
+		// Get The Subscriber's Delivery Options (Defaulting If Not Specified)
+		options, ok := subscriberOptions[subscriberSpec.UID]
+		if !ok {
+			options = DefaultSubscriberOptions()
+		}
+
+		// Restart Existing Subscribers Whose Delivery Options Or Effective Delivery Spec (Retry, Backoff, Dead Letter Sink)
+		// Have Changed (Close Failures Will Leave It Running)
+		if existingSubscriber, ok := d.subscribers[subscriberSpec.UID]; ok &&
+			(existingSubscriber.Options != options || !equality.Semantic.DeepEqual(existingSubscriber.Delivery, subscriberSpec.Delivery)) {
+			d.Logger.Info("Subscriber Delivery Changed - Restarting ConsumerGroup", zap.String("GroupId", existingSubscriber.GroupId), zap.Any("Options", options), zap.Any("Delivery", subscriberSpec.Delivery))
+			d.closeConsumerGroup(existingSubscriber)
+		}
+
 		// If The Subscriber Wrapper For The SubscriberSpec Does Not Exist Then Create One
 		if _, ok := d.subscribers[subscriberSpec.UID]; !ok {
 
 			// Format The GroupId For The Specified Subscriber
-			groupId := fmt.Sprintf("kafka.%s", subscriberSpec.UID)
+			groupId := commonkafkautil.ConsumerGroupId(string(subscriberSpec.UID))
 
 			// Create A ConsumerGroup Logger
 			logger := d.Logger.With(zap.String("GroupId", groupId))
 
+			// Cancel Any Pending Deletion Of The ConsumerGroup (Subscriber Removed & Re-Added Within The Grace Period)
+			d.GroupCleaner.Cancel(groupId)
+
+			// Ensure The SyncProducer Exists If The Subscriber Uses Retry Topics Or A Dead Letter Topic
+			if options.RetryTopics > 0 || options.DeadLetterTopic != "" {
+				err := d.createProducer()
+				if err != nil {
+					logger.Error("Failed To Create SyncProducer", zap.Error(err))
+					failedSubscriptions[subscriberSpec] = err
+					continue
+				}
+			}
+
+			// Initialize The Offsets Of A New ConsumerGroup To The Subscriber's Start Position (Existing Groups Are Untouched)
+			startedFrom, err := initializeOffsetsWrapper(d.Brokers, d.SaramaConfig, groupId, d.Topic, options.StartPosition)
+			if err != nil {
+				logger.Error("Failed To Initialize ConsumerGroup Offsets", zap.Error(err))
+				failedSubscriptions[subscriberSpec] = err
+				continue
+			}
+
 			// Attempt To Create A Kafka ConsumerGroup
 			consumerGroup, _, err := consumer.CreateConsumerGroup(d.Brokers, d.SaramaConfig, groupId)
 			if err != nil {
@@ -123,7 +251,8 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 			} else {
 
 				// Create A New SubscriberWrapper With The ConsumerGroup
-				subscriber := NewSubscriberWrapper(subscriberSpec, groupId, consumerGroup)
+				subscriber := NewSubscriberWrapper(subscriberSpec, options, groupId, consumerGroup)
+				subscriber.StartedFrom = startedFrom
 
 				// Should start observing metrics from Sarama Config.MetricsRegistry from CreateConsumerGroup() above ; )
 
@@ -145,13 +274,20 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 	// Save the current (active) subscriber specs so that ConfigChanged() can use them to recreate the Dispatcher
 	// if necessary without going through the inactive subscribers again.
 	d.SubscriberSpecs = []eventingduck.SubscriberSpec{}
+	d.subscriberOptions = make(map[types.UID]SubscriberOptions)
 
 	// Close ConsumerGroups For Removed Subscriptions (In Map But No Longer Active)
 	for _, subscriber := range d.subscribers {
 		if !activeSubscriptions[subscriber.UID] {
 			d.closeConsumerGroup(subscriber)
+
+			// Delete The Closed ConsumerGroup & Its Committed Offsets After The Grace Period
+			if _, ok := d.subscribers[subscriber.UID]; !ok {
+				d.GroupCleaner.Schedule(subscriber.GroupId)
+			}
 		} else {
 			d.SubscriberSpecs = append(d.SubscriberSpecs, subscriber.SubscriberSpec)
+			d.subscriberOptions[subscriber.UID] = subscriber.Options
 		}
 	}
 
@@ -178,7 +314,11 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 		}()
 
 		// Create A New ConsumerGroupHandler To Consume Messages With
-		handler := NewHandler(logger, &subscriber.SubscriberSpec)
+		handler := NewHandler(logger, &subscriber.SubscriberSpec, subscriber.Options, d.DeliveryReporter, d.deliveryTags(subscriber.UID), d.notifyStatusChanged)
+		if subscriber.Options.RetryTopics > 0 || subscriber.Options.DeadLetterTopic != "" {
+			handler.Producer = d.syncProducer
+		}
+		subscriber.Handler = handler
 
 		// Consume Messages Asynchronously
 		go func() {
@@ -196,7 +336,7 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 				// Start ConsumerGroup Consumption
 				default:
 					logger.Info("ConsumerGroup Message Consumption Initiated")
-					err := subscriber.ConsumerGroup.Consume(ctx, []string{d.Topic}, handler)
+					err := subscriber.ConsumerGroup.Consume(ctx, d.consumeTopics(subscriber.Options), handler)
 					if err != nil {
 						if err == sarama.ErrClosedConsumerGroup {
 							logger.Info("ConsumerGroup Closed Error - Ceasing Consumption") // Should be caught above but here as added precaution.
@@ -211,6 +351,61 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 	}
 }
 
+// Create The Shared SyncProducer If It Does Not Already Exist
+func (d *DispatcherImpl) createProducer() error {
+	if d.syncProducer == nil {
+		syncProducer, err := newSyncProducerWrapper(d.Brokers, d.SaramaConfig)
+		if err != nil {
+			return err
+		}
+		d.syncProducer = syncProducer
+	}
+	return nil
+}
+
+// Wrapper Function To Facilitate Testing With A Mock Sarama SyncProducer
+var newSyncProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	syncProducer, _, err := producer.CreateSyncProducer(brokers, config)
+	return syncProducer, err
+}
+
+// Wrapper Function To Facilitate Testing Without A Kafka Cluster
+var initializeOffsetsWrapper = func(brokers []string, config *sarama.Config, groupId string, topic string, position commonconsumer.StartPosition) (string, error) {
+	if position == "" {
+		return "", nil
+	}
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	return commonconsumer.InitializeOffsets(client, groupId, topic, position)
+}
+
+// Get The Topics To Consume For The Specified Subscriber Options (The KafkaChannel's Topic & Any Retry Topics)
+func (d *DispatcherImpl) consumeTopics(options SubscriberOptions) []string {
+	topics := []string{d.Topic}
+	for tier := 1; tier <= options.RetryTopics; tier++ {
+		topics = append(topics, commonkafkautil.RetryTopicName(d.Topic, tier))
+	}
+	return topics
+}
+
+// Get The Delivery Metrics Tags For The Specified Subscriber Of The Dispatcher's KafkaChannel
+func (d *DispatcherImpl) deliveryTags(uid types.UID) metrics.DeliveryTags {
+	return newDeliveryTags(d.ChannelKey, uid)
+}
+
+// Get The Delivery Metrics Tags For The Specified Subscriber Of The Specified KafkaChannel
+func newDeliveryTags(channelKey string, uid types.UID) metrics.DeliveryTags {
+	deliveryTags := metrics.DeliveryTags{SubscriptionUID: string(uid)}
+	if namespace, name, err := cache.SplitMetaNamespaceKey(channelKey); err == nil {
+		deliveryTags.Namespace = namespace
+		deliveryTags.Channel = name
+	}
+	return deliveryTags
+}
+
 // Close The ConsumerGroup Associated With A Single Subscriber
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 
@@ -255,35 +450,48 @@ func (d *DispatcherImpl) ConfigChanged(configMap *v1.ConfigMap) Dispatcher {
 
 	// Create A New Sarama Config
 	d.Logger.Debug("New ConfigMap Received", zap.String("configMap.Name", configMap.ObjectMeta.Name))
+	newConfig := newConsumerConfig(d.Logger, d.SaramaConfig, configMap)
+	if newConfig == nil {
+		return nil
+	}
+
+	// Create A New Dispatcher With The New Configuration (Reusing All Other Existing Config)
+	d.Logger.Info("Consumer Changes Detected In New Configuration - Recreating Dispatcher")
+	d.Shutdown()
+	d.DispatcherConfig.SaramaConfig = newConfig
+	d.GroupCleaner.SetConfig(newConfig)
+	d.DispatcherConfig.StatusChanged = d.statusChangedFunc()
+	newDispatcher := NewDispatcher(d.DispatcherConfig)
+	failedSubscriptions := newDispatcher.UpdateSubscriptions(d.SubscriberSpecs, d.subscriberOptions)
+	if len(failedSubscriptions) > 0 {
+		d.Logger.Fatal("Failed To Subscribe Kafka Subscriptions For New Dispatcher", zap.Int("Count", len(failedSubscriptions)))
+		return nil
+	}
+	return newDispatcher
+}
+
+// Create A New Sarama Config From The Specified ConfigMap, Returning Nil If It Is Invalid Or Has No Changes Relevant
+// To The ConsumerGroups Of The Current Sarama Config.
+func newConsumerConfig(logger *zap.Logger, currentConfig *sarama.Config, configMap *v1.ConfigMap) *sarama.Config {
 
 	newConfig, err := kafkasarama.MergeSaramaSettings(nil, configMap)
 	if err != nil {
-		d.Logger.Error("Unable to merge sarama settings", zap.Error(err))
+		logger.Error("Unable to merge sarama settings", zap.Error(err))
 		return nil
 	}
 
 	// Validate Configuration (Should Always Be Present)
-	if d.SaramaConfig != nil {
+	if currentConfig != nil {
 
 		// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
-		kafkasarama.UpdateSaramaConfig(newConfig, d.SaramaConfig.ClientID, d.SaramaConfig.Net.SASL.User, d.SaramaConfig.Net.SASL.Password)
+		kafkasarama.UpdateSaramaConfig(newConfig, currentConfig.ClientID, currentConfig.Net.SASL.User, currentConfig.Net.SASL.Password)
 
 		// Ignore the "Producer" section as changes to that do not require recreating the Dispatcher
-		if kafkasarama.ConfigEqual(newConfig, d.SaramaConfig, newConfig.Producer) {
-			d.Logger.Info("No Consumer Changes Detected In New Configuration - Ignoring")
+		if kafkasarama.ConfigEqual(newConfig, currentConfig, newConfig.Producer) {
+			logger.Info("No Consumer Changes Detected In New Configuration - Ignoring")
 			return nil
 		}
 	}
 
-	// Create A New Dispatcher With The New Configuration (Reusing All Other Existing Config)
-	d.Logger.Info("Consumer Changes Detected In New Configuration - Recreating Dispatcher")
-	d.Shutdown()
-	d.DispatcherConfig.SaramaConfig = newConfig
-	newDispatcher := NewDispatcher(d.DispatcherConfig)
-	failedSubscriptions := newDispatcher.UpdateSubscriptions(d.SubscriberSpecs)
-	if len(failedSubscriptions) > 0 {
-		d.Logger.Fatal("Failed To Subscribe Kafka Subscriptions For New Dispatcher", zap.Int("Count", len(failedSubscriptions)))
-		return nil
-	}
-	return newDispatcher
+	return newConfig
 }
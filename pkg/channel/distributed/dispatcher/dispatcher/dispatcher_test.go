@@ -2,6 +2,7 @@ package dispatcher
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -15,9 +16,13 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
 	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/system"
 
 	"testing"
@@ -95,11 +100,12 @@ func TestNewSubscriberWrapper(t *testing.T) {
 	consumerGroup := kafkatesting.NewMockConsumerGroup(t)
 
 	// Perform The Test
-	subscriberWrapper := NewSubscriberWrapper(subscriber, groupId, consumerGroup)
+	subscriberWrapper := NewSubscriberWrapper(subscriber, DefaultSubscriberOptions(), groupId, consumerGroup)
 
 	// Verify Results
 	assert.NotNil(t, subscriberWrapper)
 	assert.Equal(t, subscriber.UID, subscriberWrapper.UID)
+	assert.Equal(t, DefaultSubscriberOptions(), subscriberWrapper.Options)
 	assert.Equal(t, consumerGroup, subscriberWrapper.ConsumerGroup)
 	assert.Equal(t, groupId, subscriberWrapper.GroupId)
 	assert.NotNil(t, subscriberWrapper.StopChan)
@@ -118,6 +124,52 @@ func TestNewDispatcher(t *testing.T) {
 	assert.NotNil(t, dispatcher)
 }
 
+// Test The Dispatcher's SetStatusChangedFunc() & StuckPartitions() Functionality
+func TestDispatcherStuckPartitions(t *testing.T) {
+
+	// Create Test Subscribers, One Of Which Is Stuck & One Of Which Has Not Started Consuming
+	logger := logtesting.TestLogger(t).Desugar()
+	stuckErr := errors.New("test stuck error")
+	subscriber1 := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: id123}, DefaultSubscriberOptions(), "kafka.123", nil)
+	subscriber1.Handler = NewHandler(logger, &subscriber1.SubscriberSpec, subscriber1.Options, nil, metrics.DeliveryTags{}, nil)
+	subscriber2 := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: id456}, DefaultSubscriberOptions(), "kafka.456", nil)
+	subscriber2.Handler = NewHandler(logger, &subscriber2.SubscriberSpec, subscriber2.Options, nil, metrics.DeliveryTags{}, nil)
+	subscriber2.Handler.stuckPartitions.set(2, stuckErr)
+	subscriber3 := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: id789}, DefaultSubscriberOptions(), "kafka.789", nil)
+
+	// Create The Dispatcher To Test
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{Logger: logger},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			id123: subscriber1,
+			id456: subscriber2,
+			id789: subscriber3,
+		},
+	}
+
+	// Perform The Test & Verify Only The Stuck Subscriber Is Returned
+	assert.Equal(t, map[types.UID]map[int32]error{id456: {2: stuckErr}}, dispatcher.StuckPartitions())
+
+	// Verify The Status Changed Function Is Retained In The DispatcherConfig
+	statusChanged := false
+	dispatcher.SetStatusChangedFunc(func() { statusChanged = true })
+	dispatcher.StatusChanged()
+	assert.True(t, statusChanged)
+
+	// Verify A Status Changed Function Set After A Handler Was Created Reaches The Running Handler
+	handlerStatusChanged := false
+	subscriber3.Handler = NewHandler(logger, &subscriber3.SubscriberSpec, subscriber3.Options, nil, metrics.DeliveryTags{}, dispatcher.notifyStatusChanged)
+	dispatcher.SetStatusChangedFunc(func() { handlerStatusChanged = true })
+	subscriber3.Handler.stuckPartitions.set(1, stuckErr)
+	assert.True(t, handlerStatusChanged)
+}
+
+// Test The Dispatcher's deliveryTags() Functionality
+func TestDeliveryTags(t *testing.T) {
+	dispatcher := &DispatcherImpl{DispatcherConfig: DispatcherConfig{ChannelKey: "TestNamespace/TestName"}}
+	assert.Equal(t, metrics.DeliveryTags{Namespace: "TestNamespace", Channel: "TestName", SubscriptionUID: id123}, dispatcher.deliveryTags(id123))
+}
+
 // Test The Dispatcher's Shutdown() Functionality
 func TestShutdown(t *testing.T) {
 
@@ -140,9 +192,9 @@ func TestShutdown(t *testing.T) {
 			Logger: logtesting.TestLogger(t).Desugar(),
 		},
 		subscribers: map[types.UID]*SubscriberWrapper{
-			subscriber1.UID: NewSubscriberWrapper(subscriber1, groupId1, consumerGroup1),
-			subscriber2.UID: NewSubscriberWrapper(subscriber2, groupId2, consumerGroup2),
-			subscriber3.UID: NewSubscriberWrapper(subscriber3, groupId3, consumerGroup3),
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, DefaultSubscriberOptions(), groupId1, consumerGroup1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, DefaultSubscriberOptions(), groupId2, consumerGroup2),
+			subscriber3.UID: NewSubscriberWrapper(subscriber3, DefaultSubscriberOptions(), groupId3, consumerGroup3),
 		},
 	}
 
@@ -173,7 +225,8 @@ func TestUpdateSubscriptions(t *testing.T) {
 		subscribers      map[types.UID]*SubscriberWrapper
 	}
 	type args struct {
-		subscriberSpecs []eventingduck.SubscriberSpec
+		subscriberSpecs   []eventingduck.SubscriberSpec
+		subscriberOptions map[types.UID]SubscriberOptions
 	}
 	type testCase struct {
 		name   string
@@ -274,6 +327,49 @@ func TestUpdateSubscriptions(t *testing.T) {
 			},
 			want: map[eventingduck.SubscriberSpec]error{},
 		},
+		{
+			name: "Change Subscription Delivery Options",
+			fields: fields{
+				DispatcherConfig: DispatcherConfig{
+					SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+					Logger:       logtesting.TestLogger(t).Desugar(),
+				},
+				subscribers: map[types.UID]*SubscriberWrapper{
+					uid123: createSubscriberWrapper(t, uid123),
+					uid456: createSubscriberWrapper(t, uid456),
+				},
+			},
+			args: args{
+				subscriberSpecs: []eventingduck.SubscriberSpec{
+					{UID: uid123},
+					{UID: uid456},
+				},
+				subscriberOptions: map[types.UID]SubscriberOptions{
+					uid123: {Ordering: DeliveryOrderingUnordered, Concurrency: 5},
+				},
+			},
+			want: map[eventingduck.SubscriberSpec]error{},
+		},
+		{
+			name: "Change Subscription Delivery Spec",
+			fields: fields{
+				DispatcherConfig: DispatcherConfig{
+					SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+					Logger:       logtesting.TestLogger(t).Desugar(),
+				},
+				subscribers: map[types.UID]*SubscriberWrapper{
+					uid123: createSubscriberWrapper(t, uid123),
+					uid456: createSubscriberWrapper(t, uid456),
+				},
+			},
+			args: args{
+				subscriberSpecs: []eventingduck.SubscriberSpec{
+					{UID: uid123, Delivery: &eventingduck.DeliverySpec{Retry: ptr.Int32(5)}},
+					{UID: uid456},
+				},
+			},
+			want: map[eventingduck.SubscriberSpec]error{},
+		},
 	}
 
 	// Execute The Test Cases (Create A DispatcherImpl & UpdateSubscriptions() :)
@@ -299,7 +395,7 @@ func TestUpdateSubscriptions(t *testing.T) {
 			}
 
 			// Perform The Test
-			got := dispatcher.UpdateSubscriptions(tt.args.subscriberSpecs)
+			got := dispatcher.UpdateSubscriptions(tt.args.subscriberSpecs, tt.args.subscriberOptions)
 
 			// Verify Results
 			assert.Equal(t, tt.want, got)
@@ -308,6 +404,13 @@ func TestUpdateSubscriptions(t *testing.T) {
 			assert.Len(t, dispatcher.subscribers, len(tt.args.subscriberSpecs))
 			for _, subscriber := range tt.args.subscriberSpecs {
 				assert.NotNil(t, dispatcher.subscribers[subscriber.UID])
+				assert.Equal(t, subscriber.Delivery, dispatcher.subscribers[subscriber.UID].Delivery)
+				expectedOptions, ok := tt.args.subscriberOptions[subscriber.UID]
+				if !ok {
+					expectedOptions = DefaultSubscriberOptions()
+				}
+				assert.Equal(t, expectedOptions, dispatcher.subscribers[subscriber.UID].Options)
+				assert.Equal(t, expectedOptions, dispatcher.subscriberOptions[subscriber.UID])
 			}
 
 			// Shutdown The Dispatcher to Cleanup Resources
@@ -320,9 +423,208 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test The UpdateSubscriptions() Functionality With Subscribers Using Retry Topics & Dead Letter Topics
+func TestUpdateSubscriptionsSyncProducer(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Replace The SyncProducer Wrapper With Mock For Testing & Restore After Test
+	mockSyncProducer := &dispatchertesting.MockSyncProducer{}
+	producerCount := 0
+	newSyncProducerWrapperPlaceholder := newSyncProducerWrapper
+	newSyncProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		producerCount++
+		return mockSyncProducer, nil
+	}
+	defer func() { newSyncProducerWrapper = newSyncProducerWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Topic:        "TestTopic",
+			SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+			Logger:       logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+	}
+
+	// Perform The Test With A Retry Topic Subscriber, A Dead Letter Topic Subscriber & One Inline Subscriber
+	retryOptions := DefaultSubscriberOptions()
+	retryOptions.RetryTopics = 2
+	deadLetterOptions := DefaultSubscriberOptions()
+	deadLetterOptions.DeadLetterTopic = "TestDeadLetterTopic"
+	failed := dispatcher.UpdateSubscriptions(
+		[]eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}, {UID: uid789}},
+		map[types.UID]SubscriberOptions{uid123: retryOptions, uid456: deadLetterOptions})
+
+	// Verify The SyncProducer Was Created Once & Shared By The Retry Topic & Dead Letter Topic Subscribers Only
+	assert.Empty(t, failed)
+	assert.Equal(t, 1, producerCount)
+	assert.Equal(t, mockSyncProducer, dispatcher.subscribers[uid123].Handler.Producer)
+	assert.Equal(t, mockSyncProducer, dispatcher.subscribers[uid456].Handler.Producer)
+	assert.Nil(t, dispatcher.subscribers[uid789].Handler.Producer)
+
+	// Verify The Consumed Topics Include The Retry Topics
+	assert.Equal(t, []string{"TestTopic", "TestTopic.retry.1", "TestTopic.retry.2"}, dispatcher.consumeTopics(retryOptions))
+	assert.Equal(t, []string{"TestTopic"}, dispatcher.consumeTopics(DefaultSubscriberOptions()))
+
+	// Verify Shutdown Closes The SyncProducer
+	dispatcher.Shutdown()
+	assert.True(t, mockSyncProducer.Closed)
+	assert.Nil(t, dispatcher.syncProducer)
+}
+
+// Test The UpdateSubscriptions() Functionality When The SyncProducer Cannot Be Created
+func TestUpdateSubscriptionsSyncProducerError(t *testing.T) {
+
+	// Replace The SyncProducer Wrapper With Failing Mock For Testing & Restore After Test
+	producerErr := errors.New("test sync producer error")
+	newSyncProducerWrapperPlaceholder := newSyncProducerWrapper
+	newSyncProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		return nil, producerErr
+	}
+	defer func() { newSyncProducerWrapper = newSyncProducerWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+			Logger:       logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+	}
+
+	// Perform The Test
+	retryOptions := DefaultSubscriberOptions()
+	retryOptions.RetryTopics = 1
+	subscriberSpec := eventingduck.SubscriberSpec{UID: uid123}
+	failed := dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{subscriberSpec}, map[types.UID]SubscriberOptions{uid123: retryOptions})
+
+	// Verify The Subscription Failed
+	assert.Equal(t, map[eventingduck.SubscriberSpec]error{subscriberSpec: producerErr}, failed)
+	assert.Empty(t, dispatcher.subscribers)
+}
+
+// Test The UpdateSubscriptions() Functionality With Subscriber Start Positions
+func TestUpdateSubscriptionsStartPosition(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Replace The Offset Initialization Wrapper With Mock For Testing & Restore After Test
+	initializeErr := errors.New("test initialize offsets error")
+	initializeOffsetsWrapperPlaceholder := initializeOffsetsWrapper
+	initializeOffsetsWrapper = func(_ []string, _ *sarama.Config, groupId string, topic string, position commonconsumer.StartPosition) (string, error) {
+		switch position {
+		case "":
+			return "", nil
+		case commonconsumer.StartPositionLatest:
+			return "", initializeErr
+		default:
+			return fmt.Sprintf("%s %s %s", groupId, topic, position), nil
+		}
+	}
+	defer func() { initializeOffsetsWrapper = initializeOffsetsWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Topic:        "TestTopic",
+			SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+			Logger:       logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+	}
+
+	// Perform The Test With An Earliest, A Failing Latest & A Default Subscriber
+	earliestOptions := DefaultSubscriberOptions()
+	earliestOptions.StartPosition = commonconsumer.StartPositionEarliest
+	latestOptions := DefaultSubscriberOptions()
+	latestOptions.StartPosition = commonconsumer.StartPositionLatest
+	failed := dispatcher.UpdateSubscriptions(
+		[]eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}, {UID: uid789}},
+		map[types.UID]SubscriberOptions{uid123: earliestOptions, uid456: latestOptions})
+
+	// Verify The Failed Initialization Failed The Subscription & The Others Were Started
+	assert.Equal(t, map[eventingduck.SubscriberSpec]error{{UID: uid456}: initializeErr}, failed)
+	assert.Len(t, dispatcher.subscribers, 2)
+	assert.Equal(t, map[types.UID]string{uid123: "kafka.123 TestTopic earliest"}, dispatcher.StartPositions())
+	dispatcher.Shutdown()
+}
+
+// Test The Deletion Of The ConsumerGroups Of Removed Subscribers
+func TestUpdateSubscriptionsGroupCleanup(t *testing.T) {
+
+	// Create A Mock Kafka Broker Which Only Deletes The First Removed Subscriber's ConsumerGroup
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "kafka.456", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "kafka.789", broker),
+		"DeleteGroupsRequest": sarama.NewMockDeleteGroupsRequest(t).
+			SetDeletedGroups([]string{"kafka.456"}),
+	})
+
+	// Create A New DispatcherImpl With A GroupCleaner & Existing Subscribers To Test
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.V2_0_0_0
+	deliveryReporter := dispatchertesting.NewMockDeliveryReporter()
+	dispatcherConfig := DispatcherConfig{
+		Brokers:          []string{broker.Addr()},
+		SaramaConfig:     saramaConfig,
+		Logger:           logtesting.TestLogger(t).Desugar(),
+		ChannelKey:       "TestNamespace/TestName",
+		DeliveryReporter: deliveryReporter,
+	}
+	dispatcherConfig.GroupCleaner = NewGroupCleaner(dispatcherConfig, 0)
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: dispatcherConfig,
+		subscribers: map[types.UID]*SubscriberWrapper{
+			uid123: createSubscriberWrapper(t, uid123),
+			uid456: createSubscriberWrapper(t, uid456),
+			uid789: createSubscriberWrapper(t, uid789),
+		},
+	}
+	for _, subscriber := range dispatcher.subscribers {
+		subscriber.Options = DefaultSubscriberOptions()
+	}
+
+	// Perform The Test Removing Two Of The Subscribers
+	failed := dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid123}}, map[types.UID]SubscriberOptions{})
+	assert.Empty(t, failed)
+	assert.Len(t, dispatcher.subscribers, 1)
+
+	// Verify The Removed Subscribers' ConsumerGroups Were Deleted & The Failures Reported After All Attempts
+	assert.Eventually(t, func() bool {
+		return len(dispatcherConfig.GroupCleaner.Failures()) == 1 && deliveryReporter.CleanupFailed == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, dispatcherConfig.GroupCleaner.Failures(), "kafka.789")
+	deleteRequests := 0
+	for _, exchange := range broker.History() {
+		if _, ok := exchange.Request.(*sarama.DeleteGroupsRequest); ok {
+			deleteRequests++
+		}
+	}
+	assert.Equal(t, 4, deleteRequests)
+	dispatcher.Shutdown()
+}
+
 // Utility Function For Creating A SubscriberWrapper With Specified UID & Mock ConsumerGroup
 func createSubscriberWrapper(t *testing.T, uid types.UID) *SubscriberWrapper {
-	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, fmt.Sprintf("kafka.%s", string(uid)), kafkatesting.NewMockConsumerGroup(t))
+	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, DefaultSubscriberOptions(), fmt.Sprintf("kafka.%s", string(uid)), kafkatesting.NewMockConsumerGroup(t))
 }
 
 func getBaseConfigMap() *corev1.ConfigMap {
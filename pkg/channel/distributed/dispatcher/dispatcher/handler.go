@@ -3,14 +3,25 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/tracing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -26,15 +37,38 @@ var _ sarama.ConsumerGroupHandler = &Handler{}
 type Handler struct {
 	Logger            *zap.Logger
 	Subscriber        *eventingduck.SubscriberSpec
+	Options           SubscriberOptions
 	MessageDispatcher channel.MessageDispatcher
+	DeliveryReporter  metrics.DeliveryReporter
+	DeliveryTags      metrics.DeliveryTags
+	Producer          sarama.SyncProducer // Required When Options.RetryTopics > 0 Or Options.DeadLetterTopic Is Set
+	filter            *filter.Filter      // Parsed From Options.Filter (Nil Delivers All Messages)
+	stuckPartitions   *stuckPartitions
 }
 
-// Create A New Handler
-func NewHandler(logger *zap.Logger, subscriber *eventingduck.SubscriberSpec) *Handler {
+// Create A New Handler (The Optional statusChanged Function Is Called When The Handler's Stuck Partitions Change)
+func NewHandler(logger *zap.Logger,
+	subscriber *eventingduck.SubscriberSpec,
+	options SubscriberOptions,
+	deliveryReporter metrics.DeliveryReporter,
+	deliveryTags metrics.DeliveryTags,
+	statusChanged func()) *Handler {
+
+	// Parse The Subscriber's Filter (Already Validated When The Options Were Created)
+	eventFilter, err := filter.Parse(options.Filter)
+	if err != nil {
+		logger.Warn("Ignoring Invalid Filter", zap.String("Filter", options.Filter), zap.Error(err))
+	}
+
 	return &Handler{
 		Logger:            logger,
 		Subscriber:        subscriber,
+		Options:           options,
 		MessageDispatcher: newMessageDispatcherWrapper(logger),
+		DeliveryReporter:  deliveryReporter,
+		DeliveryTags:      deliveryTags,
+		filter:            eventFilter,
+		stuckPartitions:   newStuckPartitions(statusChanged),
 	}
 }
 
@@ -44,8 +78,20 @@ var newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispat
 }
 
 // ConsumerGroupHandler Lifecycle Method (Runs before any ConsumeClaims)
-func (h *Handler) Setup(_ sarama.ConsumerGroupSession) error {
-	return nil // Nothing To Do As Of Yet
+func (h *Handler) Setup(session sarama.ConsumerGroupSession) error {
+	h.stuckPartitions.retain(claimedPartitions(session)) // Partitions Still Claimed Stay Stuck On The Same Undelivered Message
+	return nil
+}
+
+// Get The Partitions Claimed By The Specified Session (Of Any Of The Subscriber's Topics)
+func claimedPartitions(session sarama.ConsumerGroupSession) map[int32]bool {
+	claimed := make(map[int32]bool)
+	for _, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			claimed[partition] = true
+		}
+	}
+	return claimed
 }
 
 // ConsumerGroupHandler Lifecycle Method (Runs after all ConsumeClaims stop but before final offset commit)
@@ -53,6 +99,11 @@ func (h *Handler) Cleanup(_ sarama.ConsumerGroupSession) error {
 	return nil // Nothing To Do As Of Yet
 }
 
+// Get The Partitions Which Are Currently Stuck On An Undeliverable Message (With The Delivery Error)
+func (h *Handler) StuckPartitions() map[int32]error {
+	return h.stuckPartitions.get()
+}
+
 // ConsumerGroupHandler Lifecycle Method (Main processing loop, must finish when claim.Messages() channel closes.)
 func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 
@@ -91,13 +142,28 @@ func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama
 		}
 	}
 
+	// Observe Each HTTP Dispatch Attempt For Delivery Metrics
+	retryConfig.CheckRetry = h.observeCheckRetry(retryConfig.CheckRetry, deadLetterURL)
+
+	// Unordered Delivery Processes Messages Concurrently
+	if h.Options.Ordering == DeliveryOrderingUnordered {
+		h.consumeClaimUnordered(session, claim, destinationURL, replyURL, deadLetterURL, &retryConfig)
+		return nil
+	}
+
 	// Pull Any Available Messages From The ConsumerGroupClaim (Until The Channel Closes)
 	for message := range claim.Messages() {
 
-		// Consume The Message (Ignore Errors - Will have already been retried and we're moving on so as not to block further Topic processing.)
-		_ = h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &retryConfig)
+		// Report The Partition's Consumer Lag
+		h.reportConsumerLag(claim, message)
+
+		// Deliver The Message Applying The FailurePolicy & Stop Delivering From The Partition If It Is Paused (Or The Session Ended)
+		if !h.deliverMessage(session, message, destinationURL, replyURL, deadLetterURL, &retryConfig) {
+			h.drainClaim(claim)
+			return nil
+		}
 
-		// Mark The Message As Having Been Consumed (Does Not Imply Successful Delivery - Only Full Retry Attempts Made)
+		// Mark The Message As Having Been Consumed (Does Not Imply Successful Delivery With The "commit" FailurePolicy)
 		session.MarkMessage(message, "")
 	}
 
@@ -105,8 +171,331 @@ func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama
 	return nil
 }
 
-// Consume A Single Message
-func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+//
+// Consume The ConsumerGroupClaim's Messages With A Bounded Number Of Concurrent Deliveries
+//
+// Messages are dispatched by up to Options.Concurrency goroutines and may complete out of
+// order.  The offsetTracker ensures only messages below the first incomplete message are
+// marked, and the concurrency slots are only released as messages are marked, so that the
+// number of unmarked messages is also bounded.  All in-flight deliveries are completed
+// before returning so that their offsets are included in the session's final commit.
+//
+func (h *Handler) consumeClaimUnordered(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) {
+
+	// Determine The Concurrency (Defensive Default)
+	concurrency := h.Options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSubscriberOptions().Concurrency
+	}
+
+	// Create The Concurrency Semaphore, Offset Tracker, In-Flight WaitGroup & Paused Channel
+	semaphore := make(chan struct{}, concurrency)
+	tracker := newOffsetTracker()
+	waitGroup := &sync.WaitGroup{}
+	pausedChan := make(chan struct{})
+	pauseOnce := &sync.Once{}
+
+	// Pull Any Available Messages From The ConsumerGroupClaim (Until The Channel Closes Or The Partition Is Paused)
+	consuming := true
+	for consuming {
+		select {
+		case <-pausedChan:
+			consuming = false
+		case message, ok := <-claim.Messages():
+			if !ok {
+				consuming = false
+				break
+			}
+
+			// Wait For An Available Concurrency Slot (Unless Paused While Waiting)
+			select {
+			case semaphore <- struct{}{}:
+			case <-pausedChan:
+				consuming = false
+				continue
+			}
+
+			// Report The Partition's Consumer Lag & Track The Message
+			h.reportConsumerLag(claim, message)
+			tracker.add(message)
+			waitGroup.Add(1)
+
+			// Deliver The Message Asynchronously
+			go func(message *sarama.ConsumerMessage) {
+				defer waitGroup.Done()
+
+				// Deliver The Message Applying The FailurePolicy - Undelivered Messages Are Never Completed & Pause The Partition
+				if !h.deliverMessage(session, message, destinationURL, replyURL, deadLetterURL, retryConfig) {
+					pauseOnce.Do(func() { close(pausedChan) })
+					return
+				}
+
+				// Mark The Highest Contiguous Completed Message & Release Its Concurrency Slots
+				released := tracker.complete(message, func(completedMessage *sarama.ConsumerMessage) {
+					session.MarkMessage(completedMessage, "")
+				})
+				for i := 0; i < released; i++ {
+					<-semaphore
+				}
+			}(message)
+		}
+	}
+
+	// Wait For All In-Flight Deliveries To Complete & Drain The Claim If It Was Paused
+	waitGroup.Wait()
+	h.drainClaim(claim)
+}
+
+//
+// Drain The ConsumerGroupClaim's Messages Without Delivering Or Marking Them Until The Session Ends
+//
+// Sarama ends the whole session as soon as any ConsumeClaim returns, which would re-balance every
+// partition of the ConsumerGroup and redeliver the undeliverable message straight away.  Draining
+// instead keeps the other claims consuming, while the committed offset of the paused partition stays
+// on the undelivered message from which the partition's next claim resumes.
+//
+func (h *Handler) drainClaim(claim sarama.ConsumerGroupClaim) {
+	for range claim.Messages() {
+	}
+}
+
+//
+// Deliver A Single Message Applying The Subscriber's FailurePolicy
+//
+// Returns true if the message should be marked (delivered, or failed with the "commit" policy),
+// and false if the partition should stop being delivered without marking the message (failed
+// with the "pause-partition" policy, or the session ended while blocked with backoff).
+//
+func (h *Handler) deliverMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) bool {
+
+	// Skip Retry Topic Messages Of Other Subscribers
+	if !h.isSubscriberMessage(message) {
+		return true
+	}
+
+	// Skip (And Mark) Messages Excluded By The Subscriber's Filter
+	if !h.isFilterMatch(message) {
+		return true
+	}
+
+	// Wait Until Any Retry Topic Message Is Due (Stop Consuming Without Marking If The Session Ends First)
+	if !h.waitUntilDue(session, message) {
+		return false
+	}
+
+	// Consume The Message (Will Have Already Been Retried As Per The Subscriber's DeliverySpec Or Retry Topics)
+	err := h.deliverOrDeadLetter(message, destinationURL, replyURL, deadLetterURL, retryConfig)
+	if err == nil {
+		h.stuckPartitions.clear(message.Partition)
+		return true
+	}
+
+	// Create A Logger With The Message's Partition & Offset
+	logger := h.Logger.With(zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset), zap.Error(err))
+
+	// Apply The FailurePolicy
+	switch h.Options.FailurePolicy {
+
+	case FailurePolicyPausePartition:
+		logger.Error("Failed To Deliver Message - Pausing Partition")
+		h.stuckPartitions.set(message.Partition, err)
+		return false
+
+	case FailurePolicyBlockWithBackoff:
+		logger.Error("Failed To Deliver Message - Blocking Partition With Backoff")
+		h.stuckPartitions.set(message.Partition, err)
+		backoff := constants.FailureBackoffInitial
+		for {
+			select {
+			case <-session.Context().Done():
+				logger.Info("ConsumerGroup Session Ended While Blocked On Message - Ceasing Delivery")
+				return false
+			case <-time.After(backoff):
+			}
+			err = h.deliverOrDeadLetter(message, destinationURL, replyURL, deadLetterURL, retryConfig)
+			if err == nil {
+				logger.Info("Successfully Delivered Blocked Message")
+				h.stuckPartitions.clear(message.Partition)
+				return true
+			}
+			h.stuckPartitions.set(message.Partition, err)
+			backoff *= 2
+			if backoff > constants.FailureBackoffMax {
+				backoff = constants.FailureBackoffMax
+			}
+		}
+
+	default:
+		logger.Warn("Failed To Deliver Message - Committing Offset As Per FailurePolicy")
+		return true
+	}
+}
+
+// Attempt Delivery Of A Single Message & Write It To The Dead Letter Topic (If Any) If Undeliverable
+func (h *Handler) deliverOrDeadLetter(message *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+
+	// Attempt Delivery & Return Success Or Failure If There Is No Dead Letter Topic
+	attempts, err := h.attemptDelivery(message, destinationURL, replyURL, deadLetterURL, retryConfig)
+	if err == nil || h.Options.DeadLetterTopic == "" || h.Producer == nil {
+		return err
+	}
+
+	// Produce The Undeliverable Message (Without Any Retry Topic Headers) To The Dead Letter Topic
+	channelTopic, _ := commonkafkautil.ParseRetryTopicName(message.Topic)
+	originalMessage := *message
+	originalMessage.Headers = nil
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) != constants.RetrySubscriberHeader && string(header.Key) != constants.RetryNotBeforeHeader {
+			originalMessage.Headers = append(originalMessage.Headers, header)
+		}
+	}
+	_, _, produceErr := h.Producer.SendMessage(dlq.NewDeadLetterMessage(&originalMessage, h.Options.DeadLetterTopic, channelTopic, h.Subscriber.UID, attempts, err))
+	if produceErr != nil {
+		h.Logger.Error("Failed To Produce Message To Dead Letter Topic", zap.String("DeadLetterTopic", h.Options.DeadLetterTopic), zap.Error(produceErr))
+		return fmt.Errorf("%v (failed to produce to dead letter topic: %v)", err, produceErr)
+	}
+	h.Logger.Warn("Failed To Deliver Message - Produced To Dead Letter Topic", zap.String("DeadLetterTopic", h.Options.DeadLetterTopic), zap.Int("Attempts", attempts), zap.Error(err))
+	if h.DeliveryReporter != nil {
+		h.DeliveryReporter.ReportDeadLetter(h.DeliveryTags)
+	}
+	return nil
+}
+
+//
+// Attempt Delivery Of A Single Message Via The Retry Topics If Enabled, Otherwise Inline
+//
+// With retry topics the message is dispatched once (without inline retries) and, if that
+// fails, produced to the next retry topic tier to be redelivered after that tier's delay.
+// Only the final tier dispatches with the DeadLetterSink, so that retrying never blocks the
+// partition.  Failing to produce to the next tier is returned as a delivery failure.  The number
+// of attempts made to the subscriber (including those of any previous tiers) is also returned.
+//
+func (h *Handler) attemptDelivery(message *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) (int, error) {
+
+	// Consume Inline If Retry Topics Are Not Enabled
+	if h.Options.RetryTopics <= 0 || h.Producer == nil {
+		return h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, retryConfig)
+	}
+
+	// Dispatch Once (Final Tier Falls Back To The DeadLetterSink)
+	topicName, tier := commonkafkautil.ParseRetryTopicName(message.Topic)
+	noRetriesConfig := kncloudevents.NoRetries()
+	if tier >= h.Options.RetryTopics {
+		noRetriesConfig.CheckRetry = h.observeCheckRetry(nil, deadLetterURL)
+		attempts, err := h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &noRetriesConfig)
+		return tier + attempts, err
+	}
+	noRetriesConfig.CheckRetry = h.observeCheckRetry(nil, nil)
+	attempts, err := h.consumeMessage(message, destinationURL, replyURL, nil, &noRetriesConfig)
+	if err == nil {
+		return tier + attempts, nil
+	}
+
+	// Produce The Failed Message To The Next Retry Topic Tier
+	nextTier := tier + 1
+	notBefore := time.Now().Add(h.Options.RetryTopicDelay * time.Duration(1<<uint(tier)))
+	retryTopicName := commonkafkautil.RetryTopicName(topicName, nextTier)
+	_, _, produceErr := h.Producer.SendMessage(h.newRetryMessage(message, retryTopicName, notBefore))
+	if produceErr != nil {
+		h.Logger.Error("Failed To Produce Message To Retry Topic", zap.String("RetryTopic", retryTopicName), zap.Error(produceErr))
+		return tier + attempts, produceErr
+	}
+	h.Logger.Info("Failed To Deliver Message - Produced To Retry Topic", zap.String("RetryTopic", retryTopicName), zap.Error(err))
+	if h.DeliveryReporter != nil {
+		h.DeliveryReporter.ReportRetry(h.DeliveryTags)
+	}
+	return tier + attempts, nil
+}
+
+// Create A ProducerMessage For The Specified Retry Topic From The Specified ConsumerMessage (Replacing Any Retry Headers)
+func (h *Handler) newRetryMessage(message *sarama.ConsumerMessage, retryTopicName string, notBefore time.Time) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+2)
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) != constants.RetrySubscriberHeader && string(header.Key) != constants.RetryNotBeforeHeader {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(constants.RetrySubscriberHeader), Value: []byte(h.Subscriber.UID)},
+		sarama.RecordHeader{Key: []byte(constants.RetryNotBeforeHeader), Value: []byte(strconv.FormatInt(notBefore.UnixNano()/int64(time.Millisecond), 10))})
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   retryTopicName,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(message.Key)
+	}
+	return producerMessage
+}
+
+// Determine Whether The Specified Message Is For This Subscriber (Retry Topics Are Shared By All Subscribers)
+func (h *Handler) isSubscriberMessage(message *sarama.ConsumerMessage) bool {
+	if _, tier := commonkafkautil.ParseRetryTopicName(message.Topic); tier == 0 {
+		return true
+	}
+	subscriberUID, _ := retryHeader(message, constants.RetrySubscriberHeader)
+	return subscriberUID == string(h.Subscriber.UID)
+}
+
+// Determine Whether The Specified Message Matches The Subscriber's Filter (Invalid Events Are Delivered To Fail As Usual)
+func (h *Handler) isFilterMatch(message *sarama.ConsumerMessage) bool {
+	if h.filter == nil {
+		return true
+	}
+	match, err := h.filter.Match(context.Background(), kafkasaramaprotocol.NewMessageFromConsumerMessage(message))
+	if err != nil {
+		h.Logger.Warn("Failed To Evaluate Filter - Delivering Message", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset), zap.Error(err))
+		return true
+	}
+	if !match {
+		h.Logger.Debug("Message Excluded By Filter - Skipping", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset))
+		if h.DeliveryReporter != nil {
+			h.DeliveryReporter.ReportFiltered(h.DeliveryTags)
+		}
+	}
+	return match
+}
+
+// Wait Until The Specified Retry Topic Message's Not-Before Time, Returning False If The Session Ended First
+func (h *Handler) waitUntilDue(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) bool {
+	if _, tier := commonkafkautil.ParseRetryTopicName(message.Topic); tier == 0 {
+		return true
+	}
+	notBeforeString, ok := retryHeader(message, constants.RetryNotBeforeHeader)
+	if !ok {
+		return true
+	}
+	notBeforeMillis, err := strconv.ParseInt(notBeforeString, 10, 64)
+	if err != nil {
+		h.Logger.Warn("Ignoring Invalid Retry Not-Before Header", zap.String("NotBefore", notBeforeString))
+		return true
+	}
+	delay := time.Until(time.Unix(0, notBeforeMillis*int64(time.Millisecond)))
+	if delay <= 0 {
+		return true
+	}
+	select {
+	case <-session.Context().Done():
+		h.Logger.Info("ConsumerGroup Session Ended While Waiting For Retry - Ceasing Delivery", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset))
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+// Get The Value Of The Specified Retry Header From The Specified Message
+func retryHeader(message *sarama.ConsumerMessage, key string) (string, bool) {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
+
+// Consume A Single Message & Return The Number Of Attempts Made To The Subscriber
+func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) (int, error) {
 
 	// Debug Log Kafka ConsumerMessage
 	h.Logger.Debug("Consuming Kafka Message",
@@ -121,11 +510,38 @@ func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destin
 	message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
 	if message.ReadEncoding() == binding.EncodingUnknown {
 		h.Logger.Warn("Received A Message With Unknown Encoding - Skipping")
-		return errors.New("received a message with unknown encoding - skipping")
+		return 0, errors.New("received a message with unknown encoding - skipping")
 	}
 
-	// Dispatch The Message With Configured Retries & Return Any Errors
-	return h.MessageDispatcher.DispatchMessageWithRetries(context.Background(), message, nil, destinationURL, replyURL, deadLetterURL, retryConfig)
+	// Continue The Trace From The Receiver (If Any) With A Span Covering This Subscriber's Delivery
+	ctx, span := tracing.StartTraceFromMessage(context.Background(), consumerMessage)
+	defer span.End()
+	span.AddAttributes(
+		trace.StringAttribute("subscriber.uid", string(h.Subscriber.UID)),
+		trace.Int64Attribute("kafka.partition", int64(consumerMessage.Partition)),
+		trace.Int64Attribute("kafka.offset", consumerMessage.Offset))
+
+	// Track The Delivery's HTTP Dispatch Attempts (Observed By The CheckRetry Function) For Delivery Metrics
+	ctx = context.WithValue(ctx, dispatchAttemptsKey{}, newDispatchAttempts())
+
+	// Count The Attempts Made To The Subscriber (At Least One When Dispatched)
+	var attempts int32
+	countingRetryConfig := *retryConfig
+	countingRetryConfig.CheckRetry = dlq.CountAttempts(retryConfig.CheckRetry, destinationURL, &attempts)
+
+	// Dispatch The Message With Configured Retries & Return Any Errors (Recorded On The Span & In The Latency Metric)
+	startTime := time.Now()
+	err := h.MessageDispatcher.DispatchMessageWithRetries(ctx, message, nil, destinationURL, replyURL, deadLetterURL, &countingRetryConfig)
+	if h.DeliveryReporter != nil {
+		h.DeliveryReporter.ReportDispatchLatency(h.DeliveryTags, err == nil, time.Since(startTime))
+	}
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	if attempts == 0 {
+		attempts = 1
+	}
+	return int(attempts), err
 }
 
 //
@@ -172,3 +588,78 @@ func (h *Handler) checkRetry(_ context.Context, response *http.Response, err err
 	// Do Not Retry 1XX, 2XX, & Most 4XX StatusCode Responses
 	return false, nil
 }
+
+// Context Key For The Dispatch Attempts Of A Single Delivery
+type dispatchAttemptsKey struct{}
+
+// The Number Of HTTP Dispatch Attempts Made To Each URL During A Single Delivery
+type dispatchAttempts struct {
+	mutex    sync.Mutex
+	attempts map[string]int
+}
+
+// Create A New Dispatch Attempts Tracker
+func newDispatchAttempts() *dispatchAttempts {
+	return &dispatchAttempts{attempts: make(map[string]int)}
+}
+
+// Track An Attempt To The Specified URL & Return The Total Number Of Attempts To It
+func (d *dispatchAttempts) add(url string) int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.attempts[url]++
+	return d.attempts[url]
+}
+
+//
+// Wrap The Specified CheckRetry Function To Report Delivery Metrics For Each HTTP Dispatch Attempt
+//
+// The CheckRetry function is called with the request's context after every attempt (to the
+// subscriber, reply, or DeadLetterSink) which makes it the one place that sees all response
+// codes.  Repeated attempts to the same URL within a delivery are retries, and successful
+// attempts to the DeadLetterSink are dead-lettered messages.
+//
+func (h *Handler) observeCheckRetry(checkRetry kncloudevents.CheckRetry, deadLetterURL *url.URL) kncloudevents.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		if h.DeliveryReporter != nil {
+
+			// Determine The Response Code & Target URL Of The Attempt
+			responseCode := -1
+			var targetURL *url.URL
+			if response != nil {
+				responseCode = response.StatusCode
+				if response.Request != nil {
+					targetURL = response.Request.URL
+				}
+			} else if urlErr, ok := err.(*url.Error); ok {
+				targetURL, _ = url.Parse(urlErr.URL)
+			}
+
+			// Report The Attempt, Any Retry, & Any Successful Dead Lettering
+			h.DeliveryReporter.ReportDispatch(h.DeliveryTags, responseCode)
+			if attempts, ok := ctx.Value(dispatchAttemptsKey{}).(*dispatchAttempts); ok && targetURL != nil {
+				if attempts.add(targetURL.String()) > 1 {
+					h.DeliveryReporter.ReportRetry(h.DeliveryTags)
+				}
+			}
+			if deadLetterURL != nil && targetURL != nil && targetURL.String() == deadLetterURL.String() && responseCode >= 200 && responseCode <= 299 {
+				h.DeliveryReporter.ReportDeadLetter(h.DeliveryTags)
+			}
+		}
+		if checkRetry == nil {
+			return false, nil
+		}
+		return checkRetry(ctx, response, err)
+	}
+}
+
+// Report The Consumer Lag Of The Specified Message's Partition (Messages Produced After It)
+func (h *Handler) reportConsumerLag(claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) {
+	if h.DeliveryReporter != nil {
+		lag := claim.HighWaterMarkOffset() - message.Offset - 1
+		if lag < 0 {
+			lag = 0
+		}
+		h.DeliveryReporter.ReportConsumerLag(h.DeliveryTags, message.Partition, lag)
+	}
+}
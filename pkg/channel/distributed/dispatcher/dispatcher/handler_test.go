@@ -5,16 +5,25 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Shopify/sarama"
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/tracing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -26,6 +35,8 @@ import (
 // Test Data
 const (
 	testSubscriberUID        = types.UID("123")
+	testChannelNamespace     = "TestChannelNamespace"
+	testChannelName          = "TestChannelName"
 	testSubscriberURIString  = "https://www.foo.bar/test/path"
 	testReplyURIString       = "https://www.something.com/"
 	testDeadLetterURIString  = "https://www.made.up/url"
@@ -60,7 +71,16 @@ func TestNewHandler(t *testing.T) {
 // Test The Handler's Setup() Functionality
 func TestHandlerSetup(t *testing.T) {
 	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
-	assert.Nil(t, handler.Setup(nil))
+	assert.Nil(t, handler.Setup(dispatchertesting.NewMockConsumerGroupSession(t)))
+
+	// Partitions Which Are Still Claimed Stay Stuck, Others Are Cleared
+	stuckErr := errors.New("test stuck error")
+	handler.stuckPartitions.set(0, stuckErr)
+	handler.stuckPartitions.set(1, stuckErr)
+	session := dispatchertesting.NewMockConsumerGroupSession(t)
+	session.Claimed = map[string][]int32{testTopic: {1, 2}}
+	assert.Nil(t, handler.Setup(session))
+	assert.Equal(t, map[int32]error{1: stuckErr}, handler.StuckPartitions())
 }
 
 // Test The Handler's Cleanup() Functionality
@@ -177,6 +197,7 @@ func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, dead
 	// Create Mocks For Testing
 	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
 	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockConsumerGroupClaim.HighWaterMark = testOffset + 5
 	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, destinationUrl, replyUrl, deadLetterUrl, &retryConfig, nil)
 
 	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
@@ -209,6 +230,575 @@ func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, dead
 	assert.Equal(t, consumerMessage, markedMessage)
 	assert.NotNil(t, mockMessageDispatcher.Message())
 	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+
+	// Verify The Delivery Metrics Were Reported
+	mockDeliveryReporter := handler.DeliveryReporter.(*dispatchertesting.MockDeliveryReporter)
+	assert.Equal(t, map[int32]int64{testPartition: 4}, mockDeliveryReporter.ConsumerLag)
+	assert.Equal(t, []bool{true}, mockDeliveryReporter.Latencies)
+}
+
+// Test The Handler's observeCheckRetry() Delivery Metrics Functionality
+func TestHandlerObserveCheckRetry(t *testing.T) {
+
+	// Test Data
+	subscriberUrl := testSubscriberURI.URL()
+	deadLetterUrl := testDeadLetterURI.URL()
+	newResponse := func(requestUrl *url.URL, statusCode int) *http.Response {
+		return &http.Response{StatusCode: statusCode, Request: &http.Request{URL: requestUrl}}
+	}
+
+	// Create The Handler & A Wrapped CheckRetry Function Which Always Retries
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	mockDeliveryReporter := handler.DeliveryReporter.(*dispatchertesting.MockDeliveryReporter)
+	checkRetry := handler.observeCheckRetry(func(_ context.Context, _ *http.Response, _ error) (bool, error) { return true, nil }, deadLetterUrl)
+	ctx := context.WithValue(context.TODO(), dispatchAttemptsKey{}, newDispatchAttempts())
+
+	// Perform The Test (Two Failed Subscriber Attempts, A Connection Failure, & A Successful DeadLetterSink Attempt)
+	retry, err := checkRetry(ctx, newResponse(subscriberUrl, 500), nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	_, _ = checkRetry(ctx, newResponse(subscriberUrl, 503), nil)
+	_, _ = checkRetry(ctx, nil, &url.Error{Op: "Post", URL: subscriberUrl.String(), Err: errors.New("connection refused")})
+	_, _ = checkRetry(ctx, newResponse(deadLetterUrl, 202), nil)
+
+	// Verify The Results
+	assert.Equal(t, []int{500, 503, -1, 202}, mockDeliveryReporter.ResponseCodes)
+	assert.Equal(t, 2, mockDeliveryReporter.Retries)
+	assert.Equal(t, 1, mockDeliveryReporter.DeadLetters)
+
+	// Verify A Nil CheckRetry Function Does Not Retry
+	retry, err = handler.observeCheckRetry(nil, nil)(context.TODO(), newResponse(subscriberUrl, 500), nil)
+	assert.False(t, retry)
+	assert.Nil(t, err)
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Unordered Delivery
+func TestHandlerConsumeClaimUnordered(t *testing.T) {
+
+	// Create A Blocking MessageDispatcher For Controlling Message Completion Order
+	blockingMessageDispatcher := newBlockingMessageDispatcher("1", "2", "3")
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return blockingMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Unordered Handler To Test
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	handler.Options = SubscriberOptions{Ordering: DeliveryOrderingUnordered, Concurrency: 3}
+
+	// Background Start Consuming Claims
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	consumeClaimDone := make(chan struct{})
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+		close(consumeClaimDone)
+	}()
+
+	// Send Three Messages (All Of Which Fit Within The Concurrency Limit)
+	for offset := int64(1); offset <= 3; offset++ {
+		mockConsumerGroupClaim.MessageChan <- createConsumerMessageWithOffset(t, offset)
+	}
+
+	// Complete The Later Messages First & Verify Nothing Is Marked (First Message Still Incomplete)
+	blockingMessageDispatcher.release("3")
+	blockingMessageDispatcher.release("2")
+	select {
+	case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+		assert.Fail(t, "Unexpected Message Marked Before First Message Completed", "Offset %d", markedMessage.Offset)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Complete The First Message & Verify The Highest Offset Is Marked
+	blockingMessageDispatcher.release("1")
+	markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+	assert.Equal(t, int64(3), markedMessage.Offset)
+
+	// Close The Mock ConsumerGroupClaim Message Channel & Verify ConsumeClaim() Completes
+	close(mockConsumerGroupClaim.MessageChan)
+	select {
+	case <-consumeClaimDone:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ConsumeClaim() Did Not Complete")
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With The Various FailurePolicies
+func TestHandlerConsumeClaimFailurePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only          bool
+		name          string
+		options       SubscriberOptions
+		failures      int32 // Number Of Failed Deliveries Before Succeeding (-1 For Always)
+		endSession    bool
+		expectMarked  bool
+		expectStuck   bool
+		expectChanges int32
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Commit",
+			options:      SubscriberOptions{Ordering: DeliveryOrderingOrdered, FailurePolicy: FailurePolicyCommit},
+			failures:     -1,
+			expectMarked: true,
+		},
+		{
+			name:          "Pause Partition",
+			options:       SubscriberOptions{Ordering: DeliveryOrderingOrdered, FailurePolicy: FailurePolicyPausePartition},
+			failures:      -1,
+			expectStuck:   true,
+			expectChanges: 1,
+		},
+		{
+			name:          "Pause Partition Unordered",
+			options:       SubscriberOptions{Ordering: DeliveryOrderingUnordered, Concurrency: 3, FailurePolicy: FailurePolicyPausePartition},
+			failures:      -1,
+			expectStuck:   true,
+			expectChanges: 1,
+		},
+		{
+			name:          "Block With Backoff Recovers",
+			options:       SubscriberOptions{Ordering: DeliveryOrderingOrdered, FailurePolicy: FailurePolicyBlockWithBackoff},
+			failures:      1,
+			expectMarked:  true,
+			expectChanges: 2,
+		},
+		{
+			name:          "Block With Backoff Session Ended",
+			options:       SubscriberOptions{Ordering: DeliveryOrderingOrdered, FailurePolicy: FailurePolicyBlockWithBackoff},
+			failures:      -1,
+			endSession:    true,
+			expectStuck:   true,
+			expectChanges: 1,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Mock The newMessageDispatcherWrapper Function With A Failing MessageDispatcher (And Restore Post-Test)
+			failingMessageDispatcher := &failingMessageDispatcher{failures: testCase.failures}
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return failingMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With The Specified Options & A Counting Status Changed Function
+			var statusChanges int32
+			handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+			handler.Options = testCase.options
+			handler.stuckPartitions = newStuckPartitions(func() { atomic.AddInt32(&statusChanges, 1) })
+
+			// Background Start Consuming Claims (Ending The Session When ConsumeClaim() Returns As Sarama Does)
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			consumeClaimDone := make(chan struct{})
+			go func() {
+				err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+				assert.Nil(t, err)
+				mockConsumerGroupSession.End()
+				close(consumeClaimDone)
+			}()
+
+			// Send A Message & Optionally End The Session (Closing The Claim's Messages) Once It Is Stuck
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+			if testCase.endSession {
+				assert.Eventually(t, func() bool { return len(handler.StuckPartitions()) > 0 }, 5*time.Second, 10*time.Millisecond)
+				mockConsumerGroupSession.End()
+				close(mockConsumerGroupClaim.MessageChan)
+			}
+
+			// Verify A Paused Partition Drains Further Messages Without Delivering Them Or Ending The Session
+			if testCase.expectStuck && !testCase.endSession {
+				assert.Eventually(t, func() bool { return len(handler.StuckPartitions()) > 0 }, 5*time.Second, 10*time.Millisecond)
+				calls := atomic.LoadInt32(&failingMessageDispatcher.calls)
+				for i := 0; i < 3; i++ {
+					select {
+					case mockConsumerGroupClaim.MessageChan <- createConsumerMessage(t):
+					case <-consumeClaimDone:
+						assert.Fail(t, "ConsumeClaim() Returned While Paused")
+					case <-time.After(5 * time.Second):
+						assert.Fail(t, "Paused Partition Was Not Drained")
+					}
+				}
+				assert.Nil(t, mockConsumerGroupSession.Context().Err())
+				if testCase.options.Ordering == DeliveryOrderingOrdered {
+					assert.Equal(t, calls, atomic.LoadInt32(&failingMessageDispatcher.calls)) // Unordered Deliveries May Already Be In-Flight
+				}
+				close(mockConsumerGroupClaim.MessageChan)
+			}
+
+			// Verify The Message Was Marked (Or Not) & ConsumeClaim() Completes
+			if testCase.expectMarked {
+				select {
+				case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+					assert.Equal(t, consumerMessage, markedMessage)
+				case <-time.After(5 * time.Second):
+					assert.Fail(t, "Message Was Not Marked")
+				}
+				close(mockConsumerGroupClaim.MessageChan)
+			}
+			select {
+			case <-consumeClaimDone:
+			case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+				assert.Fail(t, "Unexpected Message Marked", "Offset %d", markedMessage.Offset)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "ConsumeClaim() Did Not Complete")
+			}
+
+			// Verify The Stuck Partitions & Status Changes
+			stuckPartitions := handler.StuckPartitions()
+			if testCase.expectStuck {
+				assert.Len(t, stuckPartitions, 1)
+				assert.NotNil(t, stuckPartitions[testPartition])
+			} else {
+				assert.Empty(t, stuckPartitions)
+			}
+			assert.Equal(t, testCase.expectChanges, atomic.LoadInt32(&statusChanges))
+
+			// Verify A New Session Still Claiming The Partition Keeps It Stuck, And One Which Doesn't Clears It
+			claimingSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			claimingSession.Claimed = map[string][]int32{testTopic: {testPartition}}
+			assert.Nil(t, handler.Setup(claimingSession))
+			assert.Equal(t, stuckPartitions, handler.StuckPartitions())
+			assert.Nil(t, handler.Setup(dispatchertesting.NewMockConsumerGroupSession(t)))
+			assert.Empty(t, handler.StuckPartitions())
+		})
+	}
+}
+
+// Test The Handler's Delivery Via Retry Topics
+func TestHandlerAttemptDeliveryRetryTopics(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name              string
+		topic             string
+		failures          int32
+		produceErr        error
+		expectErr         bool
+		expectRetryTopic  string
+		expectDeadLetter  bool
+		expectRetryReport bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:     "Main Topic Delivered",
+			topic:    testTopic,
+			failures: 0,
+		},
+		{
+			name:              "Main Topic Failed",
+			topic:             testTopic,
+			failures:          -1,
+			expectRetryTopic:  testTopic + ".retry.1",
+			expectRetryReport: true,
+		},
+		{
+			name:              "Intermediate Tier Failed",
+			topic:             testTopic + ".retry.1",
+			failures:          -1,
+			expectRetryTopic:  testTopic + ".retry.2",
+			expectRetryReport: true,
+		},
+		{
+			name:             "Final Tier Failed",
+			topic:            testTopic + ".retry.2",
+			failures:         -1,
+			expectErr:        true,
+			expectDeadLetter: true,
+		},
+		{
+			name:       "Produce Failed",
+			topic:      testTopic,
+			failures:   -1,
+			produceErr: errors.New("test produce failure"),
+			expectErr:  true,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Handler To Test With Two Retry Topics & A Mock Retry Producer
+			handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+			handler.Options.RetryTopics = 2
+			handler.Options.RetryTopicDelay = time.Minute
+			failingMessageDispatcher := &failingMessageDispatcher{failures: testCase.failures}
+			handler.MessageDispatcher = failingMessageDispatcher
+			mockSyncProducer := &dispatchertesting.MockSyncProducer{Err: testCase.produceErr}
+			handler.Producer = mockSyncProducer
+
+			// Perform The Test
+			consumerMessage := createConsumerMessage(t)
+			consumerMessage.Topic = testCase.topic
+			deadLetterURL := testDeadLetterURI.URL()
+			retryConfig := kncloudevents.NoRetries()
+			startTime := time.Now()
+			_, err := handler.attemptDelivery(consumerMessage, testSubscriberURI.URL(), testReplyURI.URL(), deadLetterURL, &retryConfig)
+
+			// Verify The Results
+			assert.Equal(t, testCase.expectErr, err != nil)
+			if testCase.expectDeadLetter {
+				assert.Equal(t, deadLetterURL, failingMessageDispatcher.deadLetterURL)
+			} else {
+				assert.Nil(t, failingMessageDispatcher.deadLetterURL)
+			}
+			if testCase.expectRetryTopic == "" {
+				assert.Empty(t, mockSyncProducer.Messages)
+			} else {
+				assert.Len(t, mockSyncProducer.Messages, 1)
+				retryMessage := mockSyncProducer.Messages[0]
+				assert.Equal(t, testCase.expectRetryTopic, retryMessage.Topic)
+				retryConsumerMessage := &sarama.ConsumerMessage{Topic: retryMessage.Topic}
+				for i := range retryMessage.Headers {
+					retryConsumerMessage.Headers = append(retryConsumerMessage.Headers, &retryMessage.Headers[i])
+				}
+				assert.Len(t, retryConsumerMessage.Headers, len(consumerMessage.Headers)+2)
+				subscriberUID, _ := retryHeader(retryConsumerMessage, constants.RetrySubscriberHeader)
+				assert.Equal(t, string(testSubscriberUID), subscriberUID)
+				notBeforeString, _ := retryHeader(retryConsumerMessage, constants.RetryNotBeforeHeader)
+				notBeforeMillis, err := strconv.ParseInt(notBeforeString, 10, 64)
+				assert.Nil(t, err)
+				_, tier := commonkafkautil.ParseRetryTopicName(testCase.topic)
+				expectedDelay := time.Minute * time.Duration(1<<uint(tier))
+				assert.True(t, notBeforeMillis >= startTime.Add(expectedDelay).UnixNano()/int64(time.Millisecond))
+			}
+			deliveryReporter := handler.DeliveryReporter.(*dispatchertesting.MockDeliveryReporter)
+			if testCase.expectRetryReport {
+				assert.Equal(t, 1, deliveryReporter.Retries)
+			} else {
+				assert.Equal(t, 0, deliveryReporter.Retries)
+			}
+		})
+	}
+}
+
+// Test The Handler's Filtering & Delaying Of Retry Topic Messages
+func TestHandlerRetryTopicMessages(t *testing.T) {
+
+	// Create The Handler To Test
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	session := dispatchertesting.NewMockConsumerGroupSession(t)
+
+	// Utility Function For Creating A Retry Topic Message
+	newRetryMessage := func(subscriberUID types.UID, notBefore time.Time) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Topic: testTopic + ".retry.1",
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte(constants.RetrySubscriberHeader), Value: []byte(subscriberUID)},
+				{Key: []byte(constants.RetryNotBeforeHeader), Value: []byte(strconv.FormatInt(notBefore.UnixNano()/int64(time.Millisecond), 10))},
+			},
+		}
+	}
+
+	// Verify Main Topic Messages & Retry Topic Messages Of This Subscriber Are Delivered
+	assert.True(t, handler.isSubscriberMessage(createConsumerMessage(t)))
+	assert.True(t, handler.isSubscriberMessage(newRetryMessage(testSubscriberUID, time.Now())))
+	assert.False(t, handler.isSubscriberMessage(newRetryMessage("OtherSubscriberUID", time.Now())))
+
+	// Verify Due Messages Are Not Delayed
+	assert.True(t, handler.waitUntilDue(session, createConsumerMessage(t)))
+	assert.True(t, handler.waitUntilDue(session, newRetryMessage(testSubscriberUID, time.Now().Add(-time.Second))))
+
+	// Verify Messages Which Are Not Yet Due Are Delayed
+	startTime := time.Now()
+	assert.True(t, handler.waitUntilDue(session, newRetryMessage(testSubscriberUID, startTime.Add(100*time.Millisecond))))
+	assert.True(t, time.Since(startTime) >= 90*time.Millisecond)
+
+	// Verify Waiting Stops When The Session Ends
+	session.End()
+	assert.False(t, handler.waitUntilDue(session, newRetryMessage(testSubscriberUID, time.Now().Add(time.Hour))))
+}
+
+// Test The Handler's Filtering Of Messages By CloudEvent Attributes
+func TestHandlerFilter(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name      string
+		filter    string
+		wantMatch bool
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{name: "No Filter", filter: "", wantMatch: true},
+		{name: "Exact Match", filter: `{"exact":{"type":"` + testMsgType + `"}}`, wantMatch: true},
+		{name: "Exact Mismatch", filter: `{"exact":{"type":"OtherType"}}`, wantMatch: false},
+		{name: "Prefix Match", filter: `{"prefix":{"source":"TestMsg"}}`, wantMatch: true},
+		{name: "Prefix Mismatch", filter: `{"prefix":{"source":"Other"}}`, wantMatch: false},
+		{name: "Extension Match", filter: `{"exact":{"eventtypeversion":"` + testMsgEventTypeVersion + `"}}`, wantMatch: true},
+		{name: "Missing Extension", filter: `{"exact":{"missing":"value"}}`, wantMatch: false},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Handler With The Filter & A Mock MessageDispatcher
+			options := DefaultSubscriberOptions()
+			options.Filter = testCase.filter
+			deliveryReporter := dispatchertesting.NewMockDeliveryReporter()
+			handler := NewHandler(logtesting.TestLogger(t).Desugar(), &eventingduck.SubscriberSpec{UID: testSubscriberUID, SubscriberURI: testSubscriberURI}, options, deliveryReporter, metrics.DeliveryTags{}, nil)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, nil)
+			handler.MessageDispatcher = mockMessageDispatcher
+
+			// Perform The Test
+			session := dispatchertesting.NewMockConsumerGroupSession(t)
+			marked := handler.deliverMessage(session, createConsumerMessage(t), testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{})
+
+			// Verify Filtered Messages Are Marked Without Dispatch & Counted
+			assert.True(t, marked)
+			assert.Equal(t, testCase.wantMatch, mockMessageDispatcher.Message() != nil)
+			if testCase.wantMatch {
+				assert.Equal(t, 0, deliveryReporter.Filtered)
+			} else {
+				assert.Equal(t, 1, deliveryReporter.Filtered)
+			}
+		})
+	}
+}
+
+// Test The Handler's Writing Of Undeliverable Messages To The Dead Letter Topic
+func TestHandlerDeliverOrDeadLetter(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name             string
+		deadLetterTopic  string
+		failures         int32
+		produceErr       error
+		expectErr        bool
+		expectDeadLetter bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:            "Delivered",
+			deadLetterTopic: "TestDeadLetterTopic",
+		},
+		{
+			name:      "Failed Without Dead Letter Topic",
+			failures:  -1,
+			expectErr: true,
+		},
+		{
+			name:             "Failed With Dead Letter Topic",
+			deadLetterTopic:  "TestDeadLetterTopic",
+			failures:         -1,
+			expectDeadLetter: true,
+		},
+		{
+			name:            "Failed To Produce To Dead Letter Topic",
+			deadLetterTopic: "TestDeadLetterTopic",
+			failures:        -1,
+			produceErr:      errors.New("test produce failure"),
+			expectErr:       true,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Handler To Test With The Dead Letter Topic & A Mock Producer
+			handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+			handler.Options.DeadLetterTopic = testCase.deadLetterTopic
+			handler.MessageDispatcher = &failingMessageDispatcher{failures: testCase.failures}
+			mockSyncProducer := &dispatchertesting.MockSyncProducer{Err: testCase.produceErr}
+			handler.Producer = mockSyncProducer
+
+			// Perform The Test With A Message Carrying Stale Retry Topic Headers
+			consumerMessage := createConsumerMessage(t)
+			consumerMessage.Topic = testTopic
+			consumerMessage.Headers = append(consumerMessage.Headers, &sarama.RecordHeader{Key: []byte(constants.RetryNotBeforeHeader), Value: []byte("0")})
+			retryConfig := kncloudevents.NoRetries()
+			err := handler.deliverOrDeadLetter(consumerMessage, testSubscriberURI.URL(), testReplyURI.URL(), nil, &retryConfig)
+
+			// Verify The Results
+			assert.Equal(t, testCase.expectErr, err != nil)
+			deliveryReporter := handler.DeliveryReporter.(*dispatchertesting.MockDeliveryReporter)
+			if testCase.expectDeadLetter {
+				assert.Len(t, mockSyncProducer.Messages, 1)
+				assert.Equal(t, 1, deliveryReporter.DeadLetters)
+				deadLetterMessage := mockSyncProducer.Messages[0]
+				assert.Equal(t, testCase.deadLetterTopic, deadLetterMessage.Topic)
+				deadLetterConsumerMessage := &sarama.ConsumerMessage{Topic: deadLetterMessage.Topic}
+				for i := range deadLetterMessage.Headers {
+					deadLetterConsumerMessage.Headers = append(deadLetterConsumerMessage.Headers, &deadLetterMessage.Headers[i])
+				}
+				_, hasRetryHeader := retryHeader(deadLetterConsumerMessage, constants.RetryNotBeforeHeader)
+				assert.False(t, hasRetryHeader)
+				entry := dlq.ParseEntry(deadLetterConsumerMessage)
+				assert.Equal(t, "test dispatch failure", entry.Error)
+				assert.Equal(t, 1, entry.Attempts)
+				assert.Equal(t, string(testSubscriberUID), entry.Subscriber)
+				assert.Equal(t, testTopic, entry.ChannelTopic)
+				assert.Equal(t, int64(testOffset), entry.OriginalOffset)
+			} else {
+				assert.Empty(t, mockSyncProducer.Messages)
+				assert.Equal(t, 0, deliveryReporter.DeadLetters)
+			}
+		})
+	}
+}
+
+// Test The Handler's consumeMessage() Functionality Continues The Trace From The ConsumerMessage
+func TestHandlerConsumeMessageTracing(t *testing.T) {
+
+	// Mock The newMessageDispatcherWrapper Function With A Span Capturing MessageDispatcher (And Restore Post-Test)
+	spanMessageDispatcher := &spanMessageDispatcher{}
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return spanMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create A ConsumerMessage With A Trace Context (As Written By The Receiver)
+	_, parentSpan := trace.StartSpan(context.TODO(), "TestParentSpan")
+	parentSpan.End()
+	consumerMessage := createConsumerMessage(t)
+	for _, header := range tracing.SerializeTrace(parentSpan.SpanContext()) {
+		header := header
+		consumerMessage.Headers = append(consumerMessage.Headers, &header)
+	}
+
+	// Perform The Test
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	_, err := handler.consumeMessage(consumerMessage, testSubscriberURI.URL(), testReplyURI.URL(), nil, &kncloudevents.RetryConfig{})
+
+	// Verify The Message Was Dispatched Within A Child Span Of The Trace
+	assert.Nil(t, err)
+	assert.NotNil(t, spanMessageDispatcher.span)
+	assert.Equal(t, parentSpan.SpanContext().TraceID, spanMessageDispatcher.span.SpanContext().TraceID)
+	assert.NotEqual(t, parentSpan.SpanContext().SpanID, spanMessageDispatcher.span.SpanContext().SpanID)
 }
 
 // Test The Custom CheckRetry() Implementation
@@ -397,18 +987,36 @@ func createTestHandler(t *testing.T, subscriberURL *apis.URL, replyUrl *apis.URL
 	}
 
 	// Perform The Test Create The Test Handler
-	handler := NewHandler(logger, testSubscriber)
+	deliveryReporter := dispatchertesting.NewMockDeliveryReporter()
+	deliveryTags := metrics.DeliveryTags{Namespace: testChannelNamespace, Channel: testChannelName, SubscriptionUID: string(testSubscriberUID)}
+	handler := NewHandler(logger, testSubscriber, DefaultSubscriberOptions(), deliveryReporter, deliveryTags, nil)
 
 	// Verify The Results
 	assert.NotNil(t, handler)
 	assert.Equal(t, logger, handler.Logger)
 	assert.Equal(t, testSubscriber, handler.Subscriber)
+	assert.Equal(t, DefaultSubscriberOptions(), handler.Options)
 	assert.NotNil(t, handler.MessageDispatcher)
+	assert.Equal(t, deliveryReporter, handler.DeliveryReporter)
+	assert.Equal(t, deliveryTags, handler.DeliveryTags)
+	assert.Empty(t, handler.StuckPartitions())
 
 	// Return The Handler
 	return handler
 }
 
+// Utility Function For Creating Valid ConsumerMessages With The Specified Offset (Also Used As The Event ID)
+func createConsumerMessageWithOffset(t *testing.T, offset int64) *sarama.ConsumerMessage {
+	consumerMessage := createConsumerMessage(t)
+	consumerMessage.Offset = offset
+	for _, header := range consumerMessage.Headers {
+		if string(header.Key) == "ce_id" {
+			header.Value = []byte(strconv.FormatInt(offset, 10))
+		}
+	}
+	return consumerMessage
+}
+
 // Utility Function For Creating Valid ConsumerMessages
 func createConsumerMessage(t *testing.T) *sarama.ConsumerMessage {
 
@@ -463,3 +1071,89 @@ func createConsumerMessage(t *testing.T) *sarama.ConsumerMessage {
 	// Return The Test ConsumerMessage
 	return consumerMessage
 }
+
+//
+// Blocking MessageDispatcher Implementation (Dispatches Block Until Their Event ID Is Released)
+//
+
+// Verify The Blocking MessageDispatcher Implements The Interface
+var _ channel.MessageDispatcher = &blockingMessageDispatcher{}
+
+// Define The Blocking MessageDispatcher
+type blockingMessageDispatcher struct {
+	gates map[string]chan struct{}
+}
+
+// Blocking MessageDispatcher Constructor
+func newBlockingMessageDispatcher(eventIds ...string) *blockingMessageDispatcher {
+	gates := make(map[string]chan struct{}, len(eventIds))
+	for _, eventId := range eventIds {
+		gates[eventId] = make(chan struct{})
+	}
+	return &blockingMessageDispatcher{gates: gates}
+}
+
+// Release The Dispatch Of The Specified Event ID
+func (b *blockingMessageDispatcher) release(eventId string) {
+	close(b.gates[eventId])
+}
+
+func (b *blockingMessageDispatcher) DispatchMessage(_ context.Context, _ cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL) error {
+	panic("implement me")
+}
+
+func (b *blockingMessageDispatcher) DispatchMessageWithRetries(ctx context.Context, message cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL, _ *kncloudevents.RetryConfig) error {
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		return err
+	}
+	<-b.gates[event.ID()]
+	return nil
+}
+
+//
+// Failing MessageDispatcher Implementation (Dispatches Fail The Specified Number Of Times, Or Always If Negative)
+//
+
+// Verify The Failing MessageDispatcher Implements The Interface
+var _ channel.MessageDispatcher = &failingMessageDispatcher{}
+
+// Define The Failing MessageDispatcher
+type failingMessageDispatcher struct {
+	failures      int32
+	calls         int32
+	deadLetterURL *url.URL // The DeadLetterSink Of The Most Recent Dispatch
+}
+
+func (f *failingMessageDispatcher) DispatchMessage(_ context.Context, _ cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL) error {
+	panic("implement me")
+}
+
+func (f *failingMessageDispatcher) DispatchMessageWithRetries(_ context.Context, _ cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, deadLetterURL *url.URL, _ *kncloudevents.RetryConfig) error {
+	f.deadLetterURL = deadLetterURL
+	if calls := atomic.AddInt32(&f.calls, 1); f.failures < 0 || calls <= f.failures {
+		return errors.New("test dispatch failure")
+	}
+	return nil
+}
+
+//
+// Span Capturing MessageDispatcher Implementation (Records The Span Of The Dispatch Context)
+//
+
+// Verify The Span Capturing MessageDispatcher Implements The Interface
+var _ channel.MessageDispatcher = &spanMessageDispatcher{}
+
+// Define The Span Capturing MessageDispatcher
+type spanMessageDispatcher struct {
+	span *trace.Span
+}
+
+func (s *spanMessageDispatcher) DispatchMessage(_ context.Context, _ cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL) error {
+	panic("implement me")
+}
+
+func (s *spanMessageDispatcher) DispatchMessageWithRetries(ctx context.Context, _ cloudevents.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL, _ *kncloudevents.RetryConfig) error {
+	s.span = trace.FromContext(ctx)
+	return nil
+}
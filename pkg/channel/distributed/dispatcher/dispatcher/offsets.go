@@ -0,0 +1,60 @@
+package dispatcher
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+//
+// Out-Of-Order Completion Tracking For A Single Partition
+//
+// Messages are added in the (offset) order they are received from the ConsumerGroupClaim
+// and may complete in any order.  Only the contiguous prefix of completed messages is
+// ever marked, so the committed offset never advances past the first incomplete message.
+//
+type offsetTracker struct {
+	mutex     sync.Mutex
+	pending   []*sarama.ConsumerMessage // In-flight & completed-but-unmarked messages in offset order
+	completed map[int64]bool            // Offsets of completed messages still in pending
+}
+
+// Create A New Offset Tracker
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		pending:   make([]*sarama.ConsumerMessage, 0),
+		completed: make(map[int64]bool),
+	}
+}
+
+// Add A Newly Received Message To The Tracker
+func (o *offsetTracker) add(message *sarama.ConsumerMessage) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.pending = append(o.pending, message)
+}
+
+// Complete The Specified Message, Marking The Highest Contiguous Completed Message (If Any) & Returning The Number Of Messages Released
+func (o *offsetTracker) complete(message *sarama.ConsumerMessage, mark func(*sarama.ConsumerMessage)) int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	// Track The Completion
+	o.completed[message.Offset] = true
+
+	// Release The Contiguous Prefix Of Completed Messages
+	var lastCompleted *sarama.ConsumerMessage
+	released := 0
+	for len(o.pending) > 0 && o.completed[o.pending[0].Offset] {
+		lastCompleted = o.pending[0]
+		delete(o.completed, lastCompleted.Offset)
+		o.pending = o.pending[1:]
+		released++
+	}
+
+	// Mark The Highest Released Message (Under Lock To Guarantee Monotonic Marking)
+	if lastCompleted != nil {
+		mark(lastCompleted)
+	}
+	return released
+}
@@ -0,0 +1,43 @@
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The offsetTracker's Out-Of-Order Completion Functionality
+func TestOffsetTracker(t *testing.T) {
+
+	// Create An OffsetTracker With Five In-Flight Messages
+	tracker := newOffsetTracker()
+	messages := make([]*sarama.ConsumerMessage, 5)
+	for i := range messages {
+		messages[i] = &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition, Offset: int64(10 + i)}
+		tracker.add(messages[i])
+	}
+
+	// Track The Marked Messages
+	var markedOffsets []int64
+	mark := func(message *sarama.ConsumerMessage) { markedOffsets = append(markedOffsets, message.Offset) }
+
+	// Complete Later Messages First - Nothing Should Be Marked Or Released
+	assert.Equal(t, 0, tracker.complete(messages[2], mark))
+	assert.Equal(t, 0, tracker.complete(messages[1], mark))
+	assert.Empty(t, markedOffsets)
+
+	// Complete The First Message - The Contiguous Prefix Should Be Released & Highest Marked
+	assert.Equal(t, 3, tracker.complete(messages[0], mark))
+	assert.Equal(t, []int64{12}, markedOffsets)
+
+	// Complete The Last Message - Still Blocked By The Fourth
+	assert.Equal(t, 0, tracker.complete(messages[4], mark))
+	assert.Equal(t, []int64{12}, markedOffsets)
+
+	// Complete The Fourth Message - The Remainder Should Be Released
+	assert.Equal(t, 2, tracker.complete(messages[3], mark))
+	assert.Equal(t, []int64{12, 14}, markedOffsets)
+	assert.Empty(t, tracker.pending)
+	assert.Empty(t, tracker.completed)
+}
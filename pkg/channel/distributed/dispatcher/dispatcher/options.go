@@ -0,0 +1,161 @@
+package dispatcher
+
+import (
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	commonkafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// Subscriber Delivery Ordering Type
+type DeliveryOrdering string
+
+// Supported Subscriber Delivery Orderings
+const (
+	DeliveryOrderingOrdered   DeliveryOrdering = "ordered"   // One message at a time per partition (default)
+	DeliveryOrderingUnordered DeliveryOrdering = "unordered" // Bounded concurrent delivery per partition
+)
+
+// Subscriber Failure Policy Type (Handling Of Messages Which Could Not Be Delivered To The Subscriber Or DeadLetterSink)
+type FailurePolicy string
+
+// Supported Subscriber Failure Policies
+const (
+	FailurePolicyCommit           FailurePolicy = "commit"             // Commit the offset and move on, losing the event (default)
+	FailurePolicyPausePartition   FailurePolicy = "pause-partition"    // Stop consuming the partition until the next ConsumerGroup session
+	FailurePolicyBlockWithBackoff FailurePolicy = "block-with-backoff" // Re-deliver the event with exponential backoff until it succeeds
+)
+
+// Per-Subscriber Delivery Options
+type SubscriberOptions struct {
+	Ordering        DeliveryOrdering
+	Concurrency     int // Max in-flight messages per partition when unordered
+	FailurePolicy   FailurePolicy
+	RetryTopics     int                          // Number of retry topic tiers used instead of inline retries (0 disables)
+	RetryTopicDelay time.Duration                // Delay before redelivery from the first retry topic (doubled for each tier)
+	DeadLetterTopic string                       // Kafka topic to which undeliverable events are written (empty disables)
+	Filter          string                       // Canonical JSON CloudEvent attribute filter (empty delivers all events)
+	StartPosition   commonconsumer.StartPosition // Where A New ConsumerGroup Starts Consuming (Empty Uses The Sarama Config)
+}
+
+// Get The Default SubscriberOptions (Ordered Delivery, Commit Failures, No Retry Topics)
+func DefaultSubscriberOptions() SubscriberOptions {
+	return SubscriberOptions{
+		Ordering:        DeliveryOrderingOrdered,
+		Concurrency:     constants.DefaultDeliveryConcurrency,
+		FailurePolicy:   FailurePolicyCommit,
+		RetryTopicDelay: constants.DefaultRetryTopicDelay,
+	}
+}
+
+//
+// Get The SubscriberOptions For Each Of The Specified Subscribers From The KafkaChannel Annotations
+//
+// Each option may be specified for all of the KafkaChannel's subscribers via the plain annotation
+// (e.g. "eventing-kafka.knative.dev/delivery-ordering"), and overridden for a single subscriber by
+// suffixing the annotation with "." and the subscriber's UID.  Invalid values are logged and ignored.
+//
+func NewSubscriberOptionsMap(logger *zap.Logger, annotations map[string]string, subscriberSpecs []eventingduck.SubscriberSpec) map[types.UID]SubscriberOptions {
+	subscriberOptions := make(map[types.UID]SubscriberOptions, len(subscriberSpecs))
+	for _, subscriberSpec := range subscriberSpecs {
+		subscriberOptions[subscriberSpec.UID] = NewSubscriberOptions(logger, annotations, subscriberSpec.UID)
+	}
+	return subscriberOptions
+}
+
+// Get The SubscriberOptions For The Specified Subscriber UID From The KafkaChannel Annotations
+func NewSubscriberOptions(logger *zap.Logger, annotations map[string]string, uid types.UID) SubscriberOptions {
+
+	// Start With The Defaults
+	options := DefaultSubscriberOptions()
+
+	// Parse The Delivery Ordering
+	if ordering, ok := subscriberAnnotation(annotations, constants.DeliveryOrderingAnnotation, uid); ok {
+		switch DeliveryOrdering(ordering) {
+		case DeliveryOrderingOrdered, DeliveryOrderingUnordered:
+			options.Ordering = DeliveryOrdering(ordering)
+		default:
+			logger.Warn("Ignoring Invalid Delivery Ordering", zap.String("UID", string(uid)), zap.String("Ordering", ordering))
+		}
+	}
+
+	// Parse The Delivery Concurrency
+	if concurrencyString, ok := subscriberAnnotation(annotations, constants.DeliveryConcurrencyAnnotation, uid); ok {
+		concurrency, err := strconv.Atoi(concurrencyString)
+		if err != nil || concurrency <= 0 {
+			logger.Warn("Ignoring Invalid Delivery Concurrency", zap.String("UID", string(uid)), zap.String("Concurrency", concurrencyString))
+		} else {
+			options.Concurrency = concurrency
+		}
+	}
+
+	// Parse The Failure Policy
+	if failurePolicy, ok := subscriberAnnotation(annotations, constants.FailurePolicyAnnotation, uid); ok {
+		switch FailurePolicy(failurePolicy) {
+		case FailurePolicyCommit, FailurePolicyPausePartition, FailurePolicyBlockWithBackoff:
+			options.FailurePolicy = FailurePolicy(failurePolicy)
+		default:
+			logger.Warn("Ignoring Invalid Failure Policy", zap.String("UID", string(uid)), zap.String("FailurePolicy", failurePolicy))
+		}
+	}
+
+	// Parse The Retry Topic Count (Limited To The Retry Topics Created For The KafkaChannel)
+	if retryTopicsString, ok := subscriberAnnotation(annotations, commonkafkaconstants.RetryTopicsAnnotation, uid); ok {
+		retryTopics, err := strconv.Atoi(retryTopicsString)
+		if err != nil || retryTopics < 0 {
+			logger.Warn("Ignoring Invalid Retry Topics", zap.String("UID", string(uid)), zap.String("RetryTopics", retryTopicsString))
+		} else if channelRetryTopics := commonkafkautil.RetryTopicCount(annotations); retryTopics > channelRetryTopics {
+			logger.Warn("Limiting Retry Topics To Those Of The KafkaChannel", zap.String("UID", string(uid)), zap.Int("RetryTopics", channelRetryTopics))
+			options.RetryTopics = channelRetryTopics
+		} else {
+			options.RetryTopics = retryTopics
+		}
+	}
+
+	// Parse The Retry Topic Delay
+	if retryTopicDelayString, ok := subscriberAnnotation(annotations, commonkafkaconstants.RetryTopicDelayAnnotation, uid); ok {
+		retryTopicDelay, err := time.ParseDuration(retryTopicDelayString)
+		if err != nil || retryTopicDelay <= 0 {
+			logger.Warn("Ignoring Invalid Retry Topic Delay", zap.String("UID", string(uid)), zap.String("RetryTopicDelay", retryTopicDelayString))
+		} else {
+			options.RetryTopicDelay = retryTopicDelay
+		}
+	}
+
+	// Get The Dead Letter Topic
+	options.DeadLetterTopic = dlq.DeadLetterTopic(annotations, uid)
+
+	// Parse The Start Position Of A New ConsumerGroup
+	if startPosition, err := commonconsumer.StartPositionFromAnnotations(annotations, uid); err != nil {
+		logger.Warn("Ignoring Invalid Start Position", zap.String("UID", string(uid)), zap.Error(err))
+	} else {
+		options.StartPosition = startPosition
+	}
+
+	// Parse The CloudEvent Attribute Filter (Stored In Canonical Form So Options Remain Comparable)
+	if eventFilter, err := filter.FromAnnotations(annotations, uid); err != nil {
+		logger.Warn("Ignoring Invalid Filter", zap.String("UID", string(uid)), zap.Error(err))
+	} else {
+		options.Filter = eventFilter.String()
+	}
+
+	// Return The Parsed Options
+	return options
+}
+
+// Get The Subscriber Specific Annotation Value If Present, Otherwise The KafkaChannel Wide Value
+func subscriberAnnotation(annotations map[string]string, key string, uid types.UID) (string, bool) {
+	if value, ok := annotations[key+"."+string(uid)]; ok {
+		return value, true
+	}
+	value, ok := annotations[key]
+	return value, ok
+}
@@ -0,0 +1,136 @@
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	commonkafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/dlq"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewSubscriberOptions() Functionality
+func TestNewSubscriberOptions(t *testing.T) {
+
+	// Define The TestCases
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    SubscriberOptions
+	}{
+		{
+			name:     "No Annotations",
+			expected: DefaultSubscriberOptions(),
+		},
+		{
+			name: "Channel Wide Unordered",
+			annotations: map[string]string{
+				constants.DeliveryOrderingAnnotation:    "unordered",
+				constants.DeliveryConcurrencyAnnotation: "20",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingUnordered, Concurrency: 20, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay},
+		},
+		{
+			name: "Subscriber Override",
+			annotations: map[string]string{
+				constants.DeliveryOrderingAnnotation:                           "unordered",
+				constants.DeliveryOrderingAnnotation + "." + string(uid123):    "ordered",
+				constants.DeliveryConcurrencyAnnotation + "." + string(uid456): "5",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay},
+		},
+		{
+			name: "Failure Policy",
+			annotations: map[string]string{
+				constants.FailurePolicyAnnotation:                        "pause-partition",
+				constants.FailurePolicyAnnotation + "." + string(uid123): "block-with-backoff",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyBlockWithBackoff, RetryTopicDelay: constants.DefaultRetryTopicDelay},
+		},
+		{
+			name: "Retry Topics",
+			annotations: map[string]string{
+				commonkafkaconstants.RetryTopicsAnnotation:                            "3",
+				commonkafkaconstants.RetryTopicsAnnotation + "." + string(uid123):     "2",
+				commonkafkaconstants.RetryTopicDelayAnnotation + "." + string(uid123): "30s",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopics: 2, RetryTopicDelay: 30 * time.Second},
+		},
+		{
+			name: "Retry Topics Limited To Channel",
+			annotations: map[string]string{
+				commonkafkaconstants.RetryTopicsAnnotation:                        "1",
+				commonkafkaconstants.RetryTopicsAnnotation + "." + string(uid123): "4",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopics: 1, RetryTopicDelay: constants.DefaultRetryTopicDelay},
+		},
+		{
+			name: "Dead Letter Topic",
+			annotations: map[string]string{
+				dlq.DeadLetterTopicAnnotation:                        "channel-dlq",
+				dlq.DeadLetterTopicAnnotation + "." + string(uid123): "subscriber-dlq",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay, DeadLetterTopic: "subscriber-dlq"},
+		},
+		{
+			name: "Filter",
+			annotations: map[string]string{
+				filter.Annotation: ` {"prefix":{"Source":"/orders/"},"exact":{"type":"order.created"}} `,
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay, Filter: `{"exact":{"type":"order.created"},"prefix":{"source":"/orders/"}}`},
+		},
+		{
+			name: "Start Position",
+			annotations: map[string]string{
+				commonconsumer.StartPositionAnnotation:                        "latest",
+				commonconsumer.StartPositionAnnotation + "." + string(uid123): "2020-10-01T12:00:00Z",
+			},
+			expected: SubscriberOptions{Ordering: DeliveryOrderingOrdered, Concurrency: constants.DefaultDeliveryConcurrency, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay, StartPosition: "2020-10-01T12:00:00Z"},
+		},
+		{
+			name: "Invalid Values",
+			annotations: map[string]string{
+				constants.DeliveryOrderingAnnotation:           "random",
+				constants.DeliveryConcurrencyAnnotation:        "-1",
+				constants.FailurePolicyAnnotation:              "retry-forever",
+				commonkafkaconstants.RetryTopicsAnnotation:     "many",
+				commonkafkaconstants.RetryTopicDelayAnnotation: "-5s",
+				filter.Annotation:                              `{"exact":"type"}`,
+				commonconsumer.StartPositionAnnotation:         "beginning",
+			},
+			expected: DefaultSubscriberOptions(),
+		},
+	}
+
+	// Run The TestCases
+	logger := logtesting.TestLogger(t).Desugar()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewSubscriberOptions(logger, test.annotations, uid123))
+		})
+	}
+}
+
+// Test The NewSubscriberOptionsMap() Functionality
+func TestNewSubscriberOptionsMap(t *testing.T) {
+
+	// Test Data
+	annotations := map[string]string{
+		constants.DeliveryOrderingAnnotation + "." + string(uid456):    "unordered",
+		constants.DeliveryConcurrencyAnnotation + "." + string(uid456): "3",
+	}
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}}
+
+	// Perform The Test
+	subscriberOptions := NewSubscriberOptionsMap(logtesting.TestLogger(t).Desugar(), annotations, subscriberSpecs)
+
+	// Verify The Results
+	assert.Len(t, subscriberOptions, 2)
+	assert.Equal(t, DefaultSubscriberOptions(), subscriberOptions[uid123])
+	assert.Equal(t, SubscriberOptions{Ordering: DeliveryOrderingUnordered, Concurrency: 3, FailurePolicy: FailurePolicyCommit, RetryTopicDelay: constants.DefaultRetryTopicDelay}, subscriberOptions[uid456])
+}
@@ -0,0 +1,121 @@
+package dispatcher
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+)
+
+//
+// Dispatcher Pool
+//
+// A pooled Dispatcher serves all the (low traffic) KafkaChannels of a dispatcher pool instead of a single KafkaChannel.
+// It hosts a regular Dispatcher per KafkaChannel, each consuming the KafkaChannel's Topic with the ConsumerGroups of
+// its subscribers, so that the pooled KafkaChannels behave exactly as they would with a dedicated Dispatcher.
+//
+
+// The Dispatchers Of The KafkaChannels Of A Dispatcher Pool
+type DispatcherPool struct {
+	DispatcherConfig                         // Base DispatcherConfig Of The KafkaChannels (Without Topic Or ChannelKey)
+	dispatchers      map[string]Dispatcher   // Dispatchers Keyed By KafkaChannel Key (namespace/name)
+	statusChanged    func(channelKey string) // Optional - Called When The Subscribers' Stuck Partitions Of A KafkaChannel Change
+	lock             sync.Mutex
+}
+
+// Wrapper Function To Facilitate Testing With A Mock Dispatcher
+var newDispatcherWrapper = NewDispatcher
+
+// DispatcherPool Constructor
+func NewDispatcherPool(dispatcherConfig DispatcherConfig) *DispatcherPool {
+	return &DispatcherPool{
+		DispatcherConfig: dispatcherConfig,
+		dispatchers:      make(map[string]Dispatcher),
+	}
+}
+
+// Set The Function To Be Called When The Subscribers' Stuck Partitions Of A KafkaChannel Change
+func (p *DispatcherPool) SetStatusChangedFunc(statusChanged func(channelKey string)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.statusChanged = statusChanged
+	for channelKey, dispatcher := range p.dispatchers {
+		p.setDispatcherStatusChangedFunc(channelKey, dispatcher)
+	}
+}
+
+// Get The Dispatcher Of The Specified KafkaChannel, Creating It (Consuming The Specified Topic) If Necessary
+func (p *DispatcherPool) Dispatcher(channelKey string, topic string) Dispatcher {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Return The Existing Dispatcher Of The KafkaChannel
+	if dispatcher, ok := p.dispatchers[channelKey]; ok {
+		return dispatcher
+	}
+
+	// Otherwise Create A New Dispatcher For The KafkaChannel (Its Subscriptions Are Set When Reconciled)
+	p.Logger.Info("Adding KafkaChannel To Dispatcher Pool", zap.String("ChannelKey", channelKey), zap.String("Topic", topic))
+	dispatcherConfig := p.DispatcherConfig
+	dispatcherConfig.Logger = p.Logger.With(zap.String("ChannelKey", channelKey))
+	dispatcherConfig.ChannelKey = channelKey
+	dispatcherConfig.Topic = topic
+	dispatcherConfig.SubscriberSpecs = nil
+	dispatcherConfig.StatusChanged = nil
+	dispatcher := newDispatcherWrapper(dispatcherConfig)
+	p.setDispatcherStatusChangedFunc(channelKey, dispatcher)
+	p.dispatchers[channelKey] = dispatcher
+	return dispatcher
+}
+
+// Remove The Dispatcher Of The Specified KafkaChannel (The ConsumerGroups Of Its Subscribers Are Left In Place For Any Other
+// Dispatcher Of The KafkaChannel, Or Are Deleted By The Controller When The KafkaChannel Is Finalized)
+func (p *DispatcherPool) Remove(channelKey string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if dispatcher, ok := p.dispatchers[channelKey]; ok {
+		p.Logger.Info("Removing KafkaChannel From Dispatcher Pool", zap.String("ChannelKey", channelKey))
+		dispatcher.Shutdown()
+		delete(p.dispatchers, channelKey)
+	}
+}
+
+// Recreate The Dispatchers Of All The KafkaChannels If The ConfigMap Changes Their Consumer Configuration
+func (p *DispatcherPool) ConfigChanged(configMap *v1.ConfigMap) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Use The New Sarama Config For Dispatchers Created Hereafter (Nothing To Do Without Consumer Changes)
+	newConfig := newConsumerConfig(p.Logger, p.SaramaConfig, configMap)
+	if newConfig == nil {
+		return
+	}
+	p.SaramaConfig = newConfig
+
+	// Replace The Dispatcher Of Each KafkaChannel Recreated With The New Configuration
+	for channelKey, dispatcher := range p.dispatchers {
+		newDispatcher := dispatcher.ConfigChanged(configMap)
+		if newDispatcher != nil {
+			p.setDispatcherStatusChangedFunc(channelKey, newDispatcher)
+			p.dispatchers[channelKey] = newDispatcher
+		}
+	}
+}
+
+// Shutdown The Dispatchers Of All The KafkaChannels (Their Subscribers' ConsumerGroups Are Deleted When The KafkaChannels Are Finalized)
+func (p *DispatcherPool) Shutdown() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for channelKey, dispatcher := range p.dispatchers {
+		dispatcher.Shutdown()
+		delete(p.dispatchers, channelKey)
+	}
+}
+
+// Set The StatusChanged Function Of The Specified KafkaChannel's Dispatcher (Must Hold The Lock)
+func (p *DispatcherPool) setDispatcherStatusChangedFunc(channelKey string, dispatcher Dispatcher) {
+	if p.statusChanged != nil {
+		statusChanged := p.statusChanged
+		dispatcher.SetStatusChangedFunc(func() { statusChanged(channelKey) })
+	}
+}
@@ -0,0 +1,141 @@
+package dispatcher
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+// Test Data
+const (
+	poolChannelKey1 = "namespace1/channel1"
+	poolChannelKey2 = "namespace2/channel2"
+	poolTopic1      = "namespace1.channel1"
+	poolTopic2      = "namespace2.channel2"
+)
+
+// Test The DispatcherPool's Creation, Reuse & Removal Of The KafkaChannels' Dispatchers
+func TestDispatcherPool(t *testing.T) {
+
+	// Mock The Dispatcher Creation
+	createdConfigs := stubNewDispatcher(t)
+
+	// Create The DispatcherPool With A StatusChanged Function Tracking The KafkaChannel
+	pool := NewDispatcherPool(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar(), ClientId: "TestClientId"})
+	var statusChangedKey string
+	pool.SetStatusChangedFunc(func(channelKey string) { statusChangedKey = channelKey })
+
+	// Get The Dispatchers Of Two KafkaChannels
+	dispatcher1 := pool.Dispatcher(poolChannelKey1, poolTopic1)
+	dispatcher2 := pool.Dispatcher(poolChannelKey2, poolTopic2)
+	assert.NotSame(t, dispatcher1, dispatcher2)
+	assert.Len(t, *createdConfigs, 2)
+	assert.Equal(t, poolChannelKey1, (*createdConfigs)[0].ChannelKey)
+	assert.Equal(t, poolTopic1, (*createdConfigs)[0].Topic)
+	assert.Equal(t, "TestClientId", (*createdConfigs)[0].ClientId)
+	assert.Equal(t, poolChannelKey2, (*createdConfigs)[1].ChannelKey)
+	assert.Equal(t, poolTopic2, (*createdConfigs)[1].Topic)
+
+	// The Existing Dispatcher Of A KafkaChannel Is Reused
+	assert.Same(t, dispatcher1, pool.Dispatcher(poolChannelKey1, poolTopic1))
+	assert.Len(t, *createdConfigs, 2)
+
+	// The StatusChanged Function Is Called With The KafkaChannel Of The Dispatcher
+	dispatcher2.(*mockDispatcher).statusChanged()
+	assert.Equal(t, poolChannelKey2, statusChangedKey)
+
+	// Removing A KafkaChannel Shuts Down Its Dispatcher
+	pool.Remove(poolChannelKey1)
+	assert.True(t, dispatcher1.(*mockDispatcher).shutdown)
+	assert.False(t, dispatcher2.(*mockDispatcher).shutdown)
+	assert.NotSame(t, dispatcher1, pool.Dispatcher(poolChannelKey1, poolTopic1))
+	assert.Len(t, *createdConfigs, 3)
+
+	// Shutting Down The DispatcherPool Shuts Down All Dispatchers
+	pool.Shutdown()
+	assert.True(t, dispatcher2.(*mockDispatcher).shutdown)
+	assert.Empty(t, pool.dispatchers)
+}
+
+// Test The DispatcherPool's ConfigChanged Functionality
+func TestDispatcherPoolConfigChanged(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Mock The Dispatcher Creation
+	createdConfigs := stubNewDispatcher(t)
+
+	// Create The DispatcherPool With The Dispatcher Of A KafkaChannel
+	pool := NewDispatcherPool(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar(), SaramaConfig: sarama.NewConfig()})
+	dispatcher := pool.Dispatcher(poolChannelKey1, poolTopic1)
+
+	// A ConfigMap With Consumer Changes Recreates The Dispatchers & Is Used For New Dispatchers
+	configMap := getBaseConfigMap()
+	pool.ConfigChanged(configMap)
+	assert.Equal(t, 1, dispatcher.(*mockDispatcher).configChanges)
+	assert.NotSame(t, dispatcher, pool.Dispatcher(poolChannelKey1, poolTopic1))
+	newConfig := pool.SaramaConfig
+	pool.Dispatcher(poolChannelKey2, poolTopic2)
+	assert.Same(t, newConfig, (*createdConfigs)[1].SaramaConfig)
+
+	// A ConfigMap Without Consumer Changes Is Ignored
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigProducerChange
+	pool.ConfigChanged(configMap)
+	assert.Same(t, newConfig, pool.SaramaConfig)
+}
+
+// Stub The Dispatcher Creation With A Mock Dispatcher, Returning The DispatcherConfigs Of The Created Dispatchers
+func stubNewDispatcher(t *testing.T) *[]DispatcherConfig {
+	createdConfigs := make([]DispatcherConfig, 0)
+	newDispatcherWrapperPlaceholder := newDispatcherWrapper
+	newDispatcherWrapper = func(dispatcherConfig DispatcherConfig) Dispatcher {
+		createdConfigs = append(createdConfigs, dispatcherConfig)
+		return &mockDispatcher{}
+	}
+	t.Cleanup(func() { newDispatcherWrapper = newDispatcherWrapperPlaceholder })
+	return &createdConfigs
+}
+
+// Mock Dispatcher Tracking Its Shutdown, ConfigChanged & StatusChanged Function
+type mockDispatcher struct {
+	shutdown      bool
+	configChanges int
+	statusChanged func()
+}
+
+var _ Dispatcher = &mockDispatcher{}
+
+func (m *mockDispatcher) ConfigChanged(*corev1.ConfigMap) Dispatcher {
+	m.configChanges++
+	return &mockDispatcher{}
+}
+
+func (m *mockDispatcher) Shutdown() {
+	m.shutdown = true
+}
+
+func (m *mockDispatcher) UpdateSubscriptions([]eventingduck.SubscriberSpec, map[types.UID]SubscriberOptions) map[eventingduck.SubscriberSpec]error {
+	return nil
+}
+
+func (m *mockDispatcher) SetStatusChangedFunc(statusChanged func()) {
+	m.statusChanged = statusChanged
+}
+
+func (m *mockDispatcher) StuckPartitions() map[types.UID]map[int32]error {
+	return nil
+}
+
+func (m *mockDispatcher) StartPositions() map[types.UID]string {
+	return nil
+}
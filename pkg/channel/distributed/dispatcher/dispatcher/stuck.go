@@ -0,0 +1,83 @@
+package dispatcher
+
+import (
+	"sync"
+)
+
+//
+// Thread-Safe Tracking Of A Subscriber's Stuck Partitions
+//
+// A partition is "stuck" when a message could not be delivered (to either the subscriber
+// or the DeadLetterSink) and the subscriber's FailurePolicy prevents the offset from being
+// committed past it.  The optional changed function is called whenever the set of stuck
+// partitions changes so that the subscriber's status can be updated.
+//
+type stuckPartitions struct {
+	mutex      sync.RWMutex
+	partitions map[int32]error
+	changed    func()
+}
+
+// Create A New Stuck Partitions Tracker
+func newStuckPartitions(changed func()) *stuckPartitions {
+	return &stuckPartitions{
+		partitions: make(map[int32]error),
+		changed:    changed,
+	}
+}
+
+// Mark The Specified Partition As Stuck Due To The Specified Error
+func (s *stuckPartitions) set(partition int32, err error) {
+	s.mutex.Lock()
+	_, alreadyStuck := s.partitions[partition]
+	s.partitions[partition] = err
+	s.mutex.Unlock()
+	if !alreadyStuck {
+		s.notify()
+	}
+}
+
+// Clear The Stuck State Of The Specified Partition
+func (s *stuckPartitions) clear(partition int32) {
+	s.mutex.Lock()
+	_, wasStuck := s.partitions[partition]
+	delete(s.partitions, partition)
+	s.mutex.Unlock()
+	if wasStuck {
+		s.notify()
+	}
+}
+
+// Clear The Stuck State Of All Partitions Except The Specified (Still Claimed) Partitions
+func (s *stuckPartitions) retain(claimed map[int32]bool) {
+	s.mutex.Lock()
+	wasStuck := false
+	for partition := range s.partitions {
+		if !claimed[partition] {
+			delete(s.partitions, partition)
+			wasStuck = true
+		}
+	}
+	s.mutex.Unlock()
+	if wasStuck {
+		s.notify()
+	}
+}
+
+// Get A Copy Of The Currently Stuck Partitions & Their Errors
+func (s *stuckPartitions) get() map[int32]error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	partitions := make(map[int32]error, len(s.partitions))
+	for partition, err := range s.partitions {
+		partitions[partition] = err
+	}
+	return partitions
+}
+
+// Notify The Changed Function (If Any)
+func (s *stuckPartitions) notify() {
+	if s.changed != nil {
+		s.changed()
+	}
+}
@@ -0,0 +1,52 @@
+package dispatcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The stuckPartitions Tracking & Change Notification Functionality
+func TestStuckPartitions(t *testing.T) {
+
+	// Create A stuckPartitions Tracker Which Counts Changes
+	changes := 0
+	stuck := newStuckPartitions(func() { changes++ })
+	err1 := errors.New("test error 1")
+	err2 := errors.New("test error 2")
+
+	// Verify Initially Empty
+	assert.Empty(t, stuck.get())
+
+	// Set Stuck Partitions - Only Newly Stuck Partitions Should Notify
+	stuck.set(0, err1)
+	stuck.set(3, err1)
+	stuck.set(0, err2)
+	assert.Equal(t, map[int32]error{0: err2, 3: err1}, stuck.get())
+	assert.Equal(t, 2, changes)
+
+	// Verify The Returned Map Is A Copy
+	stuck.get()[5] = err1
+	assert.Len(t, stuck.get(), 2)
+
+	// Clear A Partition - Only Previously Stuck Partitions Should Notify
+	stuck.clear(0)
+	stuck.clear(1)
+	assert.Equal(t, map[int32]error{3: err1}, stuck.get())
+	assert.Equal(t, 3, changes)
+
+	// Retain The Still Claimed Partitions - Only Notify If Any Were Cleared
+	stuck.set(4, err2)
+	stuck.retain(map[int32]bool{3: true, 7: true})
+	assert.Equal(t, map[int32]error{3: err1}, stuck.get())
+	assert.Equal(t, 5, changes)
+	stuck.retain(map[int32]bool{3: true})
+	assert.Equal(t, 5, changes)
+	stuck.retain(nil)
+	assert.Empty(t, stuck.get())
+	assert.Equal(t, 6, changes)
+
+	// Verify A Nil Changed Function Is Supported
+	newStuckPartitions(nil).set(0, err1)
+}
@@ -17,10 +17,13 @@ type Environment struct {
 
 	// Kafka Configuration
 	KafkaBrokers string // Required
-	KafkaTopic   string // Required
-	ChannelKey   string // Required
+	KafkaTopic   string // Required (Unless Pooled)
+	ChannelKey   string // Required (Unless Pooled)
 	ServiceName  string // Required
 
+	// Dispatcher Pool Configuration
+	DispatcherPoolSelector string // Optional - Label Selector Of The KafkaChannels Served By A Pooled Dispatcher
+
 	// Kafka Authorization
 	KafkaUsername string // Optional
 	KafkaPassword string // Optional
@@ -59,16 +62,23 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
-	// Get The Required K8S KafkaTopic Config Value
-	environment.KafkaTopic, err = env.GetRequiredConfigValue(logger, env.KafkaTopicEnvVarKey)
-	if err != nil {
-		return nil, err
-	}
+	// Get The Optional DispatcherPoolSelector Config Value (A Pooled Dispatcher Serves Multiple KafkaChannels)
+	environment.DispatcherPoolSelector = env.GetOptionalConfigValue(logger, env.DispatcherPoolSelectorEnvVarKey, "")
 
-	// Get The Required K8S ChannelKey Config Value
-	environment.ChannelKey, err = env.GetRequiredConfigValue(logger, env.ChannelKeyEnvVarKey)
-	if err != nil {
-		return nil, err
+	// The KafkaTopic & ChannelKey Are Only Required For A Dedicated (Single KafkaChannel) Dispatcher
+	if len(environment.DispatcherPoolSelector) <= 0 {
+
+		// Get The Required K8S KafkaTopic Config Value
+		environment.KafkaTopic, err = env.GetRequiredConfigValue(logger, env.KafkaTopicEnvVarKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get The Required K8S ChannelKey Config Value
+		environment.ChannelKey, err = env.GetRequiredConfigValue(logger, env.ChannelKeyEnvVarKey)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get The Required K8S ServiceName Config Value
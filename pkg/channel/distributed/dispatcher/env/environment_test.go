@@ -21,6 +21,7 @@ const (
 	kafkaTopic    = "TestKafkaTopic"
 	channelKey    = "TestChannelKey"
 	serviceName   = "TestServiceName"
+	poolSelector  = "eventing-kafka.knative.dev/dispatcher-pool=TestPool"
 	kafkaUsername = "TestKafkaUsername"
 	kafkaPassword = "TestKafkaPassword"
 )
@@ -35,6 +36,7 @@ type TestCase struct {
 	kafkaTopic    string
 	channelKey    string
 	serviceName   string
+	poolSelector  string
 	kafkaUsername string
 	kafkaPassword string
 	expectedError error
@@ -96,6 +98,12 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(commonenv.ServiceNameEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Pooled Config - No KafkaTopic Or ChannelKey")
+	testCase.poolSelector = poolSelector
+	testCase.kafkaTopic = ""
+	testCase.channelKey = ""
+	testCases = append(testCases, testCase)
+
 	// Loop Over All The TestCases
 	for _, testCase := range testCases {
 
@@ -108,6 +116,7 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenv(t, commonenv.KafkaTopicEnvVarKey, testCase.kafkaTopic)
 		assertSetenv(t, commonenv.ChannelKeyEnvVarKey, testCase.channelKey)
 		assertSetenv(t, commonenv.ServiceNameEnvVarKey, testCase.serviceName)
+		assertSetenvNonempty(t, commonenv.DispatcherPoolSelectorEnvVarKey, testCase.poolSelector)
 		assertSetenv(t, commonenv.KafkaUsernameEnvVarKey, testCase.kafkaUsername)
 		assertSetenv(t, commonenv.KafkaPasswordEnvVarKey, testCase.kafkaPassword)
 
@@ -125,6 +134,7 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.kafkaTopic, environment.KafkaTopic)
 			assert.Equal(t, testCase.channelKey, environment.ChannelKey)
 			assert.Equal(t, testCase.serviceName, environment.ServiceName)
+			assert.Equal(t, testCase.poolSelector, environment.DispatcherPoolSelector)
 			assert.Equal(t, testCase.kafkaUsername, environment.KafkaUsername)
 			assert.Equal(t, testCase.kafkaPassword, environment.KafkaPassword)
 
@@ -48,6 +48,11 @@ func WithKafkaChannelReady(kafkachannel *v1beta1.KafkaChannel) {
 	})
 }
 
+func WithKafkaChannelDeleted(kafkachannel *v1beta1.KafkaChannel) {
+	deletionTimestamp := metav1.Now()
+	kafkachannel.DeletionTimestamp = &deletionTimestamp
+}
+
 func WithKafkaChannelAddress(a string) KafkaChannelOption {
 	return func(kafkachannel *v1beta1.KafkaChannel) {
 		kafkachannel.Status.SetAddress(&apis.URL{
@@ -72,6 +77,32 @@ func WithSubscriber(uid types.UID, uri string) KafkaChannelOption {
 	}
 }
 
+func WithSubscriberStuck(uid types.UID, message string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
+			kafkachannel.Status.SubscribableStatus.Subscribers = []eventingduck.SubscriberStatus{}
+		}
+		kafkachannel.Status.SubscribableStatus.Subscribers = append(kafkachannel.Status.SubscribableStatus.Subscribers, eventingduck.SubscriberStatus{
+			Ready:   corev1.ConditionFalse,
+			UID:     uid,
+			Message: message,
+		})
+	}
+}
+
+func WithSubscriberStarted(uid types.UID, message string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
+			kafkachannel.Status.SubscribableStatus.Subscribers = []eventingduck.SubscriberStatus{}
+		}
+		kafkachannel.Status.SubscribableStatus.Subscribers = append(kafkachannel.Status.SubscribableStatus.Subscribers, eventingduck.SubscriberStatus{
+			Ready:   corev1.ConditionTrue,
+			UID:     uid,
+			Message: message,
+		})
+	}
+}
+
 func WithSubscriberReady(uid types.UID) KafkaChannelOption {
 	return func(kafkachannel *v1beta1.KafkaChannel) {
 		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
@@ -4,11 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
@@ -90,15 +93,24 @@ var _ sarama.ConsumerGroupSession = &MockConsumerGroupSession{}
 type MockConsumerGroupSession struct {
 	t               *testing.T
 	MarkMessageChan chan *sarama.ConsumerMessage
+	Claimed         map[string][]int32 // The Partitions (By Topic) Returned By Claims()
+	ctx             context.Context
+	cancel          context.CancelFunc
 }
 
 // Mock ConsumerGroupSession Constructor
 func NewMockConsumerGroupSession(t *testing.T) MockConsumerGroupSession {
-	return MockConsumerGroupSession{t: t, MarkMessageChan: make(chan *sarama.ConsumerMessage)}
+	ctx, cancel := context.WithCancel(context.Background())
+	return MockConsumerGroupSession{t: t, MarkMessageChan: make(chan *sarama.ConsumerMessage), ctx: ctx, cancel: cancel}
+}
+
+// End The Mock ConsumerGroupSession (Cancels Its Context As Sarama Does On Re-Balance)
+func (m MockConsumerGroupSession) End() {
+	m.cancel()
 }
 
 func (m MockConsumerGroupSession) Claims() map[string][]int32 {
-	panic("implement me")
+	return m.Claimed
 }
 
 func (m MockConsumerGroupSession) MemberID() string {
@@ -123,7 +135,7 @@ func (m MockConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metad
 }
 
 func (m MockConsumerGroupSession) Context() context.Context {
-	panic("implement me")
+	return m.ctx
 }
 
 func (m MockConsumerGroupSession) Commit() {
@@ -139,8 +151,9 @@ var _ sarama.ConsumerGroupClaim = &MockConsumerGroupClaim{}
 
 // Define The Mock ConsumerGroupSession
 type MockConsumerGroupClaim struct {
-	t           *testing.T
-	MessageChan chan *sarama.ConsumerMessage
+	t             *testing.T
+	MessageChan   chan *sarama.ConsumerMessage
+	HighWaterMark int64
 }
 
 // Mock ConsumerGroupClaim Constructor
@@ -161,9 +174,111 @@ func (m MockConsumerGroupClaim) InitialOffset() int64 {
 }
 
 func (m MockConsumerGroupClaim) HighWaterMarkOffset() int64 {
-	panic("implement me")
+	return m.HighWaterMark
 }
 
 func (m MockConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 	return m.MessageChan
 }
+
+//
+// Mock DeliveryReporter Implementation
+//
+
+// Verify The Mock DeliveryReporter Implements The Interface
+var _ metrics.DeliveryReporter = &MockDeliveryReporter{}
+
+// Define The Mock DeliveryReporter
+type MockDeliveryReporter struct {
+	mutex         sync.Mutex
+	ResponseCodes []int
+	Latencies     []bool // Whether Each Reported Delivery Succeeded
+	Retries       int
+	DeadLetters   int
+	Filtered      int
+	CleanupFailed int
+	ConsumerLag   map[int32]int64
+}
+
+// Mock DeliveryReporter Constructor
+func NewMockDeliveryReporter() *MockDeliveryReporter {
+	return &MockDeliveryReporter{ConsumerLag: make(map[int32]int64)}
+}
+
+func (m *MockDeliveryReporter) ReportDispatch(_ metrics.DeliveryTags, responseCode int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ResponseCodes = append(m.ResponseCodes, responseCode)
+}
+
+func (m *MockDeliveryReporter) ReportDispatchLatency(_ metrics.DeliveryTags, delivered bool, _ time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Latencies = append(m.Latencies, delivered)
+}
+
+func (m *MockDeliveryReporter) ReportRetry(_ metrics.DeliveryTags) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Retries++
+}
+
+func (m *MockDeliveryReporter) ReportDeadLetter(_ metrics.DeliveryTags) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.DeadLetters++
+}
+
+func (m *MockDeliveryReporter) ReportFiltered(_ metrics.DeliveryTags) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Filtered++
+}
+
+func (m *MockDeliveryReporter) ReportConsumerGroupCleanupFailure(_ metrics.DeliveryTags) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.CleanupFailed++
+}
+
+func (m *MockDeliveryReporter) ReportConsumerLag(_ metrics.DeliveryTags, partition int32, lag int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ConsumerLag[partition] = lag
+}
+
+//
+// Mock Sarama SyncProducer Implementation
+//
+
+// Verify The Mock SyncProducer Implements The Interface
+var _ sarama.SyncProducer = &MockSyncProducer{}
+
+// Define The Mock SyncProducer
+type MockSyncProducer struct {
+	mutex    sync.Mutex
+	Messages []*sarama.ProducerMessage
+	Err      error
+	Closed   bool
+}
+
+func (m *MockSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.Err != nil {
+		return -1, -1, m.Err
+	}
+	m.Messages = append(m.Messages, message)
+	return 0, int64(len(m.Messages)), nil
+}
+
+func (m *MockSyncProducer) SendMessages(_ []*sarama.ProducerMessage) error {
+	panic("implement me")
+}
+
+func (m *MockSyncProducer) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Closed = true
+	return nil
+}
@@ -8,9 +8,11 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sclientcmd "k8s.io/client-go/tools/clientcmd"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkainformers "knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/partition"
 	eventingChannel "knative.dev/eventing/pkg/channel"
 	knativecontroller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -106,6 +108,37 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	return nil
 }
 
+// Get The Name Of The CloudEvent Attribute Keying The Kafka Records Of The Specified KafkaChannel
+func PartitionKeyAttribute(channelReference eventingChannel.ChannelReference) string {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister (Default To The PartitionKey Extension)
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		logger.Warn("Failed To Get KafkaChannel - Using Default Partition Key Attribute", zap.Error(err))
+		return partition.DefaultKeyAttribute
+	}
+
+	// Return The Partition Key Attribute From The KafkaChannel's Annotations
+	return partition.KeyAttribute(kafkaChannel.Annotations)
+}
+
+// Get The Name Of The Kafka Topic Of The Specified KafkaChannel (The Adopted Topic, Otherwise ChannelNamespace.ChannelName)
+func TopicName(channelReference eventingChannel.ChannelReference) string {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister (Default To The Channel's Own Topic)
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		logger.Warn("Failed To Get KafkaChannel - Using Default Topic Name", zap.Error(err))
+		return util.TopicName(channelReference)
+	}
+
+	// Return The Adopted Topic Name, If Any
+	if adoptedTopicName := kafkaChannel.AdoptedTopicName(); adoptedTopicName != "" {
+		return adoptedTopicName
+	}
+	return util.TopicName(channelReference)
+}
+
 // Close The Channel Lister (Stop Processing)
 func Close() {
 	if stopChan != nil {
@@ -6,10 +6,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/partition"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -70,6 +74,46 @@ func performValidateKafkaChannelTest(t *testing.T, channelName string, channelNa
 	assert.Equal(t, err, validationError != nil)
 }
 
+// Test The PartitionKeyAttribute() Functionality
+func TestPartitionKeyAttribute(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Create A KafkaChannel Lister With A Channel Keyed By Its Subject
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	kafkaChannel := receivertesting.CreateKafkaChannel("keyed-channel", "TestChannelNamespace", corev1.ConditionTrue)
+	kafkaChannel.Annotations = map[string]string{partition.KeyAttributeAnnotation: "subject"}
+	assert.Nil(t, indexer.Add(kafkaChannel))
+	assert.Nil(t, indexer.Add(receivertesting.CreateKafkaChannel("default-channel", "TestChannelNamespace", corev1.ConditionTrue)))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Test & Verify The Results
+	assert.Equal(t, "subject", PartitionKeyAttribute(receivertesting.CreateChannelReference("keyed-channel", "TestChannelNamespace")))
+	assert.Equal(t, partition.DefaultKeyAttribute, PartitionKeyAttribute(receivertesting.CreateChannelReference("default-channel", "TestChannelNamespace")))
+	assert.Equal(t, partition.DefaultKeyAttribute, PartitionKeyAttribute(receivertesting.CreateChannelReference("missing-channel", "TestChannelNamespace")))
+}
+
+// Test The TopicName() Functionality
+func TestTopicName(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Create A KafkaChannel Lister With A Channel Adopting An Existing Topic
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	kafkaChannel := receivertesting.CreateKafkaChannel("adopting-channel", "TestChannelNamespace", corev1.ConditionTrue)
+	kafkaChannel.Spec.Topic = &kafkav1beta1.KafkaChannelTopic{Name: "existing-topic", Adopt: true}
+	assert.Nil(t, indexer.Add(kafkaChannel))
+	assert.Nil(t, indexer.Add(receivertesting.CreateKafkaChannel("default-channel", "TestChannelNamespace", corev1.ConditionTrue)))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Test & Verify The Results
+	assert.Equal(t, "existing-topic", TopicName(receivertesting.CreateChannelReference("adopting-channel", "TestChannelNamespace")))
+	assert.Equal(t, "TestChannelNamespace.default-channel", TopicName(receivertesting.CreateChannelReference("default-channel", "TestChannelNamespace")))
+	assert.Equal(t, "TestChannelNamespace.missing-channel", TopicName(receivertesting.CreateChannelReference("missing-channel", "TestChannelNamespace")))
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
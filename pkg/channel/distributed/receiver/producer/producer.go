@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
-	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	gometrics "github.com/rcrowley/go-metrics"
 	"go.uber.org/zap"
@@ -14,10 +13,10 @@ import (
 	kafkaproducer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/tracing"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
-	eventingChannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing-kafka/pkg/common/partition"
 )
 
 // Producer Struct
@@ -79,7 +78,8 @@ var createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (s
 }
 
 // Produce A KafkaMessage From The Specified CloudEvent To The Specified Topic And Wait For The Delivery Report
-func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, message binding.Message, transformers ...binding.Transformer) error {
+// (The Message Is Keyed By The Value Of The Specified CloudEvent Attribute / Extension, If Present)
+func (p *Producer) ProduceKafkaMessage(ctx context.Context, topicName string, partitionKeyAttribute string, message binding.Message, transformers ...binding.Transformer) error {
 
 	// Validate The Kafka Producer (Must Be Pre-Initialized)
 	if p.kafkaProducer == nil {
@@ -87,20 +87,22 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 		return errors.New("uninitialized kafka producer - unable to produce message")
 	}
 
-	// Get The Topic Name From The ChannelReference
-	topicName := util.TopicName(channelReference)
+	// Add The Topic Name To The Logger
 	logger := p.logger.With(zap.String("Topic", topicName))
 
 	// Initialize The Sarama ProducerMessage With The Specified Topic Name
 	producerMessage := &sarama.ProducerMessage{Topic: topicName}
 
-	// Use The SaramaKafka Protocol To Convert The Binding Message To A ProducerMessage
-	err := kafkasaramaprotocol.WriteProducerMessage(ctx, message, producerMessage, transformers...)
+	// Use The SaramaKafka Protocol To Convert The Binding Message To A Keyed ProducerMessage
+	err := partition.WriteProducerMessage(ctx, message, producerMessage, partitionKeyAttribute, transformers...)
 	if err != nil {
 		p.logger.Error("Failed To Convert BindingMessage To Sarama ProducerMessage", zap.Error(err))
 		return err
 	}
 
+	// Propagate The Current Trace (If Any) Via W3C Trace Context Headers For Continuation By The Dispatcher
+	tracing.InjectTrace(ctx, producerMessage)
+
 	// Produce The Kafka Message To The Kafka Topic
 	logger.Debug("Producing Kafka Message", zap.Any("Headers", producerMessage.Headers), zap.Any("Message", producerMessage.Value))
 	partition, offset, err := p.kafkaProducer.SendMessage(producerMessage)
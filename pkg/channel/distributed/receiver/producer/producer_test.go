@@ -10,14 +10,17 @@ import (
 	"github.com/ghodss/yaml"
 	gometrics "github.com/rcrowley/go-metrics"
 	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/trace"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/tracing"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	"knative.dev/eventing-kafka/pkg/common/partition"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 
@@ -114,11 +117,10 @@ func TestProduceKafkaMessage(t *testing.T) {
 	// Create Test Data
 	mockSyncProducer := receivertesting.NewMockSyncProducer()
 	producer := createTestProducer(t, mockSyncProducer)
-	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
 	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
 
 	// Perform The Test & Verify Results
-	err := producer.ProduceKafkaMessage(context.Background(), channelReference, bindingMessage)
+	err := producer.ProduceKafkaMessage(context.Background(), receivertesting.TopicName, partition.DefaultKeyAttribute, bindingMessage)
 	assert.Nil(t, err)
 
 	// Verify Message Was Produced Correctly
@@ -141,6 +143,53 @@ func TestProduceKafkaMessage(t *testing.T) {
 	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey, receivertesting.PartitionKey)
 }
 
+// Test The ProduceKafkaMessage() Functionality For A Channel Keyed By Another Attribute
+func TestProduceKafkaMessagePartitionKeyAttribute(t *testing.T) {
+
+	// Create Test Data
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), receivertesting.TopicName, "subject", bindingMessage)
+	assert.Nil(t, err)
+
+	// Verify Message Was Keyed By The Subject Rather Than The PartitionKey Extension
+	producerMessage := mockSyncProducer.GetMessage()
+	assert.NotNil(t, producerMessage)
+	key, err := producerMessage.Key.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, receivertesting.EventSubject, string(key))
+}
+
+// Test The ProduceKafkaMessage() Functionality Propagates The Current Trace
+func TestProduceKafkaMessageTracing(t *testing.T) {
+
+	// Create Test Data
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	ctx, span := trace.StartSpan(context.Background(), "TestSpan")
+	defer span.End()
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(ctx, receivertesting.TopicName, partition.DefaultKeyAttribute, bindingMessage)
+	assert.Nil(t, err)
+
+	// Verify The Produced Message Carries The Span's Trace Context
+	producerMessage := mockSyncProducer.GetMessage()
+	assert.NotNil(t, producerMessage)
+	headers := make([]*sarama.RecordHeader, len(producerMessage.Headers))
+	for i := range producerMessage.Headers {
+		headers[i] = &producerMessage.Headers[i]
+	}
+	spanContext, ok := tracing.ParseSpanContext(headers)
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID, spanContext.TraceID)
+	assert.Equal(t, span.SpanContext().SpanID, spanContext.SpanID)
+}
+
 func getBaseConfigMap() *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		TypeMeta: v1.TypeMeta{
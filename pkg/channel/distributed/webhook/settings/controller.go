@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	vwhinformer "knative.dev/pkg/client/injection/kube/informers/admissionregistration/v1/validatingwebhookconfiguration"
+	"knative.dev/pkg/controller"
+	secretinformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	"knative.dev/pkg/webhook"
+)
+
+//
+// Create A New Admission Controller Validating The Eventing-Kafka Settings ConfigMap
+//
+// The returned controller serves the validation of the config-eventing-kafka ConfigMap on the specified
+// path, and keeps the rules, CA bundle and service path of the specified ValidatingWebhookConfiguration
+// up to date (while leader) in the same way as the Knative resource validation admission controller.
+//
+func NewAdmissionController(ctx context.Context, name string, path string) *controller.Impl {
+
+	// Get The Needed Informers & Webhook Options
+	vwhInformer := vwhinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	options := webhook.GetOptions(ctx)
+
+	// Create The Settings Admission Reconciler
+	r := &reconciler{
+		LeaderAwareFuncs: pkgreconciler.LeaderAwareFuncs{
+			// Enqueue The Singleton ValidatingWebhookConfiguration Whenever Becoming The Leader
+			PromoteFunc: func(bkt pkgreconciler.Bucket, enq func(pkgreconciler.Bucket, types.NamespacedName)) error {
+				enq(bkt, types.NamespacedName{Name: name})
+				return nil
+			},
+		},
+		key:          types.NamespacedName{Name: name},
+		path:         path,
+		secretName:   options.SecretName,
+		client:       kubeclient.Get(ctx),
+		vwhlister:    vwhInformer.Lister(),
+		secretlister: secretInformer.Lister(),
+	}
+
+	// Create The Controller Impl
+	c := controller.NewImpl(r, logging.FromContext(ctx), "SettingsValidationWebhook")
+
+	// Reconcile When The Named ValidatingWebhookConfiguration Changes (The Enqueued Key Is Irrelevant)
+	vwhInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterWithName(name),
+		Handler:    controller.HandleAll(c.Enqueue),
+	})
+
+	// Reconcile When The Webhook's Certificate Bundle Changes (The Enqueued Key Is Irrelevant)
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterWithNameAndNamespace(system.Namespace(), r.secretName),
+		Handler:    controller.HandleAll(c.Enqueue),
+	})
+
+	// Return The Controller Impl
+	return c
+}
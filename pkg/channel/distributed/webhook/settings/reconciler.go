@@ -0,0 +1,164 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	admissionlisters "k8s.io/client-go/listers/admissionregistration/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	controllerconfig "knative.dev/eventing-kafka/pkg/channel/distributed/controller/config"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmp"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	"knative.dev/pkg/webhook"
+	certresources "knative.dev/pkg/webhook/certificates/resources"
+)
+
+// Reconciler Implementing The AdmissionController For The Eventing-Kafka Settings ConfigMap
+type reconciler struct {
+	webhook.StatelessAdmissionImpl
+	pkgreconciler.LeaderAwareFuncs
+
+	key        types.NamespacedName
+	path       string
+	secretName string
+
+	client       kubernetes.Interface
+	vwhlister    admissionlisters.ValidatingWebhookConfigurationLister
+	secretlister corelisters.SecretLister
+}
+
+// Verify The Reconciler Implements The Necessary Interfaces
+var _ controller.Reconciler = (*reconciler)(nil)
+var _ pkgreconciler.LeaderAware = (*reconciler)(nil)
+var _ webhook.AdmissionController = (*reconciler)(nil)
+var _ webhook.StatelessAdmissionController = (*reconciler)(nil)
+
+// Path Implements The AdmissionController Interface
+func (r *reconciler) Path() string {
+	return r.path
+}
+
+// Admit Implements The AdmissionController Interface By Validating The Eventing-Kafka Settings ConfigMap
+func (r *reconciler) Admit(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+
+	// Only The Eventing-Kafka Settings ConfigMap In The System Namespace Is Validated
+	if request.Namespace != system.Namespace() || request.Name != commonconfig.SettingsConfigMapName {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	// Decode The ConfigMap From The Request
+	configMap := &corev1.ConfigMap{}
+	if err := json.Unmarshal(request.Object.Raw, configMap); err != nil {
+		return webhook.MakeErrorStatus("decoding request failed: %v", err)
+	}
+
+	// Strictly Parse & Validate The Sarama And Eventing-Kafka Settings
+	eventingKafkaConfig, err := kafkasarama.ValidateSettings(configMap)
+	if err != nil {
+		return webhook.MakeErrorStatus("validation failed: %v", err)
+	}
+
+	// Verify The Eventing-Kafka Settings Required By The Controller
+	if err = controllerconfig.VerifyConfiguration(eventingKafkaConfig); err != nil {
+		return webhook.MakeErrorStatus("validation failed: %v", err)
+	}
+
+	// Admit The Valid ConfigMap
+	logging.FromContext(ctx).Infow("Admitted Valid Eventing-Kafka Settings", zap.String("ConfigMap", request.Name))
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// Reconcile Implements The controller.Reconciler Interface By Updating The ValidatingWebhookConfiguration
+func (r *reconciler) Reconcile(ctx context.Context, _ string) error {
+	logger := logging.FromContext(ctx)
+
+	// Only The Leader Updates The ValidatingWebhookConfiguration
+	if !r.IsLeaderFor(r.key) {
+		logger.Debugf("Skipping key %q, not the leader.", r.key)
+		return nil
+	}
+
+	// Look Up The Webhook Secret & Fetch The CA Certificate Bundle
+	secret, err := r.secretlister.Secrets(system.Namespace()).Get(r.secretName)
+	if err != nil {
+		logger.Errorw("Error fetching secret", zap.Error(err))
+		return err
+	}
+	caCert, ok := secret.Data[certresources.CACert]
+	if !ok {
+		return fmt.Errorf("secret %q is missing %q key", r.secretName, certresources.CACert)
+	}
+
+	// Reconcile The ValidatingWebhookConfiguration
+	return r.reconcileValidatingWebhook(ctx, caCert)
+}
+
+// Update The Rules, Selectors, CA Bundle & Path Of The ValidatingWebhookConfiguration As Necessary
+func (r *reconciler) reconcileValidatingWebhook(ctx context.Context, caCert []byte) error {
+	logger := logging.FromContext(ctx)
+
+	// Get The Named ValidatingWebhookConfiguration
+	configuredWebhook, err := r.vwhlister.Get(r.key.Name)
+	if err != nil {
+		return fmt.Errorf("error retrieving webhook: %w", err)
+	}
+
+	// Update A Copy Of The ValidatingWebhookConfiguration (Clearing Any Previous Bad OwnerReferences)
+	validatingWebhook := configuredWebhook.DeepCopy()
+	validatingWebhook.OwnerReferences = nil
+	for i, wh := range validatingWebhook.Webhooks {
+		if wh.Name != validatingWebhook.Name {
+			continue
+		}
+		validatingWebhook.Webhooks[i].Rules = []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create,
+				admissionregistrationv1.Update,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"configmaps"},
+			},
+		}}
+		validatingWebhook.Webhooks[i].ObjectSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      commonconfig.SettingsConfigMapLabel,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		}
+		validatingWebhook.Webhooks[i].ClientConfig.CABundle = caCert
+		if validatingWebhook.Webhooks[i].ClientConfig.Service == nil {
+			return fmt.Errorf("missing service reference for webhook: %s", wh.Name)
+		}
+		validatingWebhook.Webhooks[i].ClientConfig.Service.Path = ptr.String(r.Path())
+	}
+
+	// Update The ValidatingWebhookConfiguration If It Changed
+	if ok, err := kmp.SafeEqual(configuredWebhook, validatingWebhook); err != nil {
+		return fmt.Errorf("error diffing webhooks: %w", err)
+	} else if !ok {
+		logger.Info("Updating webhook")
+		vwhclient := r.client.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+		if _, err := vwhclient.Update(ctx, validatingWebhook, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update webhook: %w", err)
+		}
+	} else {
+		logger.Info("Webhook is valid")
+	}
+	return nil
+}
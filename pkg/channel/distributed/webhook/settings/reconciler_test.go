@@ -0,0 +1,205 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	admissionlisters "k8s.io/client-go/listers/admissionregistration/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	certresources "knative.dev/pkg/webhook/certificates/resources"
+)
+
+// Test Data
+const (
+	testWebhookName = "config.webhook.eventing-kafka.knative.dev"
+	testWebhookPath = "/config-validation"
+	testSecretName  = "test-webhook-certs"
+	testServiceName = "test-webhook"
+)
+
+var testCACert = []byte("test-ca-cert")
+
+// Test The Admission Of The Eventing-Kafka Settings ConfigMap
+func TestAdmit(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Test Data
+	invalidVersionConfigMap := newSettingsConfigMap()
+	invalidVersionConfigMap.Data[commonconfig.VersionConfigKey] = "v2"
+	misspelledSaramaConfigMap := newSettingsConfigMap()
+	misspelledSaramaConfigMap.Data[commonconfig.SaramaSettingsConfigKey] = "Admin:\n  Timout: 10000000000\n"
+	invalidDurationConfigMap := newSettingsConfigMap()
+	invalidDurationConfigMap.Data[commonconfig.SaramaSettingsConfigKey] = "Admin:\n  Timeout: 10s\n"
+	invalidAdminTypeConfigMap := newSettingsConfigMap()
+	invalidAdminTypeConfigMap.Data[commonconfig.EventingKafkaSettingsConfigKey] = "kafka:\n  adminType: unknown\n"
+	otherConfigMap := newSettingsConfigMap()
+	otherConfigMap.Name = "other-configmap"
+	otherConfigMap.Data[commonconfig.VersionConfigKey] = "v2"
+
+	// Define The Test Cases
+	tests := []struct {
+		name        string
+		configMap   *corev1.ConfigMap
+		rawObject   []byte
+		wantAllowed bool
+	}{
+		{name: "Valid Settings", configMap: newSettingsConfigMap(), wantAllowed: true},
+		{name: "Unsupported Version", configMap: invalidVersionConfigMap},
+		{name: "Misspelled Sarama Field", configMap: misspelledSaramaConfigMap},
+		{name: "Invalid Sarama Duration", configMap: invalidDurationConfigMap},
+		{name: "Invalid EventingKafka Settings", configMap: invalidAdminTypeConfigMap},
+		{name: "Other ConfigMap", configMap: otherConfigMap, wantAllowed: true},
+		{name: "Undecodable ConfigMap", configMap: newSettingsConfigMap(), rawObject: []byte("{")},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create An AdmissionRequest For The ConfigMap
+			rawObject := test.rawObject
+			if rawObject == nil {
+				var err error
+				rawObject, err = json.Marshal(test.configMap)
+				assert.Nil(t, err)
+			}
+			request := &admissionv1.AdmissionRequest{
+				Name:      test.configMap.Name,
+				Namespace: test.configMap.Namespace,
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: rawObject},
+			}
+
+			// Perform The Test
+			response := (&reconciler{}).Admit(logtesting.TestContextWithLogger(t), request)
+
+			// Verify The Results
+			assert.Equal(t, test.wantAllowed, response.Allowed)
+			if !test.wantAllowed {
+				assert.NotEmpty(t, response.Result.Message)
+			}
+		})
+	}
+}
+
+// Test The Reconciliation Of The ValidatingWebhookConfiguration
+func TestReconcile(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Test Data
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: commonconstants.KnativeEventingNamespace, Name: testSecretName},
+		Data:       map[string][]byte{certresources.CACert: testCACert},
+	}
+	vwh := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: testWebhookName},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name: testWebhookName,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{Namespace: commonconstants.KnativeEventingNamespace, Name: testServiceName},
+			},
+		}},
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name       string
+		leader     bool
+		secret     *corev1.Secret
+		wantErr    bool
+		wantUpdate bool
+	}{
+		{name: "Not Leader", secret: secret},
+		{name: "Missing Secret", leader: true, wantErr: true},
+		{name: "Missing CA Cert", leader: true, secret: &corev1.Secret{ObjectMeta: secret.ObjectMeta}, wantErr: true},
+		{name: "Updated Webhook", leader: true, secret: secret, wantUpdate: true},
+	}
+
+	// Run The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create The Reconciler With Listers & A Fake Clientset Of The Test Objects
+			r, kubeClientset := newTestReconciler(t, vwh, test.secret)
+			if test.leader {
+				assert.Nil(t, r.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {}))
+			}
+
+			// Perform The Test
+			err := r.Reconcile(logtesting.TestContextWithLogger(t), testWebhookName)
+
+			// Verify The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			updatedVwh, err := kubeClientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), testWebhookName, metav1.GetOptions{})
+			assert.Nil(t, err)
+			if !test.wantUpdate {
+				assert.Equal(t, vwh, updatedVwh)
+				return
+			}
+			webhook := updatedVwh.Webhooks[0]
+			assert.Equal(t, testCACert, webhook.ClientConfig.CABundle)
+			assert.Equal(t, ptr.String(testWebhookPath), webhook.ClientConfig.Service.Path)
+			assert.Equal(t, []string{"configmaps"}, webhook.Rules[0].Resources)
+			assert.Equal(t, commonconfig.SettingsConfigMapLabel, webhook.ObjectSelector.MatchExpressions[0].Key)
+		})
+	}
+}
+
+// Utility Function For Creating A Valid Eventing-Kafka Settings ConfigMap
+func newSettingsConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Name:      commonconfig.SettingsConfigMapName,
+			Labels:    map[string]string{commonconfig.SettingsConfigMapLabel: "true"},
+		},
+		Data: map[string]string{
+			commonconfig.VersionConfigKey:               commonconfig.SettingsVersionV1,
+			commonconfig.SaramaSettingsConfigKey:        controllertesting.SaramaConfigYaml,
+			commonconfig.EventingKafkaSettingsConfigKey: controllertesting.ControllerConfigYaml,
+		},
+	}
+}
+
+// Utility Function For Creating A Reconciler (And Its Fake Clientset) With The Specified Webhook Configuration & Secret
+func newTestReconciler(t *testing.T, vwh *admissionregistrationv1.ValidatingWebhookConfiguration, secret *corev1.Secret) (*reconciler, *fake.Clientset) {
+	vwhIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.Nil(t, vwhIndexer.Add(vwh))
+	objects := []runtime.Object{vwh}
+	if secret != nil {
+		assert.Nil(t, secretIndexer.Add(secret))
+		objects = append(objects, secret)
+	}
+	kubeClientset := fake.NewSimpleClientset(objects...)
+	return &reconciler{
+		key:          types.NamespacedName{Name: testWebhookName},
+		path:         testWebhookPath,
+		secretName:   testSecretName,
+		client:       kubeClientset,
+		vwhlister:    admissionlisters.NewValidatingWebhookConfigurationLister(vwhIndexer),
+		secretlister: corelisters.NewSecretLister(secretIndexer),
+	}, kubeClientset
+}
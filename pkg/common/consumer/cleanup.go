@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultGroupCleanupGracePeriod is the default time between the removal of a subscription and
+	// the deletion of its consumer group.
+	DefaultGroupCleanupGracePeriod = 5 * time.Minute
+
+	// maxGroupCleanupAttempts is the number of times the deletion of a consumer group is attempted,
+	// each attempt after another grace period.
+	maxGroupCleanupAttempts = 3
+)
+
+// newClusterAdmin creates the ClusterAdmin used to delete consumer groups, it is a variable to
+// facilitate testing.
+var newClusterAdmin = sarama.NewClusterAdmin
+
+// GroupCleaner deletes the consumer groups of removed subscriptions, together with their committed
+// offsets, once a grace period has elapsed.  The grace period lets the members of the closed group
+// leave it, and a subscription which is added back before it elapses keeps its offsets.
+type GroupCleaner struct {
+	logger        *zap.SugaredLogger
+	brokers       []string
+	config        *sarama.Config
+	gracePeriod   time.Duration
+	reportFailure func(groupID string, err error)
+
+	mutex    sync.Mutex
+	pending  map[string]*time.Timer
+	deleting map[string]*time.Timer
+	failures map[string]error
+}
+
+// NewGroupCleaner creates a GroupCleaner deleting consumer groups from the specified Kafka cluster
+// after the grace period, where a negative grace period disables the deletion.  The optional
+// reportFailure function is called for each failed deletion attempt.
+func NewGroupCleaner(logger *zap.SugaredLogger, brokers []string, config *sarama.Config, gracePeriod time.Duration, reportFailure func(groupID string, err error)) *GroupCleaner {
+	return &GroupCleaner{
+		logger:        logger,
+		brokers:       brokers,
+		config:        config,
+		gracePeriod:   gracePeriod,
+		reportFailure: reportFailure,
+		pending:       make(map[string]*time.Timer),
+		deleting:      make(map[string]*time.Timer),
+		failures:      make(map[string]error),
+	}
+}
+
+// Schedule deletes the specified consumer group after the grace period.  It must only be called
+// once the group has been closed.  A nil GroupCleaner does nothing.
+func (c *GroupCleaner) Schedule(groupID string) {
+	if c == nil || c.gracePeriod < 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.schedule(groupID, 1)
+}
+
+// SetConfig sets the sarama config used by the deletions attempted hereafter, as is required when
+// the config of the consumer groups changes.  A nil GroupCleaner does nothing.
+func (c *GroupCleaner) SetConfig(config *sarama.Config) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.config = config
+}
+
+// Cancel cancels the pending deletion of the specified consumer group (if any), as is required
+// before the group is used again.  The outcome of a deletion already in progress is ignored.  A nil
+// GroupCleaner does nothing.
+func (c *GroupCleaner) Cancel(groupID string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if timer, ok := c.pending[groupID]; ok {
+		timer.Stop()
+		delete(c.pending, groupID)
+	}
+	delete(c.deleting, groupID)
+	delete(c.failures, groupID)
+}
+
+// Failures returns the last error of each consumer group whose deletion has failed, and which has
+// not been deleted by a later attempt.
+func (c *GroupCleaner) Failures() map[string]error {
+	failures := make(map[string]error)
+	if c == nil {
+		return failures
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for groupID, err := range c.failures {
+		failures[groupID] = err
+	}
+	return failures
+}
+
+// schedule must be called with the mutex held.
+func (c *GroupCleaner) schedule(groupID string, attempt int) {
+	if timer, ok := c.pending[groupID]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(c.gracePeriod, func() {
+		c.mutex.Lock()
+		if c.pending[groupID] != timer {
+			// Cancelled or rescheduled in the meantime
+			c.mutex.Unlock()
+			return
+		}
+		delete(c.pending, groupID)
+		c.deleting[groupID] = timer
+		config := c.config
+		c.mutex.Unlock()
+
+		err := c.deleteGroup(groupID, config)
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if c.deleting[groupID] != timer {
+			// Cancelled while deleting, the group is in use again
+			return
+		}
+		delete(c.deleting, groupID)
+		if _, ok := c.pending[groupID]; ok {
+			// Rescheduled while deleting, the newer deletion takes precedence
+			return
+		}
+		if err == nil {
+			c.logger.Infow("Deleted the consumer group of a removed subscription", zap.String("groupID", groupID))
+			delete(c.failures, groupID)
+			return
+		}
+		c.failures[groupID] = err
+		if c.reportFailure != nil {
+			c.reportFailure(groupID, err)
+		}
+		if attempt < maxGroupCleanupAttempts {
+			c.logger.Warnw("Failed to delete the consumer group of a removed subscription, retrying",
+				zap.String("groupID", groupID), zap.Int("attempt", attempt), zap.Error(err))
+			c.schedule(groupID, attempt+1)
+		} else {
+			c.logger.Errorw("Failed to delete the consumer group of a removed subscription, giving up",
+				zap.String("groupID", groupID), zap.Int("attempt", attempt), zap.Error(err))
+		}
+	})
+	c.pending[groupID] = timer
+}
+
+// deleteGroup deletes the specified consumer group, a group which does not exist is not an error.
+func (c *GroupCleaner) deleteGroup(groupID string, config *sarama.Config) error {
+	admin, err := newClusterAdmin(c.brokers, config)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	if err := admin.DeleteConsumerGroup(groupID); err != nil && !errors.Is(err, sarama.ErrGroupIDNotFound) {
+		return err
+	}
+	return nil
+}
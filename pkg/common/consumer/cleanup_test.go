@@ -0,0 +1,233 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+)
+
+// mockClusterAdmin records the deleted consumer groups, failing with the configured errors.
+type mockClusterAdmin struct {
+	sarama.ClusterAdmin
+	mutex   sync.Mutex
+	errs    map[string]error
+	deleted []string
+	calls   chan string
+	started chan string   // optional, receives each group before its deletion waits for release
+	release chan struct{} // optional, unblocks the deletions
+}
+
+func (m *mockClusterAdmin) DeleteConsumerGroup(group string) error {
+	if m.started != nil {
+		m.started <- group
+		<-m.release
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	defer func() { m.calls <- group }()
+	if err := m.errs[group]; err != nil {
+		return err
+	}
+	m.deleted = append(m.deleted, group)
+	return nil
+}
+
+func (m *mockClusterAdmin) Close() error {
+	return nil
+}
+
+func (m *mockClusterAdmin) deletedGroups() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.deleted...)
+}
+
+func withMockClusterAdmin(t *testing.T, admin *mockClusterAdmin) {
+	original := newClusterAdmin
+	newClusterAdmin = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	}
+	t.Cleanup(func() { newClusterAdmin = original })
+}
+
+func waitForCall(t *testing.T, admin *mockClusterAdmin, group string) {
+	t.Helper()
+	select {
+	case got := <-admin.calls:
+		if got != group {
+			t.Fatalf("DeleteConsumerGroup(%q), want %q", got, group)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for DeleteConsumerGroup(%q)", group)
+	}
+}
+
+func TestGroupCleaner(t *testing.T) {
+	failure := errors.New("not authorized")
+	admin := &mockClusterAdmin{
+		errs: map[string]error{
+			"missing": sarama.ErrGroupIDNotFound,
+			"failing": failure,
+		},
+		calls: make(chan string, 10),
+	}
+	withMockClusterAdmin(t, admin)
+
+	var reportedMutex sync.Mutex
+	var reported []string
+	cleaner := NewGroupCleaner(zap.NewNop().Sugar(), []string{"broker"}, sarama.NewConfig(), 10*time.Millisecond, func(groupID string, err error) {
+		reportedMutex.Lock()
+		defer reportedMutex.Unlock()
+		reported = append(reported, groupID)
+	})
+
+	// Cancelled groups are not deleted
+	cleaner.Schedule("cancelled")
+	cleaner.Cancel("cancelled")
+
+	cleaner.Schedule("group")
+	waitForCall(t, admin, "group")
+	cleaner.Schedule("missing")
+	waitForCall(t, admin, "missing")
+
+	// Failed deletions are retried and reported until the attempts are exhausted
+	cleaner.Schedule("failing")
+	for i := 0; i < maxGroupCleanupAttempts; i++ {
+		waitForCall(t, admin, "failing")
+	}
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case got := <-admin.calls:
+		t.Fatalf("unexpected DeleteConsumerGroup(%q)", got)
+	default:
+	}
+
+	if diff := cmp.Diff([]string{"group"}, admin.deletedGroups()); diff != "" {
+		t.Errorf("deleted groups (-want, +got) = %v", diff)
+	}
+	reportedMutex.Lock()
+	if diff := cmp.Diff([]string{"failing", "failing", "failing"}, reported); diff != "" {
+		t.Errorf("reported failures (-want, +got) = %v", diff)
+	}
+	reportedMutex.Unlock()
+	if diff := cmp.Diff(map[string]string{"failing": failure.Error()}, errorStrings(cleaner.Failures())); diff != "" {
+		t.Errorf("Failures() (-want, +got) = %v", diff)
+	}
+
+	// Using a group again clears its failure
+	cleaner.Cancel("failing")
+	if failures := cleaner.Failures(); len(failures) != 0 {
+		t.Errorf("Failures() = %v, want none", failures)
+	}
+}
+
+func TestGroupCleanerCancelDuringDeletion(t *testing.T) {
+	admin := &mockClusterAdmin{
+		errs:    map[string]error{"group": sarama.ErrNonEmptyGroup},
+		calls:   make(chan string, 10),
+		started: make(chan string, 10),
+		release: make(chan struct{}),
+	}
+	withMockClusterAdmin(t, admin)
+
+	var reportedMutex sync.Mutex
+	var reported []string
+	cleaner := NewGroupCleaner(zap.NewNop().Sugar(), []string{"broker"}, sarama.NewConfig(), 10*time.Millisecond, func(groupID string, err error) {
+		reportedMutex.Lock()
+		defer reportedMutex.Unlock()
+		reported = append(reported, groupID)
+	})
+
+	// A deletion failing because the group was used again in the meantime is neither reported nor retried
+	cleaner.Schedule("group")
+	if got := <-admin.started; got != "group" {
+		t.Fatalf("DeleteConsumerGroup(%q), want %q", got, "group")
+	}
+	cleaner.Cancel("group")
+	close(admin.release)
+	waitForCall(t, admin, "group")
+
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case got := <-admin.calls:
+		t.Fatalf("unexpected DeleteConsumerGroup(%q)", got)
+	default:
+	}
+	reportedMutex.Lock()
+	if len(reported) != 0 {
+		t.Errorf("reported failures = %v, want none", reported)
+	}
+	reportedMutex.Unlock()
+	if failures := cleaner.Failures(); len(failures) != 0 {
+		t.Errorf("Failures() = %v, want none", failures)
+	}
+}
+
+func TestGroupCleanerSetConfig(t *testing.T) {
+	admin := &mockClusterAdmin{calls: make(chan string, 10)}
+	configs := make(chan *sarama.Config, 10)
+	original := newClusterAdmin
+	newClusterAdmin = func(_ []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+		configs <- config
+		return admin, nil
+	}
+	t.Cleanup(func() { newClusterAdmin = original })
+
+	// Deletions use the config set last, even when scheduled before it was set
+	oldConfig := sarama.NewConfig()
+	newConfig := sarama.NewConfig()
+	cleaner := NewGroupCleaner(zap.NewNop().Sugar(), []string{"broker"}, oldConfig, 100*time.Millisecond, nil)
+	cleaner.Schedule("group")
+	cleaner.SetConfig(newConfig)
+	waitForCall(t, admin, "group")
+	if got := <-configs; got != newConfig {
+		t.Error("deletion did not use the config set last")
+	}
+
+	var nilCleaner *GroupCleaner
+	nilCleaner.SetConfig(newConfig)
+}
+
+func TestGroupCleanerDisabled(t *testing.T) {
+	admin := &mockClusterAdmin{calls: make(chan string, 1)}
+	withMockClusterAdmin(t, admin)
+
+	NewGroupCleaner(zap.NewNop().Sugar(), nil, nil, -1, nil).Schedule("group")
+	var nilCleaner *GroupCleaner
+	nilCleaner.Schedule("group")
+	nilCleaner.Cancel("group")
+
+	time.Sleep(50 * time.Millisecond)
+	if deleted := admin.deletedGroups(); len(deleted) != 0 {
+		t.Errorf("deleted groups = %v, want none", deleted)
+	}
+}
+
+func errorStrings(errs map[string]error) map[string]string {
+	strings := make(map[string]string, len(errs))
+	for key, err := range errs {
+		strings[key] = err.Error()
+	}
+	return strings
+}
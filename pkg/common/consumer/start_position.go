@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StartPositionAnnotation is the KafkaChannel annotation specifying the position from which the
+// consumer group of a new subscriber starts consuming: "earliest", "latest" or an RFC3339 timestamp.
+// It may be overridden for a single subscriber by suffixing the annotation with "." and the
+// subscriber's UID.
+const StartPositionAnnotation = "eventing-kafka.knative.dev/start-position"
+
+// StartPosition is the position from which a new consumer group starts consuming, the empty
+// StartPosition leaving it to the consumer group's configured initial offset.
+type StartPosition string
+
+const (
+	// StartPositionEarliest starts from the oldest offset retained by each partition.
+	StartPositionEarliest StartPosition = "earliest"
+	// StartPositionLatest starts from the next offset produced to each partition.
+	StartPositionLatest StartPosition = "latest"
+)
+
+// ParseStartPosition parses "earliest", "latest" or an RFC3339 timestamp, which is normalized to UTC.
+func ParseStartPosition(value string) (StartPosition, error) {
+	value = strings.TrimSpace(value)
+	switch StartPosition(strings.ToLower(value)) {
+	case "":
+		return "", nil
+	case StartPositionEarliest:
+		return StartPositionEarliest, nil
+	case StartPositionLatest:
+		return StartPositionLatest, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid start position %q, expected %q, %q or an RFC3339 timestamp", value, StartPositionEarliest, StartPositionLatest)
+	}
+	return StartPosition(t.UTC().Format(time.RFC3339Nano)), nil
+}
+
+// StartPositionFromAnnotations returns the StartPosition of the specified subscriber from the
+// KafkaChannel annotations, preferring the subscriber specific annotation over the channel wide one.
+func StartPositionFromAnnotations(annotations map[string]string, uid types.UID) (StartPosition, error) {
+	if value, ok := annotations[StartPositionAnnotation+"."+string(uid)]; ok {
+		return ParseStartPosition(value)
+	}
+	return ParseStartPosition(annotations[StartPositionAnnotation])
+}
+
+// offsetTime returns the time argument of sarama.Client.GetOffset() for the StartPosition.
+func (p StartPosition) offsetTime() (int64, error) {
+	switch p {
+	case StartPositionEarliest:
+		return sarama.OffsetOldest, nil
+	case StartPositionLatest:
+		return sarama.OffsetNewest, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(p))
+	if err != nil {
+		return 0, fmt.Errorf("invalid start position %q: %w", p, err)
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+// InitializeOffsets commits the offsets of the StartPosition for the consumer group, so that a new
+// group starts consuming the topic from there.  Partitions for which the group already has a
+// committed offset are left untouched, so only the first creation of the group is affected.  It
+// returns a description of the initialized offsets, or "" if none were initialized.
+func InitializeOffsets(client sarama.Client, groupID string, topic string, position StartPosition) (string, error) {
+	if position == "" {
+		return "", nil
+	}
+	offsetTime, err := position.offsetTime()
+	if err != nil {
+		return "", err
+	}
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	offsetManager, err := sarama.NewOffsetManagerFromClient(groupID, client)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the offset manager of group %s: %w", groupID, err)
+	}
+	defer offsetManager.Close()
+
+	offsets := make(map[int32]int64, len(partitions))
+	initialized := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		partitionOffsetManager, err := offsetManager.ManagePartition(topic, partition)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the committed offset of partition %d: %w", partition, err)
+		}
+		defer partitionOffsetManager.AsyncClose()
+
+		// Committed offsets are never negative, unlike the configured initial offset
+		if next, _ := partitionOffsetManager.NextOffset(); next >= 0 {
+			continue
+		}
+
+		offset, err := client.GetOffset(topic, partition, offsetTime)
+		if err == nil && offset < 0 {
+			// There are no messages after the timestamp
+			offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get the %s offset of partition %d: %w", position, partition, err)
+		}
+		offsets[partition] = offset
+		initialized = append(initialized, fmt.Sprintf("%d:%d", partition, offset))
+	}
+	if len(initialized) == 0 {
+		return "", nil
+	}
+	if err := commitOffsets(client, groupID, topic, offsets); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("started from %s at partition:offset %s", position, strings.Join(initialized, ", ")), nil
+}
+
+// commitOffsets synchronously commits the specified partition offsets of the topic for the consumer
+// group, unlike the sarama.OffsetManager whose commit failures are only logged, so that a failure is
+// not mistaken for the group starting from the committed offsets.
+func commitOffsets(client sarama.Client, groupID string, topic string, offsets map[int32]int64) error {
+	coordinator, err := client.Coordinator(groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get the coordinator of group %s: %w", groupID, err)
+	}
+
+	// Mirrors the request of the sarama.OffsetManager for a consumer group without members
+	request := &sarama.OffsetCommitRequest{
+		Version:                 1,
+		ConsumerGroup:           groupID,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+	}
+	timestamp := sarama.ReceiveTime
+	if retention := client.Config().Consumer.Offsets.Retention; retention > 0 {
+		request.Version = 2
+		request.RetentionTime = int64(retention / time.Millisecond)
+		timestamp = 0
+	}
+	for partition, offset := range offsets {
+		request.AddBlock(topic, partition, offset, timestamp, "")
+	}
+
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		return fmt.Errorf("failed to commit the offsets of group %s: %w", groupID, err)
+	}
+	for partition := range offsets {
+		if kerr, ok := response.Errors[topic][partition]; ok && kerr != sarama.ErrNoError {
+			return fmt.Errorf("failed to commit the offset of partition %d of group %s: %w", partition, groupID, kerr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseStartPosition(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		want    StartPosition
+		wantErr bool
+	}{
+		"empty":      {value: " ", want: ""},
+		"earliest":   {value: "Earliest", want: StartPositionEarliest},
+		"latest":     {value: "latest ", want: StartPositionLatest},
+		"timestamp":  {value: "2020-10-01T14:00:00+02:00", want: "2020-10-01T12:00:00Z"},
+		"invalid":    {value: "beginning", wantErr: true},
+		"bad format": {value: "2020-10-01 12:00", wantErr: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseStartPosition(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseStartPosition() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseStartPosition() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStartPositionFromAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		StartPositionAnnotation:          "earliest",
+		StartPositionAnnotation + ".456": "latest",
+	}
+	if got, _ := StartPositionFromAnnotations(annotations, types.UID("123")); got != StartPositionEarliest {
+		t.Errorf("StartPositionFromAnnotations(123) = %q, want %q", got, StartPositionEarliest)
+	}
+	if got, _ := StartPositionFromAnnotations(annotations, types.UID("456")); got != StartPositionLatest {
+		t.Errorf("StartPositionFromAnnotations(456) = %q, want %q", got, StartPositionLatest)
+	}
+	if got, _ := StartPositionFromAnnotations(nil, types.UID("123")); got != "" {
+		t.Errorf("StartPositionFromAnnotations(nil) = %q, want empty", got)
+	}
+}
+
+func TestInitializeOffsets(t *testing.T) {
+	const (
+		topic = "test-topic"
+		group = "test-group"
+	)
+	timestamp := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	timestampMillis := timestamp.UnixNano() / int64(time.Millisecond)
+
+	testCases := map[string]struct {
+		position  StartPosition
+		commitErr sarama.KError
+		want      string
+		wantErr   bool
+		commits   int
+	}{
+		"no position": {},
+		"earliest": {
+			position: StartPositionEarliest,
+			want:     "started from earliest at partition:offset 1:3, 2:5",
+			commits:  1,
+		},
+		"latest": {
+			position: StartPositionLatest,
+			want:     "started from latest at partition:offset 1:30, 2:50",
+			commits:  1,
+		},
+		"timestamp": {
+			position: StartPosition(timestamp.Format(time.RFC3339Nano)),
+			want:     "started from 2020-10-01T12:00:00Z at partition:offset 1:10, 2:50",
+			commits:  1,
+		},
+		"commit failure": {
+			position:  StartPositionEarliest,
+			commitErr: sarama.ErrNotCoordinatorForConsumer,
+			wantErr:   true,
+			commits:   1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+			broker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"MetadataRequest": sarama.NewMockMetadataResponse(t).
+					SetBroker(broker.Addr(), broker.BrokerID()).
+					SetLeader(topic, 0, broker.BrokerID()).
+					SetLeader(topic, 1, broker.BrokerID()).
+					SetLeader(topic, 2, broker.BrokerID()),
+				"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+					SetCoordinator(sarama.CoordinatorGroup, group, broker),
+				"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+					SetOffset(group, topic, 0, 7, "", sarama.ErrNoError).
+					SetOffset(group, topic, 1, -1, "", sarama.ErrNoError).
+					SetOffset(group, topic, 2, -1, "", sarama.ErrNoError),
+				"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+					SetOffset(topic, 1, sarama.OffsetOldest, 3).
+					SetOffset(topic, 1, sarama.OffsetNewest, 30).
+					SetOffset(topic, 1, timestampMillis, 10).
+					SetOffset(topic, 2, sarama.OffsetOldest, 5).
+					SetOffset(topic, 2, sarama.OffsetNewest, 50).
+					SetOffset(topic, 2, timestampMillis, -1),
+				"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t).
+					SetError(group, topic, 2, tc.commitErr),
+			})
+
+			config := sarama.NewConfig()
+			config.Version = sarama.V2_0_0_0
+			config.Consumer.Offsets.AutoCommit.Enable = false
+			client, err := sarama.NewClient([]string{broker.Addr()}, config)
+			if err != nil {
+				t.Fatalf("NewClient() = %v", err)
+			}
+			defer client.Close()
+
+			got, err := InitializeOffsets(client, group, topic, tc.position)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("InitializeOffsets() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("InitializeOffsets() = %q, want %q", got, tc.want)
+			}
+			commits := 0
+			for _, rr := range broker.History() {
+				if _, ok := rr.Request.(*sarama.OffsetCommitRequest); ok {
+					commits++
+				}
+			}
+			if commits != tc.commits {
+				t.Errorf("OffsetCommitRequests = %d, want %d", commits, tc.commits)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dlq provides the Kafka dead letter topic support shared by the KafkaChannel dispatchers,
+// and the reading and replaying of dead lettered events used by the dlq command.
+package dlq
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// DeadLetterTopicAnnotation is the KafkaChannel annotation naming the Kafka topic to which events
+	// which could not be delivered to a subscriber are written.  It may be overridden for a single
+	// subscriber by suffixing the annotation with "." and the subscriber's UID.
+	DeadLetterTopicAnnotation = "eventing-kafka.knative.dev/dead-letter-topic"
+
+	// HeaderPrefix is the prefix of all the Kafka headers added to dead lettered events.
+	HeaderPrefix = "eventing-kafka-dlq-"
+
+	// The Kafka headers added to dead lettered events.
+	ErrorHeader             = HeaderPrefix + "error"
+	AttemptsHeader          = HeaderPrefix + "attempts"
+	SubscriberHeader        = HeaderPrefix + "subscriber"
+	ChannelTopicHeader      = HeaderPrefix + "channel-topic"
+	OriginalTopicHeader     = HeaderPrefix + "original-topic"
+	OriginalPartitionHeader = HeaderPrefix + "original-partition"
+	OriginalOffsetHeader    = HeaderPrefix + "original-offset"
+)
+
+// DeadLetterTopic returns the dead letter topic of the specified subscriber from the KafkaChannel
+// annotations, preferring the subscriber specific annotation over the channel wide one.
+func DeadLetterTopic(annotations map[string]string, uid types.UID) string {
+	if topic, ok := annotations[DeadLetterTopicAnnotation+"."+string(uid)]; ok {
+		return strings.TrimSpace(topic)
+	}
+	return strings.TrimSpace(annotations[DeadLetterTopicAnnotation])
+}
+
+// NewDeadLetterMessage creates the message to be written to the dead letter topic for the specified
+// consumer message, which could not be delivered to the subscriber after the specified number of
+// attempts.  The channelTopic is the topic of the KafkaChannel into which the event is replayed.
+func NewDeadLetterMessage(message *sarama.ConsumerMessage, deadLetterTopic string, channelTopic string, subscriberUID types.UID, attempts int, deliveryErr error) *sarama.ProducerMessage {
+	errString := ""
+	if deliveryErr != nil {
+		errString = deliveryErr.Error()
+	}
+	headers := copyHeaders(message.Headers)
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(ErrorHeader), Value: []byte(errString)},
+		sarama.RecordHeader{Key: []byte(AttemptsHeader), Value: []byte(strconv.Itoa(attempts))},
+		sarama.RecordHeader{Key: []byte(SubscriberHeader), Value: []byte(subscriberUID)},
+		sarama.RecordHeader{Key: []byte(ChannelTopicHeader), Value: []byte(channelTopic)},
+		sarama.RecordHeader{Key: []byte(OriginalTopicHeader), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(OriginalPartitionHeader), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(OriginalOffsetHeader), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+	return newProducerMessage(deadLetterTopic, message, headers)
+}
+
+// NewReplayMessage creates the message which replays the specified dead lettered event into the
+// topic of its KafkaChannel, without the dead letter headers.
+func NewReplayMessage(entry Entry) *sarama.ProducerMessage {
+	return newProducerMessage(entry.ChannelTopic, entry.Message, copyHeaders(entry.Message.Headers))
+}
+
+// Entry is a single dead lettered event read from a dead letter topic.
+type Entry struct {
+	Partition         int32
+	Offset            int64
+	Error             string
+	Attempts          int
+	Subscriber        string
+	ChannelTopic      string
+	OriginalTopic     string
+	OriginalPartition int32
+	OriginalOffset    int64
+	Message           *sarama.ConsumerMessage
+}
+
+// ParseEntry parses the dead letter headers of the specified message read from a dead letter topic.
+// Headers which are missing or invalid are left as zero values.
+func ParseEntry(message *sarama.ConsumerMessage) Entry {
+	entry := Entry{
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Message:   message,
+	}
+	for _, header := range message.Headers {
+		if header == nil {
+			continue
+		}
+		value := string(header.Value)
+		switch string(header.Key) {
+		case ErrorHeader:
+			entry.Error = value
+		case AttemptsHeader:
+			entry.Attempts, _ = strconv.Atoi(value)
+		case SubscriberHeader:
+			entry.Subscriber = value
+		case ChannelTopicHeader:
+			entry.ChannelTopic = value
+		case OriginalTopicHeader:
+			entry.OriginalTopic = value
+		case OriginalPartitionHeader:
+			partition, _ := strconv.ParseInt(value, 10, 32)
+			entry.OriginalPartition = int32(partition)
+		case OriginalOffsetHeader:
+			entry.OriginalOffset, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return entry
+}
+
+// CountAttempts wraps the specified CheckRetry function (which may be nil) to count the attempts made
+// to the specified destination.  The CheckRetry function is called after every attempt, including
+// those to the reply and dead letter sink, so attempts to other URLs are not counted.
+func CountAttempts(checkRetry kncloudevents.CheckRetry, destination *url.URL, attempts *int32) kncloudevents.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		var target string
+		if response != nil && response.Request != nil && response.Request.URL != nil {
+			target = response.Request.URL.String()
+		} else if urlErr, ok := err.(*url.Error); ok {
+			target = urlErr.URL
+		}
+		if destination != nil && target == destination.String() {
+			atomic.AddInt32(attempts, 1)
+		}
+		if checkRetry == nil {
+			return false, nil
+		}
+		return checkRetry(ctx, response, err)
+	}
+}
+
+// copyHeaders copies the specified headers, excluding any dead letter headers.
+func copyHeaders(headers []*sarama.RecordHeader) []sarama.RecordHeader {
+	copied := make([]sarama.RecordHeader, 0, len(headers)+7)
+	for _, header := range headers {
+		if header != nil && !strings.HasPrefix(string(header.Key), HeaderPrefix) {
+			copied = append(copied, *header)
+		}
+	}
+	return copied
+}
+
+// newProducerMessage creates a message for the specified topic with the key and value of the
+// specified consumer message and the specified headers.
+func newProducerMessage(topic string, message *sarama.ConsumerMessage, headers []sarama.RecordHeader) *sarama.ProducerMessage {
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(message.Key)
+	}
+	return producerMessage
+}
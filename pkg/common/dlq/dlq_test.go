@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dlq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDeadLetterTopic(t *testing.T) {
+	annotations := map[string]string{
+		DeadLetterTopicAnnotation:          "channel-dlq",
+		DeadLetterTopicAnnotation + ".456": " subscriber-dlq ",
+	}
+	testCases := map[string]struct {
+		annotations map[string]string
+		uid         string
+		want        string
+	}{
+		"no annotations":        {uid: "123", want: ""},
+		"channel wide":          {annotations: annotations, uid: "123", want: "channel-dlq"},
+		"subscriber override":   {annotations: annotations, uid: "456", want: "subscriber-dlq"},
+		"subscriber only":       {annotations: map[string]string{DeadLetterTopicAnnotation + ".456": "x"}, uid: "123", want: ""},
+		"subscriber disabled":   {annotations: map[string]string{DeadLetterTopicAnnotation: "x", DeadLetterTopicAnnotation + ".456": ""}, uid: "456", want: ""},
+		"channel wide disabled": {annotations: map[string]string{DeadLetterTopicAnnotation: ""}, uid: "123", want: ""},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := DeadLetterTopic(tc.annotations, types.UID(tc.uid)); got != tc.want {
+				t.Errorf("DeadLetterTopic() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeadLetterMessageRoundTrip(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Topic:     "channel-topic.retry.2",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("ce_id"), Value: []byte("1")},
+			{Key: []byte(ErrorHeader), Value: []byte("stale error")},
+		},
+	}
+
+	deadLetterMessage := NewDeadLetterMessage(message, "dlq-topic", "channel-topic", "sub-uid", 4, errors.New("delivery failed"))
+	if deadLetterMessage.Topic != "dlq-topic" {
+		t.Errorf("Topic = %q, want %q", deadLetterMessage.Topic, "dlq-topic")
+	}
+
+	// Read the dead letter message back as it would be consumed from the dead letter topic
+	consumed := consumerMessage("dlq-topic", 1, 7, deadLetterMessage)
+	entry := ParseEntry(consumed)
+	want := Entry{
+		Partition:         1,
+		Offset:            7,
+		Error:             "delivery failed",
+		Attempts:          4,
+		Subscriber:        "sub-uid",
+		ChannelTopic:      "channel-topic",
+		OriginalTopic:     "channel-topic.retry.2",
+		OriginalPartition: 3,
+		OriginalOffset:    42,
+		Message:           consumed,
+	}
+	if diff := cmp.Diff(want, entry); diff != "" {
+		t.Errorf("ParseEntry() (-want, +got) = %v", diff)
+	}
+
+	// The replayed message targets the channel topic with only the original headers
+	replay := NewReplayMessage(entry)
+	if replay.Topic != "channel-topic" {
+		t.Errorf("replay Topic = %q, want %q", replay.Topic, "channel-topic")
+	}
+	wantHeaders := []sarama.RecordHeader{{Key: []byte("ce_id"), Value: []byte("1")}}
+	if diff := cmp.Diff(wantHeaders, replay.Headers); diff != "" {
+		t.Errorf("replay Headers (-want, +got) = %v", diff)
+	}
+	if key, _ := replay.Key.Encode(); string(key) != "key" {
+		t.Errorf("replay Key = %q, want %q", key, "key")
+	}
+	if value, _ := replay.Value.Encode(); string(value) != "value" {
+		t.Errorf("replay Value = %q, want %q", value, "value")
+	}
+}
+
+func TestCountAttempts(t *testing.T) {
+	destination, _ := url.Parse("http://subscriber/")
+	reply, _ := url.Parse("http://reply/")
+	var attempts int32
+	var delegated int
+	checkRetry := CountAttempts(func(context.Context, *http.Response, error) (bool, error) {
+		delegated++
+		return true, nil
+	}, destination, &attempts)
+
+	responseFor := func(u *url.URL) *http.Response {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Request: &http.Request{URL: u}}
+	}
+	_, _ = checkRetry(context.Background(), responseFor(destination), nil)
+	_, _ = checkRetry(context.Background(), nil, &url.Error{Op: "Post", URL: destination.String(), Err: errors.New("refused")})
+	_, _ = checkRetry(context.Background(), responseFor(reply), nil)
+
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	if delegated != 3 {
+		t.Errorf("delegated = %d, want 3", delegated)
+	}
+
+	// A nil CheckRetry never retries
+	noRetry := CountAttempts(nil, destination, &attempts)
+	if retry, err := noRetry(context.Background(), responseFor(destination), nil); retry || err != nil {
+		t.Errorf("nil CheckRetry = %t, %v, want false, nil", retry, err)
+	}
+}
+
+// consumerMessage converts the specified producer message into the consumer message read at the
+// specified partition and offset of the specified topic.
+func consumerMessage(topic string, partition int32, offset int64, message *sarama.ProducerMessage) *sarama.ConsumerMessage {
+	consumed := &sarama.ConsumerMessage{Topic: topic, Partition: partition, Offset: offset}
+	if message.Key != nil {
+		consumed.Key, _ = message.Key.Encode()
+	}
+	if message.Value != nil {
+		consumed.Value, _ = message.Value.Encode()
+	}
+	for i := range message.Headers {
+		consumed.Headers = append(consumed.Headers, &message.Headers[i])
+	}
+	return consumed
+}
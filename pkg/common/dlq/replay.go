@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dlq
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// OffsetGetter gets the oldest and newest offsets of a topic partition (implemented by sarama.Client).
+type OffsetGetter interface {
+	GetOffset(topic string, partitionID int32, time int64) (int64, error)
+}
+
+// Read calls fn with each event currently on the specified dead letter topic, in partition and
+// offset order.  Events produced to the topic after the read started are not included.
+func Read(offsetGetter OffsetGetter, consumer sarama.Consumer, topic string, fn func(Entry) error) error {
+	partitions, err := consumer.Partitions(topic)
+	if err != nil {
+		return fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	for _, partition := range partitions {
+		if err := readPartition(offsetGetter, consumer, topic, partition, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPartition calls fn with each event currently on the specified partition.
+func readPartition(offsetGetter OffsetGetter, consumer sarama.Consumer, topic string, partition int32, fn func(Entry) error) error {
+	oldest, err := offsetGetter.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return fmt.Errorf("failed to get the oldest offset of partition %d: %w", partition, err)
+	}
+	newest, err := offsetGetter.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return fmt.Errorf("failed to get the newest offset of partition %d: %w", partition, err)
+	}
+	if oldest >= newest {
+		return nil
+	}
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, oldest)
+	if err != nil {
+		return fmt.Errorf("failed to consume partition %d: %w", partition, err)
+	}
+	defer partitionConsumer.AsyncClose()
+
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return nil
+			}
+			if err := fn(ParseEntry(message)); err != nil {
+				return err
+			}
+			if message.Offset >= newest-1 {
+				return nil
+			}
+		case consumerErr, ok := <-partitionConsumer.Errors():
+			if ok {
+				return fmt.Errorf("failed to read partition %d: %w", partition, consumerErr)
+			}
+		}
+	}
+}
+
+// Replay produces each of the specified dead lettered events back into the topic of its KafkaChannel.
+func Replay(producer sarama.SyncProducer, entries []Entry) error {
+	for _, entry := range entries {
+		if entry.ChannelTopic == "" {
+			return fmt.Errorf("event at partition %d offset %d has no %s header", entry.Partition, entry.Offset, ChannelTopicHeader)
+		}
+		if _, _, err := producer.SendMessage(NewReplayMessage(entry)); err != nil {
+			return fmt.Errorf("failed to replay event at partition %d offset %d: %w", entry.Partition, entry.Offset, err)
+		}
+	}
+	return nil
+}
+
+// Selection identifies a set of events on a dead letter topic by partition and offset.
+type Selection map[int32]map[int64]bool
+
+// ParseSelection parses a comma separated list of "<partition>:<offset>" event identifiers.
+func ParseSelection(selection string) (Selection, error) {
+	parsed := make(Selection)
+	for _, item := range strings.Split(selection, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid event %q, expected <partition>:<offset>", item)
+		}
+		partition, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partition in event %q: %w", item, err)
+		}
+		offset, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset in event %q: %w", item, err)
+		}
+		if parsed[int32(partition)] == nil {
+			parsed[int32(partition)] = make(map[int64]bool)
+		}
+		parsed[int32(partition)][offset] = true
+	}
+	return parsed, nil
+}
+
+// Contains returns whether the specified entry is selected.
+func (s Selection) Contains(entry Entry) bool {
+	return s[entry.Partition][entry.Offset]
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dlq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+)
+
+//------ Mocks
+
+// mockTopic holds the messages of each partition of a topic, indexed by offset from the oldest offset.
+type mockTopic struct {
+	oldest   map[int32]int64
+	messages map[int32][]*sarama.ConsumerMessage
+}
+
+func (m *mockTopic) GetOffset(_ string, partition int32, time int64) (int64, error) {
+	if time == sarama.OffsetOldest {
+		return m.oldest[partition], nil
+	}
+	return m.oldest[partition] + int64(len(m.messages[partition])), nil
+}
+
+func (m *mockTopic) Topics() ([]string, error) {
+	panic("implement me")
+}
+
+func (m *mockTopic) Partitions(_ string) ([]int32, error) {
+	partitions := make([]int32, 0, len(m.messages))
+	for partition := range m.messages {
+		partitions = append(partitions, partition)
+	}
+	return partitions, nil
+}
+
+func (m *mockTopic) ConsumePartition(_ string, partition int32, offset int64) (sarama.PartitionConsumer, error) {
+	messagesCh := make(chan *sarama.ConsumerMessage, len(m.messages[partition]))
+	for _, message := range m.messages[partition][offset-m.oldest[partition]:] {
+		messagesCh <- message
+	}
+	return &mockPartitionConsumer{messages: messagesCh, errors: make(chan *sarama.ConsumerError)}, nil
+}
+
+func (m *mockTopic) HighWaterMarks() map[string]map[int32]int64 {
+	panic("implement me")
+}
+
+func (m *mockTopic) Close() error {
+	return nil
+}
+
+type mockPartitionConsumer struct {
+	messages chan *sarama.ConsumerMessage
+	errors   chan *sarama.ConsumerError
+}
+
+func (m *mockPartitionConsumer) AsyncClose() {}
+
+func (m *mockPartitionConsumer) Close() error {
+	return nil
+}
+
+func (m *mockPartitionConsumer) Messages() <-chan *sarama.ConsumerMessage {
+	return m.messages
+}
+
+func (m *mockPartitionConsumer) Errors() <-chan *sarama.ConsumerError {
+	return m.errors
+}
+
+func (m *mockPartitionConsumer) HighWaterMarkOffset() int64 {
+	panic("implement me")
+}
+
+type mockSyncProducer struct {
+	messages []*sarama.ProducerMessage
+	err      error
+}
+
+func (m *mockSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	if m.err != nil {
+		return -1, -1, m.err
+	}
+	m.messages = append(m.messages, message)
+	return 0, int64(len(m.messages)), nil
+}
+
+func (m *mockSyncProducer) SendMessages(_ []*sarama.ProducerMessage) error {
+	panic("implement me")
+}
+
+func (m *mockSyncProducer) Close() error {
+	return nil
+}
+
+//------ Tests
+
+func TestRead(t *testing.T) {
+	topic := &mockTopic{
+		oldest: map[int32]int64{0: 5, 1: 0, 2: 0},
+		messages: map[int32][]*sarama.ConsumerMessage{
+			1: {deadLetterMessage(1, 0), deadLetterMessage(1, 1)},
+			0: {deadLetterMessage(0, 5)},
+			2: {},
+		},
+	}
+
+	var got [][2]int64
+	err := Read(topic, topic, "dlq-topic", func(entry Entry) error {
+		got = append(got, [2]int64{int64(entry.Partition), entry.Offset})
+		if entry.ChannelTopic != "channel-topic" {
+			t.Errorf("ChannelTopic = %q, want %q", entry.ChannelTopic, "channel-topic")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	want := [][2]int64{{0, 5}, {1, 0}, {1, 1}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() entries (-want, +got) = %v", diff)
+	}
+
+	// Errors from the callback stop the read
+	stopErr := errors.New("stop")
+	if err := Read(topic, topic, "dlq-topic", func(Entry) error { return stopErr }); err != stopErr {
+		t.Errorf("Read() = %v, want %v", err, stopErr)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	entries := []Entry{
+		ParseEntry(deadLetterMessage(0, 1)),
+		ParseEntry(deadLetterMessage(1, 2)),
+	}
+
+	producer := &mockSyncProducer{}
+	if err := Replay(producer, entries); err != nil {
+		t.Fatalf("Replay() = %v", err)
+	}
+	if len(producer.messages) != 2 {
+		t.Fatalf("len(messages) = %d, want 2", len(producer.messages))
+	}
+	for _, message := range producer.messages {
+		if message.Topic != "channel-topic" {
+			t.Errorf("Topic = %q, want %q", message.Topic, "channel-topic")
+		}
+	}
+
+	// Producer failures and events without a channel topic are errors
+	if err := Replay(&mockSyncProducer{err: errors.New("produce failed")}, entries); err == nil {
+		t.Error("Replay() with failing producer = nil, want error")
+	}
+	if err := Replay(producer, []Entry{{Message: &sarama.ConsumerMessage{}}}); err == nil {
+		t.Error("Replay() without channel topic = nil, want error")
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	selection, err := ParseSelection("0:5, 1:2,1:3,")
+	if err != nil {
+		t.Fatalf("ParseSelection() = %v", err)
+	}
+	want := Selection{0: {5: true}, 1: {2: true, 3: true}}
+	if diff := cmp.Diff(want, selection); diff != "" {
+		t.Errorf("ParseSelection() (-want, +got) = %v", diff)
+	}
+	if !selection.Contains(Entry{Partition: 1, Offset: 3}) {
+		t.Error("Contains(1:3) = false, want true")
+	}
+	if selection.Contains(Entry{Partition: 0, Offset: 3}) {
+		t.Error("Contains(0:3) = true, want false")
+	}
+
+	for _, invalid := range []string{"1", "a:1", "1:b", "1:2:3"} {
+		if _, err := ParseSelection(invalid); err == nil {
+			t.Errorf("ParseSelection(%q) = nil error, want error", invalid)
+		}
+	}
+}
+
+// deadLetterMessage creates a message read from the dead letter topic at the specified partition and offset.
+func deadLetterMessage(partition int32, offset int64) *sarama.ConsumerMessage {
+	original := &sarama.ConsumerMessage{Topic: "channel-topic", Value: []byte("value")}
+	return consumerMessage("dlq-topic", partition, offset, NewDeadLetterMessage(original, "dlq-topic", "channel-topic", "sub-uid", 1, errors.New("failed")))
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filter provides the per-subscriber CloudEvent attribute filters evaluated by the
+// KafkaChannel dispatchers before delivering an event to a subscriber.
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Annotation is the KafkaChannel annotation holding the JSON filter applied to the events delivered
+// to its subscribers, for example {"exact":{"type":"com.example.order"},"prefix":{"source":"/orders/"}}.
+// It may be overridden for a single subscriber by suffixing the annotation with "." and the
+// subscriber's UID, where an empty value disables filtering for that subscriber.
+const Annotation = "eventing-kafka.knative.dev/filter"
+
+// Filter matches the events whose CloudEvent attributes or extensions equal every Exact value and
+// start with every Prefix value.  Attribute names are case insensitive.
+type Filter struct {
+	Exact  map[string]string `json:"exact,omitempty"`
+	Prefix map[string]string `json:"prefix,omitempty"`
+}
+
+// Parse parses the JSON representation of a Filter, returning nil if it is empty.
+func Parse(value string) (*Filter, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	filter := &Filter{}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(filter); err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", value, err)
+	}
+	if len(filter.Exact) == 0 && len(filter.Prefix) == 0 {
+		return nil, nil
+	}
+	filter.Exact = lowerKeys(filter.Exact)
+	filter.Prefix = lowerKeys(filter.Prefix)
+	return filter, nil
+}
+
+// FromAnnotations returns the Filter of the specified subscriber from the KafkaChannel annotations,
+// preferring the subscriber specific annotation over the channel wide one.  It returns nil if the
+// subscriber's events are not filtered.
+func FromAnnotations(annotations map[string]string, uid k8stypes.UID) (*Filter, error) {
+	if value, ok := annotations[Annotation+"."+string(uid)]; ok {
+		return Parse(value)
+	}
+	return Parse(annotations[Annotation])
+}
+
+// Match returns whether the specified message matches the filter.  A nil filter matches every
+// message.  An error is returned if the message is not a valid CloudEvent.
+func (f *Filter) Match(ctx context.Context, message binding.Message) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	e, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		return false, err
+	}
+	return f.MatchEvent(e), nil
+}
+
+// MatchEvent returns whether the specified event matches the filter.
+func (f *Filter) MatchEvent(e *event.Event) bool {
+	if f == nil {
+		return true
+	}
+	for name, expected := range f.Exact {
+		if value, ok := attribute(e, name); !ok || value != expected {
+			return false
+		}
+	}
+	for name, prefix := range f.Prefix {
+		if value, ok := attribute(e, name); !ok || !strings.HasPrefix(value, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the JSON representation of the filter.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	bytes, _ := json.Marshal(f)
+	return string(bytes)
+}
+
+// attribute returns the canonical string value of the named context attribute or extension.
+func attribute(e *event.Event, name string) (string, bool) {
+	var value interface{}
+	if version := spec.VS.Version(e.SpecVersion()); version != nil && version.Attribute(name) != nil {
+		value = version.Attribute(name).Get(e.Context)
+	} else {
+		value = e.Extensions()[name]
+	}
+	if value == nil {
+		return "", false
+	}
+	s, err := types.Format(value)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+func lowerKeys(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	lowered := make(map[string]string, len(m))
+	for k, v := range m {
+		lowered[strings.ToLower(k)] = v
+	}
+	return lowered
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		want    *Filter
+		wantErr bool
+	}{
+		"empty":        {value: "  "},
+		"empty object": {value: "{}"},
+		"exact and prefix": {
+			value: `{"exact":{"Type":"order.created"},"prefix":{"source":"/orders/"}}`,
+			want:  &Filter{Exact: map[string]string{"type": "order.created"}, Prefix: map[string]string{"source": "/orders/"}},
+		},
+		"invalid json":  {value: `{"exact":`, wantErr: true},
+		"unknown field": {value: `{"suffix":{"type":"x"}}`, wantErr: true},
+		"wrong type":    {value: `{"exact":"type"}`, wantErr: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestFromAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		Annotation:          `{"exact":{"type":"channel"}}`,
+		Annotation + ".456": `{"exact":{"type":"subscriber"}}`,
+		Annotation + ".789": "",
+	}
+	testCases := map[string]struct {
+		uid  string
+		want *Filter
+	}{
+		"channel wide":        {uid: "123", want: &Filter{Exact: map[string]string{"type": "channel"}}},
+		"subscriber override": {uid: "456", want: &Filter{Exact: map[string]string{"type": "subscriber"}}},
+		"subscriber disabled": {uid: "789"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := FromAnnotations(annotations, types.UID(tc.uid))
+			if err != nil {
+				t.Fatalf("FromAnnotations() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FromAnnotations() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.order.created")
+	e.SetSource("/orders/eu/123")
+	e.SetTime(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC))
+	e.SetExtension("region", "eu-west-1")
+	e.SetExtension("priority", 5)
+
+	testCases := map[string]struct {
+		filter *Filter
+		want   bool
+	}{
+		"nil filter":         {want: true},
+		"exact attribute":    {filter: &Filter{Exact: map[string]string{"type": "com.example.order.created"}}, want: true},
+		"exact mismatch":     {filter: &Filter{Exact: map[string]string{"type": "com.example.order"}}},
+		"prefix attribute":   {filter: &Filter{Prefix: map[string]string{"source": "/orders/eu/"}}, want: true},
+		"prefix mismatch":    {filter: &Filter{Prefix: map[string]string{"source": "/orders/us/"}}},
+		"exact extension":    {filter: &Filter{Exact: map[string]string{"region": "eu-west-1"}}, want: true},
+		"integer extension":  {filter: &Filter{Exact: map[string]string{"priority": "5"}}, want: true},
+		"prefix extension":   {filter: &Filter{Prefix: map[string]string{"region": "eu-"}}, want: true},
+		"missing extension":  {filter: &Filter{Prefix: map[string]string{"tenant": ""}}},
+		"missing attribute":  {filter: &Filter{Exact: map[string]string{"subject": ""}}},
+		"time attribute":     {filter: &Filter{Prefix: map[string]string{"time": "2020-10-01T"}}, want: true},
+		"all must match":     {filter: &Filter{Exact: map[string]string{"type": "com.example.order.created"}, Prefix: map[string]string{"region": "us-"}}},
+		"all terms matching": {filter: &Filter{Exact: map[string]string{"type": "com.example.order.created"}, Prefix: map[string]string{"region": "eu-"}}, want: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.filter.Match(context.Background(), binding.ToMessage(&e))
+			if err != nil {
+				t.Fatalf("Match() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Match() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	var nilFilter *Filter
+	if got := nilFilter.String(); got != "" {
+		t.Errorf("String() = %q, want empty", got)
+	}
+	f := &Filter{Prefix: map[string]string{"source": "/a"}, Exact: map[string]string{"type": "t", "id": "1"}}
+	want := `{"exact":{"id":"1","type":"t"},"prefix":{"source":"/a"}}`
+	if got := f.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package partition selects the key of the Kafka records written by the KafkaChannel ingresses,
+// which determines the partition of the records and therefore the events delivered in order.
+package partition
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// KeyAttributeAnnotation is the KafkaChannel annotation naming the CloudEvent attribute or
+	// extension whose value is the key of the records written to the channel's topic.
+	KeyAttributeAnnotation = "eventing-kafka.knative.dev/partition-key-attribute"
+
+	// DefaultKeyAttribute is the partitionkey extension of the CloudEvents Partitioning extension
+	// specification, which keys the records unless the channel names another attribute.
+	DefaultKeyAttribute = "partitionkey"
+)
+
+// KeyAttribute returns the name of the attribute keying the records of the channel from the
+// KafkaChannel annotations.
+func KeyAttribute(annotations map[string]string) string {
+	if attribute := strings.ToLower(strings.TrimSpace(annotations[KeyAttributeAnnotation])); attribute != "" {
+		return attribute
+	}
+	return DefaultKeyAttribute
+}
+
+// WriteProducerMessage fills the producerMessage with the message like the kafka_sarama
+// WriteProducerMessage, keying the record with the value of the named attribute or extension of
+// the event.  Events without the attribute are written without a key, spreading them across the
+// partitions of the topic.
+func WriteProducerMessage(ctx context.Context, message binding.Message, producerMessage *sarama.ProducerMessage, keyAttribute string, transformers ...binding.Transformer) error {
+	if keyAttribute == "" || keyAttribute == DefaultKeyAttribute {
+		// The kafka_sarama protocol maps the partitionkey extension itself
+		return protocolkafka.WriteProducerMessage(ctx, message, producerMessage, transformers...)
+	}
+
+	var key string
+	transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, _ binding.MessageMetadataWriter) error {
+		value := attribute(r, keyAttribute)
+		if types.IsZero(value) {
+			return nil
+		}
+		s, err := types.Format(value)
+		if err != nil {
+			return err
+		}
+		key = s
+		return nil
+	}))
+
+	err := protocolkafka.WriteProducerMessage(protocolkafka.WithSkipKeyMapping(ctx), message, producerMessage, transformers...)
+	if key != "" {
+		producerMessage.Key = sarama.StringEncoder(key)
+	}
+	return err
+}
+
+// attribute returns the value of the named context attribute or extension, if any.
+func attribute(r binding.MessageMetadataReader, name string) interface{} {
+	for kind := spec.ID; kind <= spec.Time; kind++ {
+		if attr, value := r.GetAttribute(kind); attr != nil && attr.Name() == name {
+			return value
+		}
+	}
+	return r.GetExtension(name)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+)
+
+func TestKeyAttribute(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        string
+	}{
+		"default":   {want: DefaultKeyAttribute},
+		"empty":     {annotations: map[string]string{KeyAttributeAnnotation: " "}, want: DefaultKeyAttribute},
+		"attribute": {annotations: map[string]string{KeyAttributeAnnotation: " Subject "}, want: "subject"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := KeyAttribute(tc.annotations); got != tc.want {
+				t.Errorf("KeyAttribute() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteProducerMessage(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.order.created")
+	event.SetSource("/orders")
+	event.SetSubject("order-1")
+	event.SetExtension("partitionkey", "customer-1")
+	event.SetExtension("tenant", "tenant-1")
+	event.SetExtension("sequence", 42)
+
+	testCases := map[string]struct {
+		keyAttribute string
+		want         string
+	}{
+		"unset":                  {want: "customer-1"},
+		"partitionkey extension": {keyAttribute: DefaultKeyAttribute, want: "customer-1"},
+		"context attribute":      {keyAttribute: "subject", want: "order-1"},
+		"extension":              {keyAttribute: "tenant", want: "tenant-1"},
+		"integer extension":      {keyAttribute: "sequence", want: "42"},
+		"missing attribute":      {keyAttribute: "region"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			producerMessage := &sarama.ProducerMessage{Topic: "topic"}
+			if err := WriteProducerMessage(context.Background(), binding.ToMessage(&event), producerMessage, tc.keyAttribute); err != nil {
+				t.Fatalf("WriteProducerMessage() = %v", err)
+			}
+			if tc.want == "" {
+				if producerMessage.Key != nil {
+					t.Errorf("Key = %v, want none", producerMessage.Key)
+				}
+				return
+			}
+			if producerMessage.Key == nil {
+				t.Fatalf("Key = nil, want %q", tc.want)
+			}
+			key, _ := producerMessage.Key.Encode()
+			if string(key) != tc.want {
+				t.Errorf("Key = %q, want %q", key, tc.want)
+			}
+		})
+	}
+}